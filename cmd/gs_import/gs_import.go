@@ -4,33 +4,64 @@ import (
 	"context"
 	"errors"
 	"log"
-	"time"
+	"os"
+	"strings"
+	// База часовых поясов встраивается в бинарник: в образе её может не быть.
+	_ "time/tzdata"
 
 	"github.com/zhikh23/sm-instruction/internal/adapters"
 
 	"github.com/zhikh23/sm-instruction/internal/domain/sm"
 )
 
-const slotDuration = 20 * time.Minute
-
 func main() {
 	ctx := context.Background()
 
-	activitiesProvider := adapters.NewDefaultGSActivitiesProvider()
+	rules, err := adapters.NewDefaultGSEventRulesProvider().Rules(ctx)
+	if errors.Is(err, sm.ErrEventRulesNotFound) {
+		rules, err = adapters.NewEnvEventRulesProvider().Rules(ctx)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	skills, err := adapters.NewDefaultGSSkillsProvider().Skills(ctx)
+	if errors.Is(err, sm.ErrSkillCatalogueNotFound) {
+		skills, err = sm.DefaultSkillCatalogue(), nil
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	event, err := adapters.NewEnvEventProvider(rules, skills).Event(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	activitiesProvider := adapters.NewDefaultGSActivitiesProvider(event)
 	activities, err := activitiesProvider.Activities(ctx)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	quizzes, err := adapters.NewDefaultGSQuizzesProvider(event, activities).Quizzes(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	charactersProvider := adapters.NewDefaultGSCharactersProvider()
 	templateCharacters, err := charactersProvider.Characters(ctx)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	mapGroupToUsername := make(map[string]string)
-	for _, char := range templateCharacters {
-		mapGroupToUsername[char.GroupName] = char.Username
+	eventsRepos, closeEvents := adapters.NewPGEventsRepository()
+	defer func() {
+		_ = closeEvents()
+	}()
+
+	if err = eventsRepos.Save(ctx, event); err != nil && !errors.Is(err, sm.ErrEventAlreadyExists) {
+		log.Fatalf("Failed to save event %s: %s", event.ID, err.Error())
 	}
 
 	usersRepos, closeUsers := adapters.NewPGUsersRepository()
@@ -48,16 +79,10 @@ func main() {
 		_ = closeActivities()
 	}()
 
-	groups := make(map[string]bool)
-	for _, act := range activities {
-		for _, slot := range act.Slots {
-			if !slot.IsAvailable() {
-				groups[*slot.Whom] = true
-			}
-		}
-	}
-
-	times := slotTimes()
+	quizzesRepos, closeQuizzes := adapters.NewPGQuizzesRepository()
+	defer func() {
+		_ = closeQuizzes()
+	}()
 
 	users := make(map[string]sm.User)
 	for _, act := range activities {
@@ -66,72 +91,81 @@ func main() {
 		}
 	}
 
-	chars := make(map[string]*sm.Character, len(groups))
-	for group := range groups {
-		username, ok := mapGroupToUsername[group]
-		if !ok {
-			log.Fatalf("GroupName for group %s not found", group)
+	// Персонажи создаются для всех групп: расписание можно сгенерировать
+	// командой scheduler, а брони из таблицы активностей необязательны.
+	chars := make(map[string]*sm.Character, len(templateCharacters))
+	for _, tmpl := range templateCharacters {
+		if err = event.ValidateGroupName(tmpl.GroupName); err != nil {
+			log.Fatalf("Invalid group name %s: %s", tmpl.GroupName, err.Error())
+		}
+		char := sm.MustNewCharacter(tmpl.GroupName, tmpl.Username, event.EmptySlots())
+		for _, member := range tmpl.Members {
+			if member.Username != tmpl.Username {
+				if err = char.AddMember(member.Username, member.Role); err != nil {
+					log.Fatalf("Failed to add member %s to group %s: %s", member.Username, tmpl.GroupName, err.Error())
+				}
+			}
+			users[member.Username] = sm.MustNewUser(member.Username, sm.Participant)
 		}
-		user := sm.MustNewUser(username, sm.Participant)
-		users[user.Username] = user
-		char := sm.MustNewCharacter(group, username, emptySlots(times))
 		chars[char.GroupName] = char
 	}
 
+	for _, act := range activities {
+		for _, slot := range act.Slots {
+			for _, b := range slot.Bookings {
+				if _, ok := chars[b.Whom]; !ok {
+					log.Fatalf("Group %s booked at %q not found in characters", b.Whom, act.Name)
+				}
+			}
+		}
+	}
+
+	// Организаторы перечисляются через запятую: EVENT_ORGANIZERS="@first, @second".
+	for _, username := range strings.Split(os.Getenv("EVENT_ORGANIZERS"), ",") {
+		username = strings.TrimPrefix(strings.TrimSpace(username), "@")
+		if username != "" {
+			users[username] = sm.MustNewUser(username, sm.Organizer)
+		}
+	}
+
 	for _, user := range users {
-		err = usersRepos.Save(ctx, user)
+		err = usersRepos.Save(ctx, event.ID, user)
 		if err != nil && !errors.Is(err, sm.ErrUserAlreadyExists) {
 			log.Fatalf("Failed to save user %s: %s", user.Username, err.Error())
 		}
 	}
 
 	for _, char := range chars {
-		err = charsRepos.Save(ctx, char)
+		err = charsRepos.Save(ctx, event.ID, char)
 		if err != nil && !errors.Is(err, sm.ErrCharacterAlreadyExists) {
 			log.Fatalf("Failed to save character %s: %s", char.GroupName, err.Error())
 		}
 	}
 
 	for _, act := range activities {
-		err = activitiesRepos.Save(ctx, act)
+		err = activitiesRepos.Save(ctx, event.ID, act)
 		if err != nil && !errors.Is(err, sm.ErrActivityAlreadyExists) {
 			log.Fatalf("Failed to save actitvity %q: %s\n", act.Name, err.Error())
 		}
 	}
 
+	for _, quiz := range quizzes {
+		err = quizzesRepos.Save(ctx, event.ID, quiz)
+		if err != nil && !errors.Is(err, sm.ErrQuizAlreadyExists) {
+			log.Fatalf("Failed to save quiz of activity %q: %s\n", quiz.ActivityName, err.Error())
+		}
+	}
+
 	for _, act := range activities {
 		for _, slot := range act.Slots {
-			if slot.IsAvailable() {
-				continue
-			}
-			group := *slot.Whom
-			if err = charsRepos.Update(ctx, group, func(innerCtx context.Context, char *sm.Character) error {
-				return char.TakeSlot(slot.Start, act.Name)
-			}); err != nil {
-				log.Fatalf("Failed to update char %s: %s", group, err.Error())
+			for _, b := range slot.Bookings {
+				group := b.Whom
+				if err = charsRepos.Update(ctx, event.ID, group, func(innerCtx context.Context, char *sm.Character) error {
+					return char.TakeSlot(slot.Start, act.Name, event.Rules)
+				}); err != nil {
+					log.Fatalf("Failed to update char %s: %s", group, err.Error())
+				}
 			}
 		}
 	}
 }
-
-func slotTimes() []time.Time {
-	times := make([]time.Time, 0)
-	first := todayTime(11, 20)
-	last := todayTime(17, 20)
-	for it := first; !it.After(last); it = it.Add(slotDuration) {
-		times = append(times, it)
-	}
-	return times
-}
-
-func todayTime(hours int, minutes int) time.Time {
-	return time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), hours, minutes, 0, 0, time.Local)
-}
-
-func emptySlots(times []time.Time) []*sm.Slot {
-	slots := make([]*sm.Slot, len(times))
-	for i, t := range times {
-		slots[i] = sm.MustNewSlot(t, t.Add(slotDuration))
-	}
-	return slots
-}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+	// База часовых поясов встраивается в бинарник: в образе её может не быть.
+	_ "time/tzdata"
+
+	"github.com/zhikh23/sm-instruction/internal/app"
+	"github.com/zhikh23/sm-instruction/internal/app/command"
+	"github.com/zhikh23/sm-instruction/internal/app/query"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+	"github.com/zhikh23/sm-instruction/internal/service"
+)
+
+// groupConstraints описывает пожелания группы в файле ограничений:
+//
+//	{"СМ1-11Б": {"not_before": "12:00", "not_after": "15:00", "excluded": ["ЦМР"]}}
+type groupConstraints struct {
+	NotBefore string   `json:"not_before"`
+	NotAfter  string   `json:"not_after"`
+	Excluded  []string `json:"excluded"`
+}
+
+func main() {
+	apply := flag.Bool("apply", false, "save generated timetable; without it only a report is printed")
+	eventID := flag.String("event", "", "event ID; the current event is used by default")
+	constraintsFile := flag.String("constraints", "", "path to JSON file with per-group constraints")
+	flag.Parse()
+
+	ctx := context.Background()
+
+	application, closeFn := service.NewApplication()
+	defer func() {
+		if err := closeFn(); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	event, err := findEvent(ctx, application, *eventID)
+	if err != nil {
+		log.Fatalf("Failed to find event: %s", err.Error())
+	}
+
+	constraints, err := readConstraints(*constraintsFile, event)
+	if err != nil {
+		log.Fatalf("Failed to read constraints: %s", err.Error())
+	}
+
+	plan, err := application.Queries.PlanTimetable.Handle(ctx, query.PlanTimetable{
+		EventID:     event.ID,
+		Constraints: constraints,
+	})
+	if err != nil {
+		log.Fatalf("Failed to plan timetable: %s", err.Error())
+	}
+
+	printReport(plan, event)
+
+	if !*apply {
+		fmt.Println("\nDry run: nothing saved. Run with -apply to book the slots.")
+		fmt.Println("Slots are booked one by one: if some bookings fail, the rest are still saved.")
+		return
+	}
+
+	assignments := make([]command.TimetableAssignment, len(plan.Assignments))
+	for i, a := range plan.Assignments {
+		assignments[i] = command.TimetableAssignment{
+			GroupName:    a.GroupName,
+			ActivityName: a.ActivityName,
+			Start:        a.Start,
+		}
+	}
+
+	err = application.Commands.ApplyTimetable.Handle(ctx, command.ApplyTimetable{
+		EventID:     event.ID,
+		Assignments: assignments,
+	})
+	if err != nil {
+		log.Fatalf("Failed to apply timetable, other bookings are saved: %s", err.Error())
+	}
+
+	fmt.Printf("\nBooked %d slots.\n", len(assignments))
+}
+
+func findEvent(ctx context.Context, application *app.Application, eventID string) (query.Event, error) {
+	if eventID == "" {
+		return application.Queries.CurrentEvent.Handle(ctx, query.CurrentEvent{})
+	}
+	return application.Queries.GetEvent.Handle(ctx, query.GetEvent{EventID: eventID})
+}
+
+func readConstraints(path string, event query.Event) (map[string]query.GroupConstraints, error) {
+	res := make(map[string]query.GroupConstraints)
+	if path == "" {
+		return res, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]groupConstraints
+	if err = json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	for groupName, c := range raw {
+		notBefore, err := parseEventTime(c.NotBefore, event)
+		if err != nil {
+			return nil, fmt.Errorf("group %s: %w", groupName, err)
+		}
+
+		notAfter, err := parseEventTime(c.NotAfter, event)
+		if err != nil {
+			return nil, fmt.Errorf("group %s: %w", groupName, err)
+		}
+
+		res[groupName] = query.GroupConstraints{
+			NotBefore:          notBefore,
+			NotAfter:           notAfter,
+			ExcludedActivities: c.Excluded,
+		}
+	}
+
+	return res, nil
+}
+
+func parseEventTime(s string, event query.Event) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	t, err := sm.ParseTimeAt(event.Date, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func printReport(plan query.TimetablePlan, event query.Event) {
+	assignments := plan.Assignments
+	sort.SliceStable(assignments, func(i, j int) bool {
+		if assignments[i].GroupName != assignments[j].GroupName {
+			return assignments[i].GroupName < assignments[j].GroupName
+		}
+		return assignments[i].Start.Before(assignments[j].Start)
+	})
+
+	fmt.Printf("Timetable for %s (%s): %d new bookings\n\n", event.Name, event.ID, len(assignments))
+	for _, a := range assignments {
+		fmt.Printf("%-10s %s  %s\n", a.GroupName, a.Start.In(event.Timezone).Format(sm.TimeFormat), a.ActivityName)
+	}
+
+	groups := plan.Groups
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].GroupName < groups[j].GroupName
+	})
+
+	fmt.Println("\nGroups:")
+	for _, g := range groups {
+		skills := make([]string, 0, len(g.Skills))
+		for skill, n := range g.Skills {
+			skills = append(skills, fmt.Sprintf("%s=%d", skill, n))
+		}
+		sort.Strings(skills)
+
+		line := fmt.Sprintf("%-10s %d/%d", g.GroupName, g.TakenSlots, g.TakenSlots+g.MissingSlots)
+		if g.MissingSlots > 0 {
+			line += fmt.Sprintf(" (missing %d)", g.MissingSlots)
+		}
+		fmt.Printf("%s  %s\n", line, strings.Join(skills, " "))
+	}
+}
@@ -1,15 +1,26 @@
 package main
 
 import (
+	"context"
 	"log"
+	"os"
+	"time"
+	// База часовых поясов встраивается в бинарник: в образе её может не быть.
+	_ "time/tzdata"
 
 	"github.com/vitaliy-ukiru/fsm-telebot/v2"
+	"gopkg.in/telebot.v3"
 
 	"github.com/zhikh23/sm-instruction/internal/common/server"
 	"github.com/zhikh23/sm-instruction/internal/ports/telegram"
 	"github.com/zhikh23/sm-instruction/internal/service"
 )
 
+const (
+	defaultNoShowCheckInterval   = time.Minute
+	defaultWaitlistCheckInterval = time.Minute
+)
+
 func main() {
 	app, closeFn := service.NewApplication()
 	defer func() {
@@ -19,7 +30,29 @@ func main() {
 		}
 	}()
 
-	server.RunTelegramServer(func(m *fsm.Manager, dp fsm.Dispatcher) {
-		telegram.NewTelegramPort(app).RegisterFSMManager(m, dp)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	noShowInterval := durationFromEnv("NO_SHOW_CHECK_INTERVAL", defaultNoShowCheckInterval)
+	waitlistInterval := durationFromEnv("WAITLIST_CHECK_INTERVAL", defaultWaitlistCheckInterval)
+
+	server.RunTelegramServer(func(bot *telebot.Bot, m *fsm.Manager, dp fsm.Dispatcher) {
+		port := telegram.NewTelegramPort(app)
+		port.RegisterFSMManager(m, dp)
+		go port.RunNoShowReleaser(ctx, bot, noShowInterval)
+		go port.RunWaitlistProcessor(ctx, bot, waitlistInterval)
 	})
 }
+
+func durationFromEnv(key string, def time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return def
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Fatalf("failed to parse %s: %s", key, err.Error())
+	}
+	return d
+}
@@ -0,0 +1,193 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+const (
+	defaultEventName     = "Инструктаж"
+	defaultEventTimezone = "Europe/Moscow"
+)
+
+type envEventProvider struct {
+	rules  sm.EventRules
+	skills sm.SkillCatalogue
+}
+
+func NewEnvEventProvider(rules sm.EventRules, skills sm.SkillCatalogue) sm.EventProvider {
+	return &envEventProvider{rules: rules, skills: skills}
+}
+
+// Event собирает событие из переменных окружения EVENT_ID, EVENT_NAME,
+// EVENT_DATE, EVENT_TIMEZONE, EVENT_GROUP_NAME_PATTERN, EVENT_TRAVEL_TIMES и
+// настроек рейтинга EVENT_RATING_*.
+//
+// Дата проведения обязательна: от неё строятся слоты, и импорт накануне или
+// после полуночи по UTC не должен сдвигать их на другой день. По умолчанию
+// идентификатором события служит дата проведения.
+func (p *envEventProvider) Event(_ context.Context) (*sm.Event, error) {
+	timezone := getEnvOrDefault("EVENT_TIMEZONE", defaultEventTimezone)
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load event timezone: %w", err)
+	}
+
+	dateStr := os.Getenv("EVENT_DATE")
+	if dateStr == "" {
+		return nil, errors.New("EVENT_DATE environment variable is not set")
+	}
+
+	date, err := time.ParseInLocation(sm.DateFormat, dateStr, loc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse event date: %w", err)
+	}
+
+	id := getEnvOrDefault("EVENT_ID", date.Format(sm.DateFormat))
+	name := getEnvOrDefault("EVENT_NAME", defaultEventName)
+
+	travelTimes, err := parseTravelTimes(os.Getenv("EVENT_TRAVEL_TIMES"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse travel times: %w", err)
+	}
+
+	ratingPolicy, err := parseRatingPolicy(
+		p.rules,
+		p.skills,
+		os.Getenv("EVENT_RATING_WEIGHTS"),
+		os.Getenv("EVENT_RATING_CAPS"),
+		os.Getenv("EVENT_RATING_BALANCE_BONUS"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse rating policy: %w", err)
+	}
+
+	groupNamePattern := getEnvOrDefault("EVENT_GROUP_NAME_PATTERN", sm.DefaultGroupNamePattern)
+
+	return sm.NewEvent(id, name, date, timezone, groupNamePattern, p.rules, travelTimes, p.skills, ratingPolicy)
+}
+
+// parseRatingPolicy собирает политику рейтинга из весов вида "Творческие=1.5; Спортивные=2",
+// потолков вида "Спортивные=10" и надбавки за баланс. Не указанные навыки имеют вес 1
+// и не ограничены потолком.
+func parseRatingPolicy(
+	rules sm.EventRules,
+	catalogue sm.SkillCatalogue,
+	weightsStr string,
+	capsStr string,
+	balanceBonusStr string,
+) (*sm.SkillsRatingPolicy, error) {
+	skills := make(map[sm.SkillType]sm.SkillRating)
+	for _, skill := range catalogue.Types() {
+		skills[skill] = sm.SkillRating{Skill: skill, Weight: 1}
+	}
+
+	weights, err := parseSkillValues(catalogue, weightsStr)
+	if err != nil {
+		return nil, err
+	}
+	for skill, v := range weights {
+		sr := skills[skill]
+		sr.Weight, err = strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse weight of skill %q: %w", skill.String(), err)
+		}
+		skills[skill] = sr
+	}
+
+	caps, err := parseSkillValues(catalogue, capsStr)
+	if err != nil {
+		return nil, err
+	}
+	for skill, v := range caps {
+		sr := skills[skill]
+		sr.Cap, err = strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse cap of skill %q: %w", skill.String(), err)
+		}
+		skills[skill] = sr
+	}
+
+	balanceBonus := 0.0
+	if balanceBonusStr = strings.TrimSpace(balanceBonusStr); balanceBonusStr != "" {
+		balanceBonus, err = strconv.ParseFloat(balanceBonusStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse balance bonus: %w", err)
+		}
+	}
+
+	list := make([]sm.SkillRating, 0, len(skills))
+	for _, skill := range catalogue.Types() {
+		list = append(list, skills[skill])
+	}
+	return sm.NewSkillsRatingPolicy(catalogue, rules.RatingLambda, list, balanceBonus)
+}
+
+func parseSkillValues(catalogue sm.SkillCatalogue, s string) (map[sm.SkillType]string, error) {
+	res := make(map[sm.SkillType]string)
+	for _, item := range strings.Split(s, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected value in format skill=value, got %q", item)
+		}
+
+		skill, err := catalogue.Parse(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		res[skill] = strings.TrimSpace(value)
+	}
+	return res, nil
+}
+
+// parseTravelTimes разбирает матрицу времени на дорогу вида "ГЗ|УЛК=15m; ГЗ|СК=10m".
+func parseTravelTimes(s string) (sm.TravelTimes, error) {
+	list := make([]sm.TravelTime, 0)
+	for _, item := range strings.Split(s, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		zones, durationStr, ok := strings.Cut(item, "=")
+		if !ok {
+			return sm.TravelTimes{}, fmt.Errorf("expected travel time in format from|to=duration, got %q", item)
+		}
+
+		from, to, ok := strings.Cut(zones, "|")
+		if !ok {
+			return sm.TravelTimes{}, fmt.Errorf("expected zones in format from|to, got %q", zones)
+		}
+
+		d, err := time.ParseDuration(strings.TrimSpace(durationStr))
+		if err != nil {
+			return sm.TravelTimes{}, err
+		}
+
+		list = append(list, sm.TravelTime{
+			From:     strings.TrimSpace(from),
+			To:       strings.TrimSpace(to),
+			Duration: d,
+		})
+	}
+	return sm.NewTravelTimes(list)
+}
+
+func getEnvOrDefault(key string, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,152 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+const (
+	instructionDurationKey     = "instruction_duration"
+	maxTakenSlotsKey           = "max_taken_slots"
+	minDurationBeforeKey       = "min_duration_before"
+	minDurationBeforeCancelKey = "min_duration_before_cancel"
+	ratingLambdaKey            = "rating_lambda"
+	slotDurationKey            = "slot_duration"
+	firstSlotStartKey          = "first_slot_start"
+	lastSlotStartKey           = "last_slot_start"
+	awardGracePeriodKey        = "award_grace_period"
+	noShowTimeoutKey           = "no_show_timeout"
+	waitlistOfferTimeoutKey    = "waitlist_offer_timeout"
+	maxPenaltyPointsKey        = "max_penalty_points"
+	maxTotalPenaltyPointsKey   = "max_total_penalty_points"
+	maxCodeAttemptsKey         = "max_code_attempts"
+	codeLockoutKey             = "code_lockout"
+)
+
+var eventRulesKeys = []string{
+	instructionDurationKey,
+	maxTakenSlotsKey,
+	minDurationBeforeKey,
+	minDurationBeforeCancelKey,
+	ratingLambdaKey,
+	slotDurationKey,
+	firstSlotStartKey,
+	lastSlotStartKey,
+	awardGracePeriodKey,
+	noShowTimeoutKey,
+	waitlistOfferTimeoutKey,
+	maxPenaltyPointsKey,
+	maxTotalPenaltyPointsKey,
+	maxCodeAttemptsKey,
+	codeLockoutKey,
+}
+
+type envEventRulesProvider struct {
+	prefix string
+}
+
+func NewEnvEventRulesProvider() sm.EventRulesProvider {
+	return &envEventRulesProvider{prefix: "EVENT_"}
+}
+
+func (p *envEventRulesProvider) Rules(_ context.Context) (sm.EventRules, error) {
+	values := make(map[string]string)
+	for _, key := range eventRulesKeys {
+		if v, ok := os.LookupEnv(p.prefix + strings.ToUpper(key)); ok {
+			values[key] = v
+		}
+	}
+	return parseEventRules(values)
+}
+
+// parseEventRules собирает правила из строковых значений, недостающие значения
+// берутся из правил по умолчанию.
+func parseEventRules(values map[string]string) (sm.EventRules, error) {
+	rules := sm.DefaultEventRules()
+
+	var err error
+	for key, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+
+		switch key {
+		case instructionDurationKey:
+			rules.InstructionDuration, err = time.ParseDuration(value)
+		case maxTakenSlotsKey:
+			rules.MaxTakenSlots, err = strconv.Atoi(value)
+		case minDurationBeforeKey:
+			rules.MinDurationBefore, err = time.ParseDuration(value)
+		case minDurationBeforeCancelKey:
+			rules.MinDurationBeforeCancel, err = time.ParseDuration(value)
+		case ratingLambdaKey:
+			rules.RatingLambda, err = parseFloatOrFraction(value)
+		case slotDurationKey:
+			rules.SlotDuration, err = time.ParseDuration(value)
+		case firstSlotStartKey:
+			rules.FirstSlotStart, err = parseTimeOfDay(value)
+		case lastSlotStartKey:
+			rules.LastSlotStart, err = parseTimeOfDay(value)
+		case awardGracePeriodKey:
+			rules.AwardGracePeriod, err = time.ParseDuration(value)
+		case noShowTimeoutKey:
+			rules.NoShowTimeout, err = time.ParseDuration(value)
+		case waitlistOfferTimeoutKey:
+			rules.WaitlistOfferTimeout, err = time.ParseDuration(value)
+		case maxPenaltyPointsKey:
+			rules.MaxPenaltyPoints, err = strconv.Atoi(value)
+		case maxTotalPenaltyPointsKey:
+			rules.MaxTotalPenaltyPoints, err = strconv.Atoi(value)
+		case maxCodeAttemptsKey:
+			rules.MaxCodeAttempts, err = strconv.Atoi(value)
+		case codeLockoutKey:
+			rules.CodeLockout, err = time.ParseDuration(value)
+		default:
+			return sm.EventRules{}, fmt.Errorf("unknown event rule %q", key)
+		}
+		if err != nil {
+			return sm.EventRules{}, fmt.Errorf("failed to parse event rule %q: %w", key, err)
+		}
+	}
+
+	return sm.NewEventRules(rules)
+}
+
+// parseFloatOrFraction понимает как десятичную запись, так и дробь вида "1/72".
+func parseFloatOrFraction(s string) (float64, error) {
+	num, den, isFraction := strings.Cut(s, "/")
+	if !isFraction {
+		return strconv.ParseFloat(s, 64)
+	}
+
+	n, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+	if err != nil {
+		return 0, err
+	}
+
+	d, err := strconv.ParseFloat(strings.TrimSpace(den), 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if d == 0 {
+		return 0, fmt.Errorf("division by zero in %q", s)
+	}
+
+	return n / d, nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse(sm.TimeFormat, s)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
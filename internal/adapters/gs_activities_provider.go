@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
-	"time"
+	"strings"
 
 	"golang.org/x/net/context"
 	"golang.org/x/oauth2/google"
@@ -13,13 +13,12 @@ import (
 	"github.com/zhikh23/sm-instruction/internal/domain/sm"
 )
 
-const slotDuration = 20 * time.Minute
-
 type gsActivitiesProvider struct {
-	s ss.Spreadsheet
+	s     ss.Spreadsheet
+	event *sm.Event
 }
 
-func NewDefaultGSActivitiesProvider() sm.ActivitiesProvider {
+func NewDefaultGSActivitiesProvider(event *sm.Event) sm.ActivitiesProvider {
 	credentialsFile := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS_FILE")
 	if credentialsFile == "" {
 		panic("GOOGLE_APPLICATION_CREDENTIALS_FILE environment variable is not set")
@@ -30,10 +29,14 @@ func NewDefaultGSActivitiesProvider() sm.ActivitiesProvider {
 		panic("GOOGLE_SPREADSHEET_ID environment variable is not set")
 	}
 
-	return NewGSActivitiesProvider(credentialsFile, spreadsheetID)
+	return NewGSActivitiesProvider(credentialsFile, spreadsheetID, event)
 }
 
-func NewGSActivitiesProvider(credentialsFile string, spreadsheetID string) sm.ActivitiesProvider {
+func NewGSActivitiesProvider(
+	credentialsFile string,
+	spreadsheetID string,
+	event *sm.Event,
+) sm.ActivitiesProvider {
 	data, err := os.ReadFile(credentialsFile)
 	checkError(err)
 
@@ -46,7 +49,8 @@ func NewGSActivitiesProvider(credentialsFile string, spreadsheetID string) sm.Ac
 	checkError(err)
 
 	return &gsActivitiesProvider{
-		s: spreadsheet,
+		s:     spreadsheet,
+		event: event,
 	}
 }
 
@@ -56,16 +60,26 @@ func (p *gsActivitiesProvider) Activities(_ context.Context) ([]*sm.Activity, er
 		return nil, err
 	}
 
+	// Строк расписания столько же, сколько слотов по правилам мероприятия;
+	// время в листе должно с ними совпадать.
 	column := sheet.Columns[1]
 	start := 11
-	total := 18
-	times := make([]time.Time, total)
+	times := p.event.Rules.SlotTimes(p.event.Date)
+	total := len(times)
+	if len(column) < start+total {
+		return nil, fmt.Errorf("expected %d slot rows in timetable, got %d", total, max(len(column)-start, 0))
+	}
 	for i, cell := range column[start : start+total] {
-		t, err := time.Parse(sm.TimeFormat, cell.Value)
+		t, err := p.event.ParseTime(cell.Value)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse time in column 0 row %d: %w", i+5, err)
+			return nil, fmt.Errorf("failed to parse time in column 1 row %d: %w", start+i+1, err)
+		}
+		if !t.Equal(times[i]) {
+			return nil, fmt.Errorf(
+				"expected slot time %s in column 1 row %d, got %s",
+				times[i].Format(sm.TimeFormat), start+i+1, t.Format(sm.TimeFormat),
+			)
 		}
-		times[i] = todayTime(t.Hour(), t.Minute())
 	}
 
 	activities := make([]*sm.Activity, 0)
@@ -94,6 +108,12 @@ func (p *gsActivitiesProvider) Activities(_ context.Context) ([]*sm.Activity, er
 		desc := column[6].Value
 		location := column[7].Value
 
+		// Зона указывается в строке под вместимостью слотов.
+		zone := ""
+		if len(column) > start+total+1 {
+			zone = strings.TrimSpace(column[start+total+1].Value)
+		}
+
 		maxSkills := 2
 		skills := make([]sm.SkillType, 0, maxSkills)
 		for j := 0; j < maxSkills; j++ {
@@ -101,7 +121,7 @@ func (p *gsActivitiesProvider) Activities(_ context.Context) ([]*sm.Activity, er
 			if skillName == "" {
 				break
 			}
-			skill, err := sm.NewSkillTypeFromString(skillName)
+			skill, err := p.event.Skills.Parse(skillName)
 			if err != nil {
 				return nil, err
 			}
@@ -117,15 +137,28 @@ func (p *gsActivitiesProvider) Activities(_ context.Context) ([]*sm.Activity, er
 			}
 		}
 
+		// Вместимость слотов указывается в строке под расписанием; пустая ячейка — одна группа.
+		capacity := 1
+		if len(column) > start+total {
+			if capacityStr := strings.TrimSpace(column[start+total].Value); capacityStr != "" {
+				capacity, err = strconv.Atoi(capacityStr)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse slot capacity: %w", err)
+				}
+			}
+		}
+
 		slots := make([]*sm.Slot, 0, total)
 		for j := 0; j < total; j++ {
 			startTime := times[j]
-			groupName := column[start+j].Value
-			slot, err := sm.NewSlot(startTime, startTime.Add(slotDuration))
+			groupsNames := sm.SplitGroupNames(column[start+j].Value)
+			slot, err := sm.NewSlotWithCapacity(
+				startTime, startTime.Add(p.event.Rules.SlotDuration), max(capacity, len(groupsNames)),
+			)
 			if err != nil {
 				return nil, err
 			}
-			if groupName != "" {
+			for _, groupName := range groupsNames {
 				err = slot.Take(groupName)
 				if err != nil {
 					return nil, err
@@ -139,6 +172,7 @@ func (p *gsActivitiesProvider) Activities(_ context.Context) ([]*sm.Activity, er
 			fullName,
 			pointerIfNotEmpty(desc),
 			pointerIfNotEmpty(location),
+			pointerIfNotEmpty(zone),
 			admins,
 			skills,
 			maxPoints,
@@ -159,10 +193,6 @@ func checkError(err error) {
 	}
 }
 
-func todayTime(hours int, minutes int) time.Time {
-	return time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), hours, minutes, 0, 0, time.Local)
-}
-
 func pointerIfNotEmpty(s string) *string {
 	if s != "" {
 		return &s
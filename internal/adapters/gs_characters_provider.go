@@ -3,6 +3,8 @@ package adapters
 import (
 	"context"
 	"os"
+	"strings"
+	"unicode"
 
 	"golang.org/x/oauth2/google"
 	ss "gopkg.in/Iwark/spreadsheet.v2"
@@ -56,8 +58,36 @@ func (p *gsCharactersProvider) Characters(ctx context.Context) ([]*sm.Character,
 		group := row[0].Value
 		username := row[1].Value[1:]
 		char := sm.MustNewCharacter(group, username, nil)
+
+		// В третьей колонке перечислены остальные участники группы.
+		if len(row) > 2 {
+			for _, member := range splitUsernames(row[2].Value) {
+				if member == username {
+					continue
+				}
+				if err = char.AddMember(member, sm.TeamMember); err != nil {
+					return nil, err
+				}
+			}
+		}
+
 		chars = append(chars, char)
 	}
 
 	return chars, nil
 }
+
+// splitUsernames разбирает ники вида "@first, @second" или по одному на строке.
+func splitUsernames(s string) []string {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ';' || unicode.IsSpace(r)
+	})
+
+	res := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f = strings.TrimPrefix(f, "@"); f != "" {
+			res = append(res, f)
+		}
+	}
+	return res
+}
@@ -0,0 +1,68 @@
+package adapters
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"golang.org/x/oauth2/google"
+	ss "gopkg.in/Iwark/spreadsheet.v2"
+
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type gsEventRulesProvider struct {
+	s ss.Spreadsheet
+}
+
+func NewDefaultGSEventRulesProvider() sm.EventRulesProvider {
+	credentialsFile := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS_FILE")
+	if credentialsFile == "" {
+		panic("GOOGLE_APPLICATION_CREDENTIALS_FILE environment variable is not set")
+	}
+
+	spreadsheetID := os.Getenv("GOOGLE_SPREADSHEET_ID")
+	if spreadsheetID == "" {
+		panic("GOOGLE_SPREADSHEET_ID environment variable is not set")
+	}
+
+	return NewGSEventRulesProvider(credentialsFile, spreadsheetID)
+}
+
+func NewGSEventRulesProvider(credentialsFile string, spreadsheetID string) sm.EventRulesProvider {
+	data, err := os.ReadFile(credentialsFile)
+	checkError(err)
+
+	conf, err := google.JWTConfigFromJSON(data, ss.Scope)
+	checkError(err)
+
+	client := conf.Client(context.Background())
+	service := ss.NewServiceWithClient(client)
+	spreadsheet, err := service.FetchSpreadsheet(spreadsheetID)
+	checkError(err)
+
+	return &gsEventRulesProvider{
+		s: spreadsheet,
+	}
+}
+
+func (p *gsEventRulesProvider) Rules(_ context.Context) (sm.EventRules, error) {
+	sheet, err := p.s.SheetByTitle("EXPORT RULES")
+	if err != nil {
+		return sm.EventRules{}, sm.ErrEventRulesNotFound
+	}
+
+	values := make(map[string]string)
+	for _, row := range sheet.Rows[1:] {
+		if len(row) < 2 {
+			continue
+		}
+		key := strings.TrimSpace(row[0].Value)
+		if key == "" {
+			continue
+		}
+		values[key] = row[1].Value
+	}
+
+	return parseEventRules(values)
+}
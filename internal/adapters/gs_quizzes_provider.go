@@ -0,0 +1,157 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2/google"
+	ss "gopkg.in/Iwark/spreadsheet.v2"
+
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type gsQuizzesProvider struct {
+	s          ss.Spreadsheet
+	event      *sm.Event
+	activities []*sm.Activity
+}
+
+func NewDefaultGSQuizzesProvider(event *sm.Event, activities []*sm.Activity) sm.QuizzesProvider {
+	credentialsFile := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS_FILE")
+	if credentialsFile == "" {
+		panic("GOOGLE_APPLICATION_CREDENTIALS_FILE environment variable is not set")
+	}
+
+	spreadsheetID := os.Getenv("GOOGLE_SPREADSHEET_ID")
+	if spreadsheetID == "" {
+		panic("GOOGLE_SPREADSHEET_ID environment variable is not set")
+	}
+
+	return NewGSQuizzesProvider(credentialsFile, spreadsheetID, event, activities)
+}
+
+func NewGSQuizzesProvider(
+	credentialsFile string,
+	spreadsheetID string,
+	event *sm.Event,
+	activities []*sm.Activity,
+) sm.QuizzesProvider {
+	data, err := os.ReadFile(credentialsFile)
+	checkError(err)
+
+	conf, err := google.JWTConfigFromJSON(data, ss.Scope)
+	checkError(err)
+
+	client := conf.Client(context.Background())
+	service := ss.NewServiceWithClient(client)
+	spreadsheet, err := service.FetchSpreadsheet(spreadsheetID)
+	checkError(err)
+
+	return &gsQuizzesProvider{
+		s:          spreadsheet,
+		event:      event,
+		activities: activities,
+	}
+}
+
+// Quizzes читает лист с вопросами викторин: точка, вопрос, варианты ответа,
+// верные ответы, навык и время на ответ в секундах. Варианты и ответы
+// перечисляются через точку с запятой или с новой строки; навык и время
+// необязательны. Первая строка — заголовок. Без листа викторин нет.
+func (p *gsQuizzesProvider) Quizzes(_ context.Context) ([]*sm.Quiz, error) {
+	sheet, err := p.s.SheetByTitle("EXPORT QUIZZES")
+	if err != nil {
+		return make([]*sm.Quiz, 0), nil
+	}
+
+	order := make([]string, 0)
+	questions := make(map[string][]sm.QuizQuestion)
+	for i, row := range sheet.Rows[1:] {
+		if len(row) < 4 {
+			continue
+		}
+		activityName := strings.TrimSpace(row[0].Value)
+		if activityName == "" {
+			continue
+		}
+
+		var skill sm.SkillType
+		if len(row) > 4 {
+			if skillName := strings.TrimSpace(row[4].Value); skillName != "" {
+				skill, err = p.event.Skills.Parse(skillName)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse skill of question in row %d: %w", i+2, err)
+				}
+			}
+		}
+
+		timeLimit := sm.DefaultQuizQuestionTime
+		if len(row) > 5 {
+			if secondsStr := strings.TrimSpace(row[5].Value); secondsStr != "" {
+				seconds, err := strconv.Atoi(secondsStr)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse time of question in row %d: %w", i+2, err)
+				}
+				timeLimit = time.Duration(seconds) * time.Second
+			}
+		}
+
+		q, err := sm.NewQuizQuestion(
+			strings.TrimSpace(row[1].Value),
+			splitQuizCell(row[2].Value),
+			splitQuizCell(row[3].Value),
+			skill,
+			timeLimit,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse question in row %d: %w", i+2, err)
+		}
+
+		if _, ok := questions[activityName]; !ok {
+			order = append(order, activityName)
+		}
+		questions[activityName] = append(questions[activityName], q)
+	}
+
+	res := make([]*sm.Quiz, 0, len(order))
+	for _, activityName := range order {
+		act, ok := p.activity(activityName)
+		if !ok {
+			return nil, fmt.Errorf("failed to find activity %q of quiz", activityName)
+		}
+
+		quiz, err := act.NewQuiz(questions[activityName])
+		if err != nil {
+			return nil, fmt.Errorf("failed to create quiz of activity %q: %w", activityName, err)
+		}
+		res = append(res, quiz)
+	}
+
+	return res, nil
+}
+
+func (p *gsQuizzesProvider) activity(name string) (*sm.Activity, bool) {
+	for _, act := range p.activities {
+		if act.Name == name {
+			return act, true
+		}
+	}
+	return nil, false
+}
+
+func splitQuizCell(cell string) []string {
+	fields := strings.FieldsFunc(cell, func(r rune) bool {
+		return r == ';' || r == '\n'
+	})
+	res := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f != "" {
+			res = append(res, f)
+		}
+	}
+	return res
+}
@@ -0,0 +1,103 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/oauth2/google"
+	ss "gopkg.in/Iwark/spreadsheet.v2"
+
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type gsSkillsProvider struct {
+	s ss.Spreadsheet
+}
+
+func NewDefaultGSSkillsProvider() sm.SkillsProvider {
+	credentialsFile := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS_FILE")
+	if credentialsFile == "" {
+		panic("GOOGLE_APPLICATION_CREDENTIALS_FILE environment variable is not set")
+	}
+
+	spreadsheetID := os.Getenv("GOOGLE_SPREADSHEET_ID")
+	if spreadsheetID == "" {
+		panic("GOOGLE_SPREADSHEET_ID environment variable is not set")
+	}
+
+	return NewGSSkillsProvider(credentialsFile, spreadsheetID)
+}
+
+func NewGSSkillsProvider(credentialsFile string, spreadsheetID string) sm.SkillsProvider {
+	data, err := os.ReadFile(credentialsFile)
+	checkError(err)
+
+	conf, err := google.JWTConfigFromJSON(data, ss.Scope)
+	checkError(err)
+
+	client := conf.Client(context.Background())
+	service := ss.NewServiceWithClient(client)
+	spreadsheet, err := service.FetchSpreadsheet(spreadsheetID)
+	checkError(err)
+
+	return &gsSkillsProvider{
+		s: spreadsheet,
+	}
+}
+
+// Skills читает лист с навыками: название, эмодзи, категория (основной или
+// дополнительный) и порядок вывода. Первая строка — заголовок.
+func (p *gsSkillsProvider) Skills(_ context.Context) (sm.SkillCatalogue, error) {
+	sheet, err := p.s.SheetByTitle("EXPORT SKILLS")
+	if err != nil {
+		return sm.SkillCatalogue{}, sm.ErrSkillCatalogueNotFound
+	}
+
+	skills := make([]sm.Skill, 0)
+	for i, row := range sheet.Rows[1:] {
+		if len(row) < 3 {
+			continue
+		}
+		name := strings.TrimSpace(row[0].Value)
+		if name == "" {
+			continue
+		}
+
+		category, err := parseSkillCategory(row[2].Value)
+		if err != nil {
+			return sm.SkillCatalogue{}, fmt.Errorf("failed to parse category of skill %q: %w", name, err)
+		}
+
+		// Без явного порядка навыки выводятся в порядке строк.
+		order := i
+		if len(row) > 3 {
+			if orderStr := strings.TrimSpace(row[3].Value); orderStr != "" {
+				order, err = strconv.Atoi(orderStr)
+				if err != nil {
+					return sm.SkillCatalogue{}, fmt.Errorf("failed to parse order of skill %q: %w", name, err)
+				}
+			}
+		}
+
+		skill, err := sm.NewSkill(name, strings.TrimSpace(row[1].Value), category, order)
+		if err != nil {
+			return sm.SkillCatalogue{}, err
+		}
+		skills = append(skills, skill)
+	}
+
+	return sm.NewSkillCatalogue(skills)
+}
+
+func parseSkillCategory(s string) (sm.SkillCategory, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "основной", "основные":
+		return sm.GeneralCategory, nil
+	case "дополнительный", "дополнительные":
+		return sm.AdditionalCategory, nil
+	}
+	return sm.NewSkillCategoryFromString(strings.TrimSpace(s))
+}
@@ -30,15 +30,16 @@ func NewPGActivitiesRepository() (sm.ActivitiesRepository, func() error) {
 
 func (r *pgActivitiesRepository) Save(
 	ctx context.Context,
+	eventID string,
 	activity *sm.Activity,
 ) error {
 	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
 		var err error
 		if err = r.requireExecResult(tx.NamedExecContext(ctx,
 			`INSERT INTO
-					activities (name, full_name, description, location, skills, max_points)
-			 VALUES (:name, :full_name, :description, :location, :skills, :max_points)`,
-			marshallActivityToRow(activity),
+					activities (event_id, name, full_name, description, location, zone, skills, max_points)
+			 VALUES (:event_id, :name, :full_name, :description, :location, :zone, :skills, :max_points)`,
+			marshallActivityToRow(eventID, activity),
 		)); pgutils.IsUniqueViolationError(err) {
 			return sm.ErrActivityAlreadyExists
 		} else if err != nil {
@@ -48,9 +49,9 @@ func (r *pgActivitiesRepository) Save(
 		if len(activity.Admins) > 0 {
 			if err = r.requireExecResult(tx.NamedExecContext(ctx,
 				`INSERT INTO
-				admins (activity_name, username)
-			 VALUES (:activity_name, :username)`,
-				marshallAdminsToRows(activity.Name, activity.Admins),
+				admins (event_id, activity_name, username)
+			 VALUES (:event_id, :activity_name, :username)`,
+				marshallAdminsToRows(eventID, activity.Name, activity.Admins),
 			)); err != nil {
 				return err
 			}
@@ -59,26 +60,27 @@ func (r *pgActivitiesRepository) Save(
 		if len(activity.Slots) > 0 {
 			if err = r.requireExecResult(tx.NamedExecContext(ctx,
 				`INSERT INTO
-					activity_slots (activity_name, start, end_, group_name) 
-			 	 VALUES (:activity_name, :start, :end_, :group_name)`,
-				marshallActivitySlotsToRows(activity.Name, activity.Slots),
+					activity_slots (event_id, activity_name, start, end_, capacity) 
+			 	 VALUES (:event_id, :activity_name, :start, :end_, :capacity)`,
+				marshallActivitySlotsToRows(eventID, activity.Name, activity.Slots),
 			)); err != nil {
 				return err
 			}
 		}
 
-		return nil
+		return r.insertBookings(ctx, tx, eventID, activity)
 	})
 }
 
 func (r *pgActivitiesRepository) Activity(
 	ctx context.Context,
+	eventID string,
 	activityName string,
 ) (*sm.Activity, error) {
 	var res *sm.Activity
 	var err error
 	if err = pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
-		res, err = r.activity(ctx, tx, activityName)
+		res, err = r.activity(ctx, tx, eventID, activityName)
 		return err
 	}); errors.Is(err, sql.ErrNoRows) {
 		return nil, sm.ErrActivityNotFound
@@ -88,18 +90,17 @@ func (r *pgActivitiesRepository) Activity(
 	return res, nil
 }
 
-func (r *pgActivitiesRepository) ActivityByAdmin(
+func (r *pgActivitiesRepository) ActivitiesByAdmin(
 	ctx context.Context,
+	eventID string,
 	adminUsername string,
-) (*sm.Activity, error) {
-	var res *sm.Activity
+) ([]*sm.Activity, error) {
+	var res []*sm.Activity
 	var err error
 	if err = pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
-		res, err = r.activityByAdmin(ctx, tx, adminUsername)
+		res, err = r.activitiesByAdmin(ctx, tx, eventID, adminUsername)
 		return err
-	}); errors.Is(err, sql.ErrNoRows) {
-		return nil, sm.ErrActivityNotFound
-	} else if err != nil {
+	}); err != nil {
 		return nil, err
 	}
 	return res, nil
@@ -107,11 +108,12 @@ func (r *pgActivitiesRepository) ActivityByAdmin(
 
 func (r *pgActivitiesRepository) Activities(
 	ctx context.Context,
+	eventID string,
 ) ([]*sm.Activity, error) {
 	var res []*sm.Activity
 	var err error
 	if err = pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
-		res, err = r.activities(ctx, tx)
+		res, err = r.activities(ctx, tx, eventID)
 		return err
 	}); err != nil {
 		return nil, err
@@ -121,11 +123,12 @@ func (r *pgActivitiesRepository) Activities(
 
 func (r *pgActivitiesRepository) AvailableActivities(
 	ctx context.Context,
+	eventID string,
 ) ([]*sm.Activity, error) {
 	var res []*sm.Activity
 	var err error
 	if err = pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
-		res, err = r.availableActivities(ctx, tx)
+		res, err = r.availableActivities(ctx, tx, eventID)
 		return err
 	}); err != nil {
 		return nil, err
@@ -135,11 +138,12 @@ func (r *pgActivitiesRepository) AvailableActivities(
 
 func (r *pgActivitiesRepository) AdditionalActivities(
 	ctx context.Context,
+	eventID string,
 ) ([]*sm.Activity, error) {
 	var res []*sm.Activity
 	var err error
 	if err = pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
-		res, err = r.additionalActivities(ctx, tx)
+		res, err = r.additionalActivities(ctx, tx, eventID)
 		return err
 	}); err != nil {
 		return nil, err
@@ -149,47 +153,59 @@ func (r *pgActivitiesRepository) AdditionalActivities(
 
 func (r *pgActivitiesRepository) UpdateSlots(
 	ctx context.Context,
-	activityUUID string,
+	eventID string,
+	activityName string,
 	updateFn func(innerCtx context.Context, activity *sm.Activity) error,
 ) error {
 	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
-		activity, err := r.activity(ctx, tx, activityUUID)
+		// Брони точки перезаписываются целиком, поэтому точка блокируется:
+		// иначе параллельная бронь того же слота потеряется.
+		var exists bool
+		err := sqlx.GetContext(ctx, tx, &exists,
+			`SELECT true FROM activities WHERE event_id = $1 AND name = $2 FOR UPDATE`, eventID, activityName,
+		)
 		if errors.Is(err, sql.ErrNoRows) {
 			return sm.ErrActivityNotFound
 		} else if err != nil {
 			return err
 		}
 
+		activity, err := r.activity(ctx, tx, eventID, activityName)
+		if err != nil {
+			return err
+		}
+
 		err = updateFn(ctx, activity)
 		if err != nil {
 			return err
 		}
 
-		return r.updateSlots(ctx, tx, activity)
+		return r.updateSlots(ctx, tx, eventID, activity)
 	})
 }
 
 func (r *pgActivitiesRepository) activity(
 	ctx context.Context,
 	qx sqlx.QueryerContext,
+	eventID string,
 	activityName string,
 ) (*sm.Activity, error) {
 	var err error
 
 	var activityRow activityRow
 	if err = sqlx.GetContext(ctx, qx, &activityRow,
-		`SELECT name, full_name, description, location, skills, max_points 
+		`SELECT event_id, name, full_name, description, location, zone, skills, max_points 
 		 FROM   activities
-		 WHERE  name = $1`, activityName,
+		 WHERE  event_id = $1 AND name = $2`, eventID, activityName,
 	); err != nil {
 		return nil, err
 	}
 
 	var adminsRows []adminRow
 	if err = sqlx.SelectContext(ctx, qx, &adminsRows,
-		`SELECT activity_name, username 
+		`SELECT event_id, activity_name, username 
 		 FROM admins 
-		 WHERE activity_name = $1`, activityRow.Name,
+		 WHERE event_id = $1 AND activity_name = $2`, activityRow.EventID, activityRow.Name,
 	); err != nil {
 		return nil, err
 	}
@@ -198,16 +214,7 @@ func (r *pgActivitiesRepository) activity(
 		return nil, err
 	}
 
-	var slotsRows []activitySlotRow
-	if err = sqlx.SelectContext(ctx, qx, &slotsRows,
-		`SELECT activity_name, start, end_, group_name
-		 FROM activity_slots
-		 WHERE activity_name = $1
-		 ORDER BY start`, activityRow.Name,
-	); err != nil {
-		return nil, err
-	}
-	slots, err := unmarshallActivitySlotsFromRows(slotsRows)
+	slots, err := r.activitySlots(ctx, qx, activityRow.EventID, activityRow.Name)
 	if err != nil {
 		return nil, err
 	}
@@ -217,6 +224,7 @@ func (r *pgActivitiesRepository) activity(
 		activityRow.FullName,
 		activityRow.Description,
 		activityRow.Location,
+		activityRow.Zone,
 		admins,
 		activityRow.Skills,
 		activityRow.MaxPoints,
@@ -224,103 +232,65 @@ func (r *pgActivitiesRepository) activity(
 	)
 }
 
-func (r *pgActivitiesRepository) activityByAdmin(
+func (r *pgActivitiesRepository) activitiesByAdmin(
 	ctx context.Context,
 	qx sqlx.QueryerContext,
+	eventID string,
 	adminUsername string,
-) (*sm.Activity, error) {
-	var err error
-
-	var activityRow activityRow
-	if err = sqlx.GetContext(ctx, qx, &activityRow,
-		`SELECT name, full_name, description, location, skills, max_points 
-		 FROM   activities AS activity
-				LEFT JOIN admins AS admin 
-					   ON admin.activity_name = activity.name
-		 WHERE admin.username = $1`, adminUsername,
-	); err != nil {
-		return nil, err
-	}
-
-	var adminsRows []adminRow
-	if err = sqlx.SelectContext(ctx, qx, &adminsRows,
-		`SELECT activity_name, username 
-		 FROM admins 
-		 WHERE activity_name = $1`, activityRow.Name,
+) ([]*sm.Activity, error) {
+	var names []string
+	if err := sqlx.SelectContext(ctx, qx, &names,
+		`SELECT   activity_name
+		 FROM     admins
+		 WHERE    event_id = $1 AND username = $2
+		 ORDER BY activity_name`, eventID, adminUsername,
 	); err != nil {
 		return nil, err
 	}
-	admins, err := unmarshallAdminsFromRows(adminsRows)
-	if err != nil {
-		return nil, err
-	}
 
-	var slotsRows []activitySlotRow
-	if err = sqlx.SelectContext(ctx, qx, &slotsRows,
-		`SELECT activity_name, start, end_, group_name
-		 FROM activity_slots
-		 WHERE activity_name = $1`, activityRow.Name,
-	); err != nil {
-		return nil, err
-	}
-	slots, err := unmarshallActivitySlotsFromRows(slotsRows)
-	if err != nil {
-		return nil, err
+	activities := make([]*sm.Activity, 0, len(names))
+	for _, name := range names {
+		activity, err := r.activity(ctx, qx, eventID, name)
+		if err != nil {
+			return nil, err
+		}
+		activities = append(activities, activity)
 	}
-
-	return sm.UnmarshallActivityFromDB(
-		activityRow.Name,
-		activityRow.FullName,
-		activityRow.Description,
-		activityRow.Location,
-		admins,
-		activityRow.Skills,
-		activityRow.MaxPoints,
-		slots,
-	)
+	return activities, nil
 }
 
 func (r *pgActivitiesRepository) activities(
 	ctx context.Context,
 	qx sqlx.QueryerContext,
+	eventID string,
 ) ([]*sm.Activity, error) {
 	var err error
 
 	var activityRows []activityRow
 	if err = sqlx.SelectContext(ctx, qx, &activityRows,
-		`SELECT name, full_name, description, location, skills, max_points
+		`SELECT event_id, name, full_name, description, location, zone, skills, max_points
 		 FROM   activities AS activity
-		 WHERE description IS NOT NULL OR location IS NOT NULL
-		 ORDER BY name`,
+		 WHERE event_id = $1 AND (description IS NOT NULL OR location IS NOT NULL)
+		 ORDER BY name`, eventID,
 	); err != nil {
 		return nil, err
 	}
 
 	activities := make([]*sm.Activity, 0, len(activityRows))
 	for _, activityRow := range activityRows {
-		var slotsRows []activitySlotRow
-		if err = sqlx.SelectContext(ctx, qx, &slotsRows,
-			`SELECT activity_name, start, end_, group_name
-			 FROM activity_slots
-			 WHERE activity_name = $1
-			 ORDER BY start`, activityRow.Name,
-		); err != nil {
+		slots, err := r.activitySlots(ctx, qx, activityRow.EventID, activityRow.Name)
+		if err != nil {
 			return nil, err
 		}
-		if len(slotsRows) == 0 {
+		if len(slots) == 0 {
 			continue
 		}
 
-		slots, err := unmarshallActivitySlotsFromRows(slotsRows)
-		if err != nil {
-			return nil, err
-		}
-
 		var adminsRows []adminRow
 		if err = sqlx.SelectContext(ctx, qx, &adminsRows,
-			`SELECT activity_name, username 
+			`SELECT event_id, activity_name, username 
 			 FROM admins 
-			 WHERE activity_name = $1`, activityRow.Name,
+			 WHERE event_id = $1 AND activity_name = $2`, activityRow.EventID, activityRow.Name,
 		); err != nil {
 			return nil, err
 		}
@@ -334,6 +304,7 @@ func (r *pgActivitiesRepository) activities(
 			activityRow.FullName,
 			activityRow.Description,
 			activityRow.Location,
+			activityRow.Zone,
 			admins,
 			activityRow.Skills,
 			activityRow.MaxPoints,
@@ -350,43 +321,34 @@ func (r *pgActivitiesRepository) activities(
 func (r *pgActivitiesRepository) availableActivities(
 	ctx context.Context,
 	qx sqlx.QueryerContext,
+	eventID string,
 ) ([]*sm.Activity, error) {
 	var err error
 
 	var activityRows []activityRow
 	if err = sqlx.SelectContext(ctx, qx, &activityRows,
-		`SELECT name, full_name, description, location, skills, max_points 
+		`SELECT event_id, name, full_name, description, location, zone, skills, max_points 
 		 FROM   activities AS activity
-		 WHERE  location IS NOT NULL`,
+		 WHERE  event_id = $1 AND location IS NOT NULL`, eventID,
 	); err != nil {
 		return nil, err
 	}
 
 	activities := make([]*sm.Activity, 0, len(activityRows))
 	for _, activityRow := range activityRows {
-		var slotsRows []activitySlotRow
-		if err = sqlx.SelectContext(ctx, qx, &slotsRows,
-			`SELECT activity_name, start, end_, group_name
-			 FROM activity_slots
-			 WHERE activity_name = $1
-			 ORDER BY start`, activityRow.Name,
-		); err != nil {
+		slots, err := r.activitySlots(ctx, qx, activityRow.EventID, activityRow.Name)
+		if err != nil {
 			return nil, err
 		}
-		if len(slotsRows) == 0 {
+		if len(slots) == 0 {
 			continue
 		}
 
-		slots, err := unmarshallActivitySlotsFromRows(slotsRows)
-		if err != nil {
-			return nil, err
-		}
-
 		var adminsRows []adminRow
 		if err = sqlx.SelectContext(ctx, qx, &adminsRows,
-			`SELECT activity_name, username 
+			`SELECT event_id, activity_name, username 
 			 FROM admins 
-			 WHERE activity_name = $1`, activityRow.Name,
+			 WHERE event_id = $1 AND activity_name = $2`, activityRow.EventID, activityRow.Name,
 		); err != nil {
 			return nil, err
 		}
@@ -400,6 +362,7 @@ func (r *pgActivitiesRepository) availableActivities(
 			activityRow.FullName,
 			activityRow.Description,
 			activityRow.Location,
+			activityRow.Zone,
 			admins,
 			activityRow.Skills,
 			activityRow.MaxPoints,
@@ -416,43 +379,34 @@ func (r *pgActivitiesRepository) availableActivities(
 func (r *pgActivitiesRepository) additionalActivities(
 	ctx context.Context,
 	qx sqlx.QueryerContext,
+	eventID string,
 ) ([]*sm.Activity, error) {
 	var err error
 
 	var activityRows []activityRow
 	if err = sqlx.SelectContext(ctx, qx, &activityRows,
-		`SELECT name, full_name, description, location, skills, max_points 
+		`SELECT event_id, name, full_name, description, location, zone, skills, max_points 
 		 FROM   activities AS activity
-		 WHERE  location IS NULL AND description IS NOT NULL`,
+		 WHERE  event_id = $1 AND location IS NULL AND description IS NOT NULL`, eventID,
 	); err != nil {
 		return nil, err
 	}
 
 	activities := make([]*sm.Activity, 0, len(activityRows))
 	for _, activityRow := range activityRows {
-		var slotsRows []activitySlotRow
-		if err = sqlx.SelectContext(ctx, qx, &slotsRows,
-			`SELECT activity_name, start, end_, group_name
-			 FROM activity_slots
-			 WHERE activity_name = $1
-			 ORDER BY start`, activityRow.Name,
-		); err != nil {
+		slots, err := r.activitySlots(ctx, qx, activityRow.EventID, activityRow.Name)
+		if err != nil {
 			return nil, err
 		}
-		if len(slotsRows) == 0 {
+		if len(slots) == 0 {
 			continue
 		}
 
-		slots, err := unmarshallActivitySlotsFromRows(slotsRows)
-		if err != nil {
-			return nil, err
-		}
-
 		var adminsRows []adminRow
 		if err = sqlx.SelectContext(ctx, qx, &adminsRows,
-			`SELECT activity_name, username 
+			`SELECT event_id, activity_name, username 
 			 FROM admins 
-			 WHERE activity_name = $1`, activityRow.Name,
+			 WHERE event_id = $1 AND activity_name = $2`, activityRow.EventID, activityRow.Name,
 		); err != nil {
 			return nil, err
 		}
@@ -466,6 +420,7 @@ func (r *pgActivitiesRepository) additionalActivities(
 			activityRow.FullName,
 			activityRow.Description,
 			activityRow.Location,
+			activityRow.Zone,
 			admins,
 			activityRow.Skills,
 			activityRow.MaxPoints,
@@ -481,19 +436,76 @@ func (r *pgActivitiesRepository) additionalActivities(
 
 func (r *pgActivitiesRepository) updateSlots(
 	ctx context.Context,
-	ex sqlx.ExecerContext,
+	ex sqlx.ExtContext,
+	eventID string,
 	activity *sm.Activity,
 ) error {
-	var err error
-	for _, slot := range activity.Slots {
-		if err = r.requireExecResult(ex.ExecContext(ctx,
-			`UPDATE activity_slots SET group_name = $3 WHERE activity_name = $1 AND start = $2`,
-			activity.Name, slot.Start.UTC(), slot.Whom,
-		)); err != nil {
-			return err
-		}
+	if _, err := ex.ExecContext(ctx,
+		`DELETE FROM activity_slot_bookings WHERE event_id = $1 AND activity_name = $2`, eventID, activity.Name,
+	); err != nil {
+		return err
 	}
-	return nil
+
+	return r.insertBookings(ctx, ex, eventID, activity)
+}
+
+func (r *pgActivitiesRepository) insertBookings(
+	ctx context.Context,
+	ex sqlx.ExtContext,
+	eventID string,
+	activity *sm.Activity,
+) error {
+	rows := marshallActivitySlotsBookingsToRows(eventID, activity.Name, activity.Slots)
+	if len(rows) == 0 {
+		return nil
+	}
+
+	// Брони ссылаются на группу по id, а домен знает только её название.
+	return r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
+		`INSERT INTO
+			activity_slot_bookings (event_id, activity_name, start, character_id, status)
+		 VALUES (
+			:event_id, :activity_name, :start,
+			(SELECT id FROM characters WHERE event_id = :event_id AND group_name = :group_name),
+			:status
+		 )`,
+		rows,
+	))
+}
+
+func (r *pgActivitiesRepository) activitySlots(
+	ctx context.Context,
+	qx sqlx.QueryerContext,
+	eventID string,
+	activityName string,
+) ([]*sm.Slot, error) {
+	var slotsRows []activitySlotRow
+	if err := sqlx.SelectContext(ctx, qx, &slotsRows,
+		`SELECT event_id, activity_name, start, end_, capacity
+		 FROM activity_slots
+		 WHERE event_id = $1 AND activity_name = $2
+		 ORDER BY start`, eventID, activityName,
+	); err != nil {
+		return nil, err
+	}
+
+	var bookingsRows []activitySlotBookingRow
+	if err := sqlx.SelectContext(ctx, qx, &bookingsRows,
+		`SELECT   b.event_id, b.activity_name, b.start, c.group_name, b.status
+		 FROM     activity_slot_bookings b
+		 JOIN     characters c ON c.event_id = b.event_id AND c.id = b.character_id
+		 WHERE    b.event_id = $1 AND b.activity_name = $2
+		 ORDER BY b.start, c.group_name`, eventID, activityName,
+	); err != nil {
+		return nil, err
+	}
+
+	loc, err := eventLocation(ctx, qx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshallActivitySlotsFromRows(slotsRows, bookingsRows, loc)
 }
 
 func (r *pgActivitiesRepository) requireExecResult(res sql.Result, err error) error {
@@ -514,43 +526,49 @@ func (r *pgActivitiesRepository) requireExecResult(res sql.Result, err error) er
 }
 
 type activityRow struct {
+	EventID     string         `db:"event_id"`
 	Name        string         `db:"name"`
 	FullName    string         `db:"full_name"`
 	Description *string        `db:"description"`
 	Location    *string        `db:"location"`
+	Zone        *string        `db:"zone"`
 	Skills      pq.StringArray `db:"skills"`
 	MaxPoints   int            `db:"max_points"`
 }
 
-func marshallActivityToRow(a *sm.Activity) activityRow {
+func marshallActivityToRow(eventID string, a *sm.Activity) activityRow {
 	pqSkills := make(pq.StringArray, len(a.Skills))
 	for i, s := range a.Skills {
 		pqSkills[i] = s.String()
 	}
 	return activityRow{
+		EventID:     eventID,
 		Name:        a.Name,
 		FullName:    a.FullName,
 		Description: a.Description,
 		Location:    a.Location,
+		Zone:        a.Zone,
 		Skills:      pqSkills,
 		MaxPoints:   a.MaxPoints,
 	}
 }
 
 type adminRow struct {
+	EventID      string `db:"event_id"`
 	ActivityName string `db:"activity_name"`
 	Username     string `db:"username"`
 }
 
-func marshallAdminToRow(activityName string, a sm.User) adminRow {
+func marshallAdminToRow(eventID string, activityName string, a sm.User) adminRow {
 	return adminRow{
+		EventID:      eventID,
 		ActivityName: activityName,
 		Username:     a.Username,
 	}
 }
 
 func unmarshallAdminFromRow(a adminRow) (sm.User, error) {
-	return sm.UnmarshallUserFromDB(a.Username, sm.Administrator.String())
+	return sm.UnmarshallUserFromDB(a.Username, sm.Administrator.String(), 0)
 }
 
 func unmarshallAdminsFromRows(as []adminRow) ([]sm.User, error) {
@@ -565,46 +583,86 @@ func unmarshallAdminsFromRows(as []adminRow) ([]sm.User, error) {
 	return res, nil
 }
 
-func marshallAdminsToRows(activityName string, as []sm.User) []adminRow {
+func marshallAdminsToRows(eventID string, activityName string, as []sm.User) []adminRow {
 	res := make([]adminRow, len(as))
 	for i, a := range as {
-		res[i] = marshallAdminToRow(activityName, a)
+		res[i] = marshallAdminToRow(eventID, activityName, a)
 	}
 	return res
 }
 
 type activitySlotRow struct {
+	EventID      string    `db:"event_id"`
 	ActivityName string    `db:"activity_name"`
 	Start        time.Time `db:"start"`
 	End          time.Time `db:"end_"`
-	GroupName    *string   `db:"group_name"`
+	Capacity     int       `db:"capacity"`
+}
+
+type activitySlotBookingRow struct {
+	EventID      string    `db:"event_id"`
+	ActivityName string    `db:"activity_name"`
+	Start        time.Time `db:"start"`
+	GroupName    string    `db:"group_name"`
+	Status       string    `db:"status"`
 }
 
-func marshallActivitySlotToRow(activityName string, s *sm.Slot) activitySlotRow {
+func marshallActivitySlotToRow(eventID string, activityName string, s *sm.Slot) activitySlotRow {
 	return activitySlotRow{
+		EventID:      eventID,
 		ActivityName: activityName,
 		Start:        s.Start.UTC(),
 		End:          s.End.UTC(),
-		GroupName:    s.Whom,
+		Capacity:     s.Capacity,
 	}
 }
 
-func marshallActivitySlotsToRows(activityName string, ss []*sm.Slot) []activitySlotRow {
+func marshallActivitySlotsToRows(eventID string, activityName string, ss []*sm.Slot) []activitySlotRow {
 	res := make([]activitySlotRow, len(ss))
 	for i, s := range ss {
-		res[i] = marshallActivitySlotToRow(activityName, s)
+		res[i] = marshallActivitySlotToRow(eventID, activityName, s)
 	}
 	return res
 }
 
-func unmarshallActivitySlotFromRow(a activitySlotRow) (*sm.Slot, error) {
-	return sm.UnmarshallSlotFromDB(a.Start.Local(), a.End.Local(), a.GroupName)
+func marshallActivitySlotsBookingsToRows(
+	eventID string,
+	activityName string,
+	ss []*sm.Slot,
+) []activitySlotBookingRow {
+	res := make([]activitySlotBookingRow, 0)
+	for _, s := range ss {
+		for _, b := range s.Bookings {
+			res = append(res, activitySlotBookingRow{
+				EventID:      eventID,
+				ActivityName: activityName,
+				Start:        s.Start.UTC(),
+				GroupName:    b.Whom,
+				Status:       b.Status.String(),
+			})
+		}
+	}
+	return res
 }
 
-func unmarshallActivitySlotsFromRows(as []activitySlotRow) ([]*sm.Slot, error) {
-	res := make([]*sm.Slot, len(as))
-	for i, a := range as {
-		slot, err := unmarshallActivitySlotFromRow(a)
+func unmarshallActivitySlotsFromRows(
+	slotsRows []activitySlotRow,
+	bookingsRows []activitySlotBookingRow,
+	loc *time.Location,
+) ([]*sm.Slot, error) {
+	bookings := make(map[time.Time][]*sm.Booking)
+	for _, row := range bookingsRows {
+		b, err := sm.UnmarshallBookingFromDB(row.GroupName, row.Status)
+		if err != nil {
+			return nil, err
+		}
+		start := row.Start.UTC()
+		bookings[start] = append(bookings[start], b)
+	}
+
+	res := make([]*sm.Slot, len(slotsRows))
+	for i, row := range slotsRows {
+		slot, err := sm.UnmarshallSlotFromDB(row.Start.In(loc), row.End.In(loc), row.Capacity, bookings[row.Start.UTC()])
 		if err != nil {
 			return nil, err
 		}
@@ -29,10 +29,11 @@ func NewPGCharactersRepository() (sm.CharactersRepository, func() error) {
 
 func (r *pgCharactersRepository) Save(
 	ctx context.Context,
+	eventID string,
 	character *sm.Character,
 ) error {
 	if err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
-		return r.save(ctx, tx, character)
+		return r.save(ctx, tx, eventID, character)
 	}); pgutils.IsUniqueViolationError(err) {
 		return sm.ErrCharacterAlreadyExists
 	} else if err != nil {
@@ -43,12 +44,13 @@ func (r *pgCharactersRepository) Save(
 
 func (r *pgCharactersRepository) Character(
 	ctx context.Context,
+	eventID string,
 	groupName string,
 ) (*sm.Character, error) {
 	var char *sm.Character
 	var err error
 	if err = pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
-		char, err = r.character(ctx, tx, groupName)
+		char, err = r.character(ctx, tx, eventID, groupName)
 		return err
 	}); errors.Is(err, sql.ErrNoRows) {
 		return nil, sm.ErrCharacterNotFound
@@ -60,11 +62,12 @@ func (r *pgCharactersRepository) Character(
 
 func (r *pgCharactersRepository) Characters(
 	ctx context.Context,
+	eventID string,
 ) ([]*sm.Character, error) {
 	var chars []*sm.Character
 	var err error
 	if err = pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
-		chars, err = r.characters(ctx, tx)
+		chars, err = r.characters(ctx, tx, eventID)
 		return err
 	}); errors.Is(err, sql.ErrNoRows) {
 		return nil, sm.ErrCharacterNotFound
@@ -76,12 +79,45 @@ func (r *pgCharactersRepository) Characters(
 
 func (r *pgCharactersRepository) CharacterByUsername(
 	ctx context.Context,
+	eventID string,
 	username string,
 ) (*sm.Character, error) {
 	var char *sm.Character
 	var err error
 	if err = pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
-		char, err = r.characterByUsername(ctx, tx, username)
+		char, err = r.characterByUsername(ctx, tx, eventID, username)
+		return err
+	}); errors.Is(err, sql.ErrNoRows) {
+		return nil, sm.ErrCharacterNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return char, nil
+}
+
+func (r *pgCharactersRepository) GroupNameByID(
+	ctx context.Context,
+	eventID string,
+	id string,
+) (string, error) {
+	groupName, err := r.groupNameByID(ctx, r.db, eventID, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", sm.ErrCharacterNotFound
+	} else if err != nil {
+		return "", err
+	}
+	return groupName, nil
+}
+
+func (r *pgCharactersRepository) CharacterByInviteCode(
+	ctx context.Context,
+	eventID string,
+	inviteCode string,
+) (*sm.Character, error) {
+	var char *sm.Character
+	var err error
+	if err = pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		char, err = r.characterByInviteCode(ctx, tx, eventID, inviteCode)
 		return err
 	}); errors.Is(err, sql.ErrNoRows) {
 		return nil, sm.ErrCharacterNotFound
@@ -93,11 +129,12 @@ func (r *pgCharactersRepository) CharacterByUsername(
 
 func (r *pgCharactersRepository) Update(
 	ctx context.Context,
+	eventID string,
 	groupName string,
 	updateFn func(innerCtx context.Context, char *sm.Character) error,
 ) error {
-	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
-		char, err := r.character(ctx, tx, groupName)
+	err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		char, err := r.character(ctx, tx, eventID, groupName)
 		if errors.Is(err, sql.ErrNoRows) {
 			return sm.ErrCharacterNotFound
 		} else if err != nil {
@@ -109,30 +146,45 @@ func (r *pgCharactersRepository) Update(
 			return err
 		}
 
-		return r.update(ctx, tx, char)
+		return r.update(ctx, tx, eventID, char)
 	})
+	// Новое название группы уже занято.
+	if pgutils.IsUniqueViolationError(err) {
+		return sm.ErrCharacterAlreadyExists
+	}
+	return err
 }
 
 func (r *pgCharactersRepository) save(
 	ctx context.Context,
 	ex sqlx.ExtContext,
+	eventID string,
 	character *sm.Character,
 ) error {
 	var err error
 	if err = r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
 		`INSERT INTO
-			characters (group_name, username, started_at) 
-		 VALUES (:group_name, :username, :started_at)`,
-		marshallCharacterToRow(character),
+			characters (event_id, id, group_name, username, invite_code, started_at, scheduled_start, extension_minutes, paused_at, paused_seconds) 
+		 VALUES (:event_id, :id, :group_name, :username, :invite_code, :started_at, :scheduled_start, :extension_minutes, :paused_at, :paused_seconds)`,
+		marshallCharacterToRow(eventID, character),
 	)); err != nil {
 		return err
 	}
 
 	if err = r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
 		`INSERT INTO
-			character_slots (group_name, start, end_, activity_name) 
-		 VALUES (:group_name, :start, :end_, :activity_name)`,
-		marshallCharacterSlotsToRows(character.GroupName, character.Slots),
+			character_members (event_id, character_id, username, role)
+		 VALUES (:event_id, :character_id, :username, :role)`,
+		marshallCharacterMembersToRows(eventID, character.ID, character.Members),
+	)); err != nil {
+		return err
+	}
+
+	if err = r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
+		`INSERT INTO
+			character_slots (event_id, character_id, start, end_, activity_name, status) 
+		 VALUES (:event_id, :character_id, :start, :end_, :activity_name, :status)`,
+		marshallCharacterSlotsToRows(eventID, character.ID, character.Slots),
 	)); err != nil {
 		return err
 	}
@@ -140,9 +192,31 @@ func (r *pgCharactersRepository) save(
 	if len(character.Grades) > 0 {
 		if err = r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
 			`INSERT INTO
-			grades (group_name, skill_type, points, activity_name, time)
-		 VALUES (:group_name, :skill_type, :points, :activity_name, :time)`,
-			marshallCharacterGradesToRows(character.GroupName, character.Grades),
+			grades (event_id, id, character_id, skill_type, points, activity_name, time, revoked_at, revoked_by, revoke_reason)
+		 VALUES (:event_id, :id, :character_id, :skill_type, :points, :activity_name, :time, :revoked_at, :revoked_by, :revoke_reason)`,
+			marshallCharacterGradesToRows(eventID, character.ID, character.Grades),
+		)); err != nil {
+			return err
+		}
+	}
+
+	if len(character.Penalties) > 0 {
+		if err = r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
+			`INSERT INTO
+			character_penalties (event_id, id, character_id, points, reason, activity_name, username, time)
+		 VALUES (:event_id, :id, :character_id, :points, :reason, :activity_name, :username, :time)`,
+			marshallCharacterPenaltiesToRows(eventID, character.ID, character.Penalties),
+		)); err != nil {
+			return err
+		}
+	}
+
+	if len(character.WindowAdjustments) > 0 {
+		if err = r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
+			`INSERT INTO
+			character_window_adjustments (event_id, id, character_id, kind, duration_seconds, username, time)
+		 VALUES (:event_id, :id, :character_id, :kind, :duration_seconds, :username, :time)`,
+			marshallCharacterWindowAdjustmentsToRows(eventID, character.ID, character.WindowAdjustments),
 		)); err != nil {
 			return err
 		}
@@ -154,104 +228,191 @@ func (r *pgCharactersRepository) save(
 func (r *pgCharactersRepository) character(
 	ctx context.Context,
 	qx sqlx.QueryerContext,
+	eventID string,
 	groupName string,
 ) (*sm.Character, error) {
 	var err error
 
 	var characterRow characterRow
 	if err = sqlx.GetContext(ctx, qx, &characterRow,
-		`SELECT group_name, username, started_at
+		`SELECT event_id, id, group_name, username, invite_code, started_at, scheduled_start, extension_minutes, paused_at, paused_seconds
    		 FROM characters
-		 WHERE group_name = $1`, groupName,
+		 WHERE event_id = $1 AND group_name = $2`, eventID, groupName,
 	); err != nil {
 		return nil, err
 	}
 
+	loc, err := eventLocation(ctx, qx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
 	var characterSlotsRows []characterSlotRow
 	if err = sqlx.SelectContext(ctx, qx, &characterSlotsRows,
-		`SELECT   group_name, start, end_, activity_name
+		`SELECT   event_id, character_id, start, end_, activity_name, status
 		 FROM     character_slots
-		 WHERE    group_name = $1
-		 ORDER BY start`, characterRow.GroupName,
+		 WHERE    event_id = $1 AND character_id = $2
+		 ORDER BY start`, characterRow.EventID, characterRow.ID,
 	); err != nil {
 		return nil, err
 	}
-	slots, err := unmarshallCharacterSlotsFromRows(characterSlotsRows)
+	slots, err := unmarshallCharacterSlotsFromRows(characterSlotsRows, loc)
 	if err != nil {
 		return nil, err
 	}
 
 	var characterGradesRows []characterGradeRow
 	if err = sqlx.SelectContext(ctx, qx, &characterGradesRows,
-		`SELECT   group_name, skill_type, points, activity_name, time
+		`SELECT   event_id, id, character_id, skill_type, points, activity_name, time, revoked_at, revoked_by, revoke_reason
 		 FROM     grades
-		 WHERE    group_name = $1`, characterRow.GroupName,
+		 WHERE    event_id = $1 AND character_id = $2
+		 ORDER BY time`, characterRow.EventID, characterRow.ID,
 	); err != nil {
 		return nil, err
 	}
-	grades, err := unmarshallCharacterGradesFromRows(characterGradesRows)
+	grades, err := unmarshallCharacterGradesFromRows(characterGradesRows, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	achievements, err := r.characterAchievements(ctx, qx, characterRow.EventID, characterRow.ID, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	penalties, err := r.characterPenalties(ctx, qx, characterRow.EventID, characterRow.ID, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	adjustments, err := r.characterWindowAdjustments(ctx, qx, characterRow.EventID, characterRow.ID, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	codeAttempts, err := r.characterCodeAttempts(ctx, qx, characterRow.EventID, characterRow.ID, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := r.characterMembers(ctx, qx, characterRow.EventID, characterRow.ID)
 	if err != nil {
 		return nil, err
 	}
 
 	return sm.UnmarshallCharacterFromDB(
+		characterRow.ID,
 		characterRow.GroupName,
 		characterRow.Username,
-		timeLocalOrNil(characterRow.StartedAt),
+		members,
+		characterRow.InviteCode,
+		timeInOrNil(characterRow.StartedAt, loc),
+		timeInOrNil(characterRow.ScheduledStart, loc),
+		time.Duration(characterRow.ExtensionMinutes)*time.Minute,
+		timeInOrNil(characterRow.PausedAt, loc),
+		time.Duration(characterRow.PausedSeconds)*time.Second,
 		slots,
 		grades,
+		achievements,
+		penalties,
+		adjustments,
+		codeAttempts,
 	)
 }
 
 func (r *pgCharactersRepository) characters(
 	ctx context.Context,
 	qx sqlx.QueryerContext,
+	eventID string,
 ) ([]*sm.Character, error) {
 	var err error
 
 	var charactersRows []characterRow
 	if err = sqlx.SelectContext(ctx, qx, &charactersRows,
-		`SELECT group_name, username, started_at
-   		 FROM characters`,
+		`SELECT event_id, id, group_name, username, invite_code, started_at, scheduled_start, extension_minutes, paused_at, paused_seconds
+   		 FROM characters
+		 WHERE event_id = $1`, eventID,
 	); err != nil {
 		return nil, err
 	}
 
+	loc, err := eventLocation(ctx, qx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
 	chars := make([]*sm.Character, len(charactersRows))
 	for i, characterRow := range charactersRows {
 		var characterSlotsRows []characterSlotRow
 		if err = sqlx.SelectContext(ctx, qx, &characterSlotsRows,
-			`SELECT   group_name, start, end_, activity_name
+			`SELECT   event_id, character_id, start, end_, activity_name, status
 		 FROM     character_slots
-		 WHERE    group_name = $1
-		 ORDER BY start`, characterRow.GroupName,
+		 WHERE    event_id = $1 AND character_id = $2
+		 ORDER BY start`, characterRow.EventID, characterRow.ID,
 		); err != nil {
 			return nil, err
 		}
-		slots, err := unmarshallCharacterSlotsFromRows(characterSlotsRows)
+		slots, err := unmarshallCharacterSlotsFromRows(characterSlotsRows, loc)
 		if err != nil {
 			return nil, err
 		}
 
 		var characterGradesRows []characterGradeRow
 		if err = sqlx.SelectContext(ctx, qx, &characterGradesRows,
-			`SELECT   group_name, skill_type, points, activity_name, time
+			`SELECT   event_id, id, character_id, skill_type, points, activity_name, time, revoked_at, revoked_by, revoke_reason
 		 FROM     grades
-		 WHERE    group_name = $1`, characterRow.GroupName,
+		 WHERE    event_id = $1 AND character_id = $2
+		 ORDER BY time`, characterRow.EventID, characterRow.ID,
 		); err != nil {
 			return nil, err
 		}
-		grades, err := unmarshallCharacterGradesFromRows(characterGradesRows)
+		grades, err := unmarshallCharacterGradesFromRows(characterGradesRows, loc)
+		if err != nil {
+			return nil, err
+		}
+
+		achievements, err := r.characterAchievements(ctx, qx, characterRow.EventID, characterRow.ID, loc)
+		if err != nil {
+			return nil, err
+		}
+
+		penalties, err := r.characterPenalties(ctx, qx, characterRow.EventID, characterRow.ID, loc)
+		if err != nil {
+			return nil, err
+		}
+
+		adjustments, err := r.characterWindowAdjustments(ctx, qx, characterRow.EventID, characterRow.ID, loc)
+		if err != nil {
+			return nil, err
+		}
+
+		codeAttempts, err := r.characterCodeAttempts(ctx, qx, characterRow.EventID, characterRow.ID, loc)
+		if err != nil {
+			return nil, err
+		}
+
+		members, err := r.characterMembers(ctx, qx, characterRow.EventID, characterRow.ID)
 		if err != nil {
 			return nil, err
 		}
 
 		char, err := sm.UnmarshallCharacterFromDB(
+			characterRow.ID,
 			characterRow.GroupName,
 			characterRow.Username,
-			timeLocalOrNil(characterRow.StartedAt),
+			members,
+			characterRow.InviteCode,
+			timeInOrNil(characterRow.StartedAt, loc),
+			timeInOrNil(characterRow.ScheduledStart, loc),
+			time.Duration(characterRow.ExtensionMinutes)*time.Minute,
+			timeInOrNil(characterRow.PausedAt, loc),
+			time.Duration(characterRow.PausedSeconds)*time.Second,
 			slots,
 			grades,
+			achievements,
+			penalties,
+			adjustments,
+			codeAttempts,
 		)
 		if err != nil {
 			return nil, err
@@ -265,101 +426,281 @@ func (r *pgCharactersRepository) characters(
 func (r *pgCharactersRepository) characterByUsername(
 	ctx context.Context,
 	qx sqlx.QueryerContext,
+	eventID string,
 	username string,
 ) (*sm.Character, error) {
-	var err error
+	// Пользователь может состоять только в одной группе мероприятия.
+	var id string
+	if err := sqlx.GetContext(ctx, qx, &id,
+		`SELECT character_id
+		 FROM   character_members
+		 WHERE  event_id = $1 AND username = $2`, eventID, username,
+	); err != nil {
+		return nil, err
+	}
 
-	var characterRow characterRow
-	if err = sqlx.GetContext(ctx, qx, &characterRow,
-		`SELECT group_name, username, started_at
-   		 FROM   characters
-		 WHERE  username = $1`, username,
+	groupName, err := r.groupNameByID(ctx, qx, eventID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.character(ctx, qx, eventID, groupName)
+}
+
+func (r *pgCharactersRepository) groupNameByID(
+	ctx context.Context,
+	qx sqlx.QueryerContext,
+	eventID string,
+	id string,
+) (string, error) {
+	var groupName string
+	err := sqlx.GetContext(ctx, qx, &groupName,
+		`SELECT group_name
+		 FROM   characters
+		 WHERE  event_id = $1 AND id = $2`, eventID, id,
+	)
+	return groupName, err
+}
+
+func (r *pgCharactersRepository) characterByInviteCode(
+	ctx context.Context,
+	qx sqlx.QueryerContext,
+	eventID string,
+	inviteCode string,
+) (*sm.Character, error) {
+	var groupName string
+	if err := sqlx.GetContext(ctx, qx, &groupName,
+		`SELECT group_name
+		 FROM   characters
+		 WHERE  event_id = $1 AND invite_code = $2`, eventID, inviteCode,
 	); err != nil {
 		return nil, err
 	}
 
-	var characterSlotsRows []characterSlotRow
-	if err = sqlx.SelectContext(ctx, qx, &characterSlotsRows,
-		`SELECT   group_name, start, end_, activity_name
-		 FROM     character_slots
-		 WHERE    group_name = $1
-		 ORDER BY start`, characterRow.GroupName,
+	return r.character(ctx, qx, eventID, groupName)
+}
+
+func (r *pgCharactersRepository) characterMembers(
+	ctx context.Context,
+	qx sqlx.QueryerContext,
+	eventID string,
+	characterID string,
+) ([]sm.Member, error) {
+	var rows []characterMemberRow
+	if err := sqlx.SelectContext(ctx, qx, &rows,
+		`SELECT   event_id, character_id, username, role
+		 FROM     character_members
+		 WHERE    event_id = $1 AND character_id = $2
+		 ORDER BY role, username`, eventID, characterID,
 	); err != nil {
 		return nil, err
 	}
-	slots, err := unmarshallCharacterSlotsFromRows(characterSlotsRows)
-	if err != nil {
+	return unmarshallCharacterMembersFromRows(rows)
+}
+
+func (r *pgCharactersRepository) characterAchievements(
+	ctx context.Context,
+	qx sqlx.QueryerContext,
+	eventID string,
+	characterID string,
+	loc *time.Location,
+) ([]sm.Achievement, error) {
+	var rows []characterAchievementRow
+	if err := sqlx.SelectContext(ctx, qx, &rows,
+		`SELECT   event_id, character_id, achievement_id, unlocked_at
+		 FROM     character_achievements
+		 WHERE    event_id = $1 AND character_id = $2
+		 ORDER BY unlocked_at`, eventID, characterID,
+	); err != nil {
 		return nil, err
 	}
+	return unmarshallCharacterAchievementsFromRows(rows, loc)
+}
 
-	var characterGradesRows []characterGradeRow
-	if err = sqlx.SelectContext(ctx, qx, &characterGradesRows,
-		`SELECT   group_name, skill_type, points, activity_name, time
-		 FROM     grades
-		 WHERE    group_name = $1`, characterRow.GroupName,
+func (r *pgCharactersRepository) characterPenalties(
+	ctx context.Context,
+	qx sqlx.QueryerContext,
+	eventID string,
+	characterID string,
+	loc *time.Location,
+) ([]sm.Penalty, error) {
+	var rows []characterPenaltyRow
+	if err := sqlx.SelectContext(ctx, qx, &rows,
+		`SELECT   event_id, id, character_id, points, reason, activity_name, username, time
+		 FROM     character_penalties
+		 WHERE    event_id = $1 AND character_id = $2
+		 ORDER BY time`, eventID, characterID,
 	); err != nil {
 		return nil, err
 	}
-	grades, err := unmarshallCharacterGradesFromRows(characterGradesRows)
-	if err != nil {
+	return unmarshallCharacterPenaltiesFromRows(rows, loc)
+}
+
+func (r *pgCharactersRepository) characterWindowAdjustments(
+	ctx context.Context,
+	qx sqlx.QueryerContext,
+	eventID string,
+	characterID string,
+	loc *time.Location,
+) ([]sm.WindowAdjustment, error) {
+	var rows []characterWindowAdjustmentRow
+	if err := sqlx.SelectContext(ctx, qx, &rows,
+		`SELECT   event_id, id, character_id, kind, duration_seconds, username, time
+		 FROM     character_window_adjustments
+		 WHERE    event_id = $1 AND character_id = $2
+		 ORDER BY time`, eventID, characterID,
+	); err != nil {
 		return nil, err
 	}
+	return unmarshallCharacterWindowAdjustmentsFromRows(rows, loc)
+}
 
-	return sm.UnmarshallCharacterFromDB(
-		characterRow.GroupName,
-		characterRow.Username,
-		timeLocalOrNil(characterRow.StartedAt),
-		slots,
-		grades,
-	)
+func (r *pgCharactersRepository) characterCodeAttempts(
+	ctx context.Context,
+	qx sqlx.QueryerContext,
+	eventID string,
+	characterID string,
+	loc *time.Location,
+) ([]time.Time, error) {
+	var rows []time.Time
+	if err := sqlx.SelectContext(ctx, qx, &rows,
+		`SELECT   time
+		 FROM     character_code_attempts
+		 WHERE    event_id = $1 AND character_id = $2
+		 ORDER BY time`, eventID, characterID,
+	); err != nil {
+		return nil, err
+	}
+
+	res := make([]time.Time, len(rows))
+	for i, t := range rows {
+		res[i] = t.In(loc)
+	}
+	return res, nil
 }
 
 func (r *pgCharactersRepository) update(
 	ctx context.Context,
 	ex sqlx.ExtContext,
+	eventID string,
 	character *sm.Character,
 ) error {
 	var err error
 	if err = r.requireExecResult(ex.ExecContext(ctx,
 		`UPDATE characters 
-		 SET    started_at = $2
-		 WHERE  group_name = $1`, character.GroupName, timeUTCOrNil(character.StartedAt),
+		 SET    group_name = $3, started_at = $4, invite_code = $5, scheduled_start = $6, extension_minutes = $7,
+		        paused_at = $8, paused_seconds = $9
+		 WHERE  event_id = $1 AND id = $2`,
+		eventID, character.ID, character.GroupName, timeUTCOrNil(character.StartedAt), character.InviteCode,
+		timeUTCOrNil(character.ScheduledStart), int(character.Extension/time.Minute),
+		timeUTCOrNil(character.PausedAt), int(character.Paused/time.Second),
 	)); err != nil {
 		return err
 	}
 
 	if err = r.requireExecResult(ex.ExecContext(ctx,
-		`DELETE FROM character_slots WHERE group_name = $1`, character.GroupName,
+		`DELETE FROM character_members WHERE event_id = $1 AND character_id = $2`, eventID, character.ID,
 	)); err != nil {
 		return err
 	}
 
 	if err = r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
 		`INSERT INTO
-			character_slots (group_name, start, end_, activity_name) 
-		 VALUES (:group_name, :start, :end_, :activity_name)`,
-		marshallCharacterSlotsToRows(character.GroupName, character.Slots),
+			character_members (event_id, character_id, username, role)
+		 VALUES (:event_id, :character_id, :username, :role)`,
+		marshallCharacterMembersToRows(eventID, character.ID, character.Members),
 	)); err != nil {
 		return err
 	}
 
-	if err = r.noRequireExecResult(ex.ExecContext(ctx,
-		`DELETE FROM grades WHERE group_name = $1`, character.GroupName,
+	if err = r.requireExecResult(ex.ExecContext(ctx,
+		`DELETE FROM character_slots WHERE event_id = $1 AND character_id = $2`, eventID, character.ID,
 	)); err != nil {
 		return err
 	}
 
+	if err = r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
+		`INSERT INTO
+			character_slots (event_id, character_id, start, end_, activity_name, status) 
+		 VALUES (:event_id, :character_id, :start, :end_, :activity_name, :status)`,
+		marshallCharacterSlotsToRows(eventID, character.ID, character.Slots),
+	)); err != nil {
+		return err
+	}
+
+	// Оценки никогда не удаляются: отозванные остаются в истории.
 	if len(character.Grades) > 0 {
 		if err = r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
 			`INSERT INTO 
-			grades (group_name, skill_type, points, activity_name, time) 
-		 VALUES (:group_name, :skill_type, :points, :activity_name, :time)`,
-			marshallCharacterGradesToRows(character.GroupName, character.Grades),
+			grades (event_id, id, character_id, skill_type, points, activity_name, time, revoked_at, revoked_by, revoke_reason) 
+		 VALUES (:event_id, :id, :character_id, :skill_type, :points, :activity_name, :time, :revoked_at, :revoked_by, :revoke_reason)
+		 ON CONFLICT (event_id, id) DO UPDATE SET
+			revoked_at    = EXCLUDED.revoked_at,
+			revoked_by    = EXCLUDED.revoked_by,
+			revoke_reason = EXCLUDED.revoke_reason`,
+			marshallCharacterGradesToRows(eventID, character.ID, character.Grades),
 		)); err != nil {
 			return err
 		}
 	}
 
+	// Достижения тоже не удаляются, поэтому вставляются только новые.
+	if len(character.Achievements) > 0 {
+		if _, err = sqlx.NamedExecContext(ctx, ex,
+			`INSERT INTO
+			character_achievements (event_id, character_id, achievement_id, unlocked_at)
+		 VALUES (:event_id, :character_id, :achievement_id, :unlocked_at)
+		 ON CONFLICT (event_id, character_id, achievement_id) DO NOTHING`,
+			marshallCharacterAchievementsToRows(eventID, character.ID, character.Achievements),
+		); err != nil {
+			return err
+		}
+	}
+
+	// Штрафы не изменяются, поэтому вставляются только новые.
+	if len(character.Penalties) > 0 {
+		if _, err = sqlx.NamedExecContext(ctx, ex,
+			`INSERT INTO
+			character_penalties (event_id, id, character_id, points, reason, activity_name, username, time)
+		 VALUES (:event_id, :id, :character_id, :points, :reason, :activity_name, :username, :time)
+		 ON CONFLICT (event_id, id) DO NOTHING`,
+			marshallCharacterPenaltiesToRows(eventID, character.ID, character.Penalties),
+		); err != nil {
+			return err
+		}
+	}
+
+	// Домен хранит только недавние попытки, поэтому они перезаписываются целиком.
+	if _, err = ex.ExecContext(ctx,
+		`DELETE FROM character_code_attempts WHERE event_id = $1 AND character_id = $2`, eventID, character.ID,
+	); err != nil {
+		return err
+	}
+
+	if len(character.CodeAttempts) > 0 {
+		if err = r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
+			`INSERT INTO
+			character_code_attempts (event_id, character_id, time)
+		 VALUES (:event_id, :character_id, :time)`,
+			marshallCharacterCodeAttemptsToRows(eventID, character.ID, character.CodeAttempts),
+		)); err != nil {
+			return err
+		}
+	}
+
+	// Журнал изменений окна только пополняется.
+	if len(character.WindowAdjustments) > 0 {
+		if _, err = sqlx.NamedExecContext(ctx, ex,
+			`INSERT INTO
+			character_window_adjustments (event_id, id, character_id, kind, duration_seconds, username, time)
+		 VALUES (:event_id, :id, :character_id, :kind, :duration_seconds, :username, :time)
+		 ON CONFLICT (event_id, id) DO NOTHING`,
+			marshallCharacterWindowAdjustmentsToRows(eventID, character.ID, character.WindowAdjustments),
+		); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -380,54 +721,117 @@ func (r *pgCharactersRepository) requireExecResult(res sql.Result, err error) er
 	return nil
 }
 
-func (r *pgCharactersRepository) noRequireExecResult(_ sql.Result, err error) error {
-	return err
-}
-
 type characterRow struct {
-	GroupName string     `db:"group_name"`
-	Username  string     `db:"username"`
-	StartedAt *time.Time `db:"started_at"`
+	EventID          string     `db:"event_id"`
+	ID               string     `db:"id"`
+	GroupName        string     `db:"group_name"`
+	Username         string     `db:"username"`
+	InviteCode       string     `db:"invite_code"`
+	StartedAt        *time.Time `db:"started_at"`
+	ScheduledStart   *time.Time `db:"scheduled_start"`
+	ExtensionMinutes int        `db:"extension_minutes"`
+	PausedAt         *time.Time `db:"paused_at"`
+	PausedSeconds    int        `db:"paused_seconds"`
 }
 
-func marshallCharacterToRow(c *sm.Character) characterRow {
+func marshallCharacterToRow(eventID string, c *sm.Character) characterRow {
 	return characterRow{
-		GroupName: c.GroupName,
-		Username:  c.Username,
-		StartedAt: timeUTCOrNil(c.StartedAt),
+		EventID:          eventID,
+		ID:               c.ID,
+		GroupName:        c.GroupName,
+		Username:         c.Username,
+		InviteCode:       c.InviteCode,
+		StartedAt:        timeUTCOrNil(c.StartedAt),
+		ScheduledStart:   timeUTCOrNil(c.ScheduledStart),
+		ExtensionMinutes: int(c.Extension / time.Minute),
+		PausedAt:         timeUTCOrNil(c.PausedAt),
+		PausedSeconds:    int(c.Paused / time.Second),
 	}
 }
 
+type characterMemberRow struct {
+	EventID     string `db:"event_id"`
+	CharacterID string `db:"character_id"`
+	Username    string `db:"username"`
+	Role        string `db:"role"`
+}
+
+func marshallCharacterMembersToRows(eventID string, characterID string, ms []sm.Member) []characterMemberRow {
+	res := make([]characterMemberRow, len(ms))
+	for i, m := range ms {
+		res[i] = characterMemberRow{
+			EventID:     eventID,
+			CharacterID: characterID,
+			Username:    m.Username,
+			Role:        m.Role.String(),
+		}
+	}
+	return res
+}
+
+func unmarshallCharacterMembersFromRows(rows []characterMemberRow) ([]sm.Member, error) {
+	res := make([]sm.Member, len(rows))
+	for i, row := range rows {
+		m, err := sm.UnmarshallMemberFromDB(row.Username, row.Role)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = m
+	}
+	return res, nil
+}
+
 type characterGradeRow struct {
-	GroupName    string    `db:"group_name"`
-	SkillType    string    `db:"skill_type"`
-	Points       int       `db:"points"`
-	ActivityName string    `db:"activity_name"`
-	Time         time.Time `db:"time"`
+	EventID      string     `db:"event_id"`
+	ID           string     `db:"id"`
+	CharacterID  string     `db:"character_id"`
+	SkillType    string     `db:"skill_type"`
+	Points       int        `db:"points"`
+	ActivityName string     `db:"activity_name"`
+	Time         time.Time  `db:"time"`
+	RevokedAt    *time.Time `db:"revoked_at"`
+	RevokedBy    *string    `db:"revoked_by"`
+	RevokeReason *string    `db:"revoke_reason"`
 }
 
-func marshallCharacterGradeToRow(groupName string, g sm.Grade) characterGradeRow {
-	return characterGradeRow{
-		GroupName:    groupName,
+func marshallCharacterGradeToRow(eventID string, characterID string, g sm.Grade) characterGradeRow {
+	row := characterGradeRow{
+		EventID:      eventID,
+		ID:           g.ID,
+		CharacterID:  characterID,
 		SkillType:    g.SkillType.String(),
 		Points:       g.Points,
 		ActivityName: g.ActivityName,
 		Time:         g.Time.UTC(),
 	}
+	if g.Revocation != nil {
+		row.RevokedAt = timeUTCOrNil(&g.Revocation.Time)
+		row.RevokedBy = &g.Revocation.Username
+		row.RevokeReason = &g.Revocation.Reason
+	}
+	return row
 }
 
-func marshallCharacterGradesToRows(groupName string, gs []sm.Grade) []characterGradeRow {
+func marshallCharacterGradesToRows(eventID string, characterID string, gs []sm.Grade) []characterGradeRow {
 	res := make([]characterGradeRow, 0, len(gs))
 	for _, g := range gs {
-		res = append(res, marshallCharacterGradeToRow(groupName, g))
+		res = append(res, marshallCharacterGradeToRow(eventID, characterID, g))
 	}
 	return res
 }
 
-func unmarshallCharacterGradesFromRows(ss []characterGradeRow) ([]sm.Grade, error) {
+func unmarshallCharacterGradesFromRows(ss []characterGradeRow, loc *time.Location) ([]sm.Grade, error) {
 	res := make([]sm.Grade, len(ss))
 	for i, s := range ss {
-		g, err := sm.UnmarshallGradeFromDB(s.SkillType, s.Points, s.ActivityName, s.Time)
+		var revocation *sm.GradeRevocation
+		if s.RevokedAt != nil {
+			r, err := sm.NewGradeRevocation(derefOrEmpty(s.RevokeReason), derefOrEmpty(s.RevokedBy), s.RevokedAt.In(loc))
+			if err != nil {
+				return nil, err
+			}
+			revocation = &r
+		}
+		g, err := sm.UnmarshallGradeFromDB(s.ID, s.SkillType, s.Points, s.ActivityName, s.Time.In(loc), revocation)
 		if err != nil {
 			return nil, err
 		}
@@ -436,38 +840,195 @@ func unmarshallCharacterGradesFromRows(ss []characterGradeRow) ([]sm.Grade, erro
 	return res, nil
 }
 
+type characterAchievementRow struct {
+	EventID       string    `db:"event_id"`
+	CharacterID   string    `db:"character_id"`
+	AchievementID string    `db:"achievement_id"`
+	UnlockedAt    time.Time `db:"unlocked_at"`
+}
+
+func marshallCharacterAchievementsToRows(eventID string, characterID string, as []sm.Achievement) []characterAchievementRow {
+	res := make([]characterAchievementRow, len(as))
+	for i, a := range as {
+		res[i] = characterAchievementRow{
+			EventID:       eventID,
+			CharacterID:   characterID,
+			AchievementID: a.ID,
+			UnlockedAt:    a.UnlockedAt.UTC(),
+		}
+	}
+	return res
+}
+
+func unmarshallCharacterAchievementsFromRows(rows []characterAchievementRow, loc *time.Location) ([]sm.Achievement, error) {
+	res := make([]sm.Achievement, len(rows))
+	for i, row := range rows {
+		a, err := sm.UnmarshallAchievementFromDB(row.AchievementID, row.UnlockedAt.In(loc))
+		if err != nil {
+			return nil, err
+		}
+		res[i] = a
+	}
+	return res, nil
+}
+
+type characterPenaltyRow struct {
+	EventID      string    `db:"event_id"`
+	ID           string    `db:"id"`
+	CharacterID  string    `db:"character_id"`
+	Points       int       `db:"points"`
+	Reason       string    `db:"reason"`
+	ActivityName *string   `db:"activity_name"`
+	Username     string    `db:"username"`
+	Time         time.Time `db:"time"`
+}
+
+func marshallCharacterPenaltiesToRows(eventID string, characterID string, ps []sm.Penalty) []characterPenaltyRow {
+	res := make([]characterPenaltyRow, len(ps))
+	for i, p := range ps {
+		res[i] = characterPenaltyRow{
+			EventID:      eventID,
+			ID:           p.ID,
+			CharacterID:  characterID,
+			Points:       p.Points,
+			Reason:       p.Reason,
+			ActivityName: pointerIfNotEmpty(p.ActivityName),
+			Username:     p.Username,
+			Time:         p.Time.UTC(),
+		}
+	}
+	return res
+}
+
+func unmarshallCharacterPenaltiesFromRows(rows []characterPenaltyRow, loc *time.Location) ([]sm.Penalty, error) {
+	res := make([]sm.Penalty, len(rows))
+	for i, row := range rows {
+		p, err := sm.UnmarshallPenaltyFromDB(
+			row.ID, row.Points, row.Reason, derefOrEmpty(row.ActivityName), row.Username, row.Time.In(loc),
+		)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = p
+	}
+	return res, nil
+}
+
+type characterCodeAttemptRow struct {
+	EventID     string    `db:"event_id"`
+	CharacterID string    `db:"character_id"`
+	Time        time.Time `db:"time"`
+}
+
+func marshallCharacterCodeAttemptsToRows(eventID string, characterID string, ts []time.Time) []characterCodeAttemptRow {
+	res := make([]characterCodeAttemptRow, len(ts))
+	for i, t := range ts {
+		res[i] = characterCodeAttemptRow{
+			EventID:     eventID,
+			CharacterID: characterID,
+			Time:        t.UTC(),
+		}
+	}
+	return res
+}
+
+type characterWindowAdjustmentRow struct {
+	EventID         string    `db:"event_id"`
+	ID              string    `db:"id"`
+	CharacterID     string    `db:"character_id"`
+	Kind            string    `db:"kind"`
+	DurationSeconds int       `db:"duration_seconds"`
+	Username        string    `db:"username"`
+	Time            time.Time `db:"time"`
+}
+
+func marshallCharacterWindowAdjustmentsToRows(
+	eventID string,
+	characterID string,
+	as []sm.WindowAdjustment,
+) []characterWindowAdjustmentRow {
+	res := make([]characterWindowAdjustmentRow, len(as))
+	for i, a := range as {
+		res[i] = characterWindowAdjustmentRow{
+			EventID:         eventID,
+			ID:              a.ID,
+			CharacterID:     characterID,
+			Kind:            a.Kind.String(),
+			DurationSeconds: int(a.Duration / time.Second),
+			Username:        a.Username,
+			Time:            a.Time.UTC(),
+		}
+	}
+	return res
+}
+
+func unmarshallCharacterWindowAdjustmentsFromRows(
+	rows []characterWindowAdjustmentRow,
+	loc *time.Location,
+) ([]sm.WindowAdjustment, error) {
+	res := make([]sm.WindowAdjustment, len(rows))
+	for i, row := range rows {
+		a, err := sm.UnmarshallWindowAdjustmentFromDB(
+			row.ID, row.Kind, time.Duration(row.DurationSeconds)*time.Second, row.Username, row.Time.In(loc),
+		)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = a
+	}
+	return res, nil
+}
+
 type characterSlotRow struct {
-	GroupName    string    `db:"group_name"`
+	EventID      string    `db:"event_id"`
+	CharacterID  string    `db:"character_id"`
 	Start        time.Time `db:"start"`
 	End          time.Time `db:"end_"`
 	ActivityName *string   `db:"activity_name"`
+	Status       string    `db:"status"`
 }
 
-func marshallCharacterSlotToRow(groupName string, s *sm.Slot) characterSlotRow {
-	return characterSlotRow{
-		GroupName:    groupName,
-		Start:        s.Start.UTC(),
-		End:          s.End.UTC(),
-		ActivityName: s.Whom,
+func marshallCharacterSlotToRow(eventID string, characterID string, s *sm.Slot) characterSlotRow {
+	row := characterSlotRow{
+		EventID:     eventID,
+		CharacterID: characterID,
+		Start:       s.Start.UTC(),
+		End:         s.End.UTC(),
+		Status:      sm.SlotFree.String(),
+	}
+	// В слоте группы может быть только одна запись — точка, на которую она идёт.
+	if len(s.Bookings) > 0 {
+		activityName := s.Bookings[0].Whom
+		row.ActivityName = &activityName
+		row.Status = s.Bookings[0].Status.String()
 	}
+	return row
 }
 
-func marshallCharacterSlotsToRows(groupName string, ss []*sm.Slot) []characterSlotRow {
+func marshallCharacterSlotsToRows(eventID string, characterID string, ss []*sm.Slot) []characterSlotRow {
 	res := make([]characterSlotRow, len(ss))
 	for i, s := range ss {
-		res[i] = marshallCharacterSlotToRow(groupName, s)
+		res[i] = marshallCharacterSlotToRow(eventID, characterID, s)
 	}
 	return res
 }
 
-func unmarshallCharacterSlotFromRow(a characterSlotRow) (*sm.Slot, error) {
-	return sm.UnmarshallSlotFromDB(a.Start.Local(), a.End.Local(), a.ActivityName)
+func unmarshallCharacterSlotFromRow(a characterSlotRow, loc *time.Location) (*sm.Slot, error) {
+	bookings := make([]*sm.Booking, 0, 1)
+	if a.ActivityName != nil {
+		b, err := sm.UnmarshallBookingFromDB(*a.ActivityName, a.Status)
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, b)
+	}
+	return sm.UnmarshallSlotFromDB(a.Start.In(loc), a.End.In(loc), 1, bookings)
 }
 
-func unmarshallCharacterSlotsFromRows(cs []characterSlotRow) ([]*sm.Slot, error) {
+func unmarshallCharacterSlotsFromRows(cs []characterSlotRow, loc *time.Location) ([]*sm.Slot, error) {
 	res := make([]*sm.Slot, len(cs))
 	for i, s := range cs {
-		slot, err := unmarshallCharacterSlotFromRow(s)
+		slot, err := unmarshallCharacterSlotFromRow(s, loc)
 		if err != nil {
 			return nil, err
 		}
@@ -476,6 +1037,13 @@ func unmarshallCharacterSlotsFromRows(cs []characterSlotRow) ([]*sm.Slot, error)
 	return res, nil
 }
 
+func derefOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
 func timeUTCOrNil(t *time.Time) *time.Time {
 	if t == nil {
 		return nil
@@ -484,10 +1052,22 @@ func timeUTCOrNil(t *time.Time) *time.Time {
 	return &v
 }
 
-func timeLocalOrNil(t *time.Time) *time.Time {
+func timeInOrNil(t *time.Time, loc *time.Location) *time.Time {
 	if t == nil {
 		return nil
 	}
-	v := t.Local()
+	v := t.In(loc)
 	return &v
 }
+
+// eventLocation возвращает часовой пояс события. Время хранится в БД в UTC и
+// читается в часовом поясе события независимо от настроек сервера.
+func eventLocation(ctx context.Context, qx sqlx.QueryerContext, eventID string) (*time.Location, error) {
+	var timezone string
+	if err := sqlx.GetContext(ctx, qx, &timezone,
+		`SELECT timezone FROM events WHERE id = $1`, eventID,
+	); err != nil {
+		return nil, err
+	}
+	return time.LoadLocation(timezone)
+}
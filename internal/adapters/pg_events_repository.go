@@ -0,0 +1,494 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type pgEventsRepository struct {
+	db *sqlx.DB
+}
+
+func NewPGEventsRepository() (sm.EventsRepository, func() error) {
+	uri := os.Getenv("DATABASE_URI")
+	if uri == "" {
+		panic("DATABASE_URI environment variable not set")
+	}
+	db := sqlx.MustConnect("postgres", uri)
+
+	return &pgEventsRepository{db: db}, db.Close
+}
+
+func (r *pgEventsRepository) Save(ctx context.Context, event *sm.Event) error {
+	if err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		return r.save(ctx, tx, event)
+	}); pgutils.IsUniqueViolationError(err) {
+		return sm.ErrEventAlreadyExists
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *pgEventsRepository) Event(ctx context.Context, eventID string) (*sm.Event, error) {
+	var event *sm.Event
+	var err error
+	if err = pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		event, err = r.event(ctx, tx, eventID)
+		return err
+	}); errors.Is(err, sql.ErrNoRows) {
+		return nil, sm.ErrEventNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (r *pgEventsRepository) CurrentEvent(ctx context.Context) (*sm.Event, error) {
+	var event *sm.Event
+	var err error
+	if err = pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		event, err = r.currentEvent(ctx, tx)
+		return err
+	}); errors.Is(err, sql.ErrNoRows) {
+		return nil, sm.ErrEventNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (r *pgEventsRepository) Events(ctx context.Context) ([]*sm.Event, error) {
+	var events []*sm.Event
+	var err error
+	if err = pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		events, err = r.events(ctx, tx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *pgEventsRepository) save(ctx context.Context, ex sqlx.ExtContext, event *sm.Event) error {
+	ratingPolicy, ok := event.RatingPolicy.(*sm.SkillsRatingPolicy)
+	if !ok {
+		return fmt.Errorf("unsupported rating policy %T", event.RatingPolicy)
+	}
+
+	if err := r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
+		`INSERT INTO
+			events (id, name, date, timezone, group_name_pattern, rating_balance_bonus)
+		 VALUES (:id, :name, :date, :timezone, :group_name_pattern, :rating_balance_bonus)`,
+		marshallEventToRow(event, ratingPolicy),
+	)); err != nil {
+		return err
+	}
+
+	if err := r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
+		`INSERT INTO
+			event_skills (event_id, name, emoji, category, display_order)
+		 VALUES (:event_id, :name, :emoji, :category, :display_order)`,
+		marshallSkillsToRows(event.ID, event.Skills.Skills()),
+	)); err != nil {
+		return err
+	}
+
+	if err := r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
+		`INSERT INTO
+			event_rating_skills (event_id, skill, weight, cap)
+		 VALUES (:event_id, :skill, :weight, :cap)`,
+		marshallSkillRatingsToRows(event.ID, ratingPolicy.SkillRatings()),
+	)); err != nil {
+		return err
+	}
+
+	if err := r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
+		`INSERT INTO
+			event_rules (
+				event_id,
+				instruction_duration_minutes,
+				max_taken_slots,
+				min_duration_before_minutes,
+				min_duration_before_cancel_minutes,
+				rating_lambda,
+				slot_duration_minutes,
+				first_slot_start_minutes,
+				last_slot_start_minutes,
+				award_grace_period_minutes,
+				no_show_timeout_minutes,
+				waitlist_offer_timeout_minutes,
+				max_penalty_points,
+				max_total_penalty_points,
+				max_code_attempts,
+				code_lockout_minutes
+			)
+		 VALUES (
+				:event_id,
+				:instruction_duration_minutes,
+				:max_taken_slots,
+				:min_duration_before_minutes,
+				:min_duration_before_cancel_minutes,
+				:rating_lambda,
+				:slot_duration_minutes,
+				:first_slot_start_minutes,
+				:last_slot_start_minutes,
+				:award_grace_period_minutes,
+				:no_show_timeout_minutes,
+				:waitlist_offer_timeout_minutes,
+				:max_penalty_points,
+				:max_total_penalty_points,
+				:max_code_attempts,
+				:code_lockout_minutes
+			)`,
+		marshallEventRulesToRow(event.ID, event.Rules),
+	)); err != nil {
+		return err
+	}
+
+	if event.TravelTimes.IsZero() {
+		return nil
+	}
+
+	return r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
+		`INSERT INTO
+			event_travel_times (event_id, from_zone, to_zone, duration_minutes)
+		 VALUES (:event_id, :from_zone, :to_zone, :duration_minutes)`,
+		marshallTravelTimesToRows(event.ID, event.TravelTimes),
+	))
+}
+
+const selectEventsQuery = `
+	SELECT e.id,
+		   e.name,
+		   e.date,
+		   e.timezone,
+		   e.group_name_pattern,
+		   e.rating_balance_bonus,
+		   r.event_id,
+		   r.instruction_duration_minutes,
+		   r.max_taken_slots,
+		   r.min_duration_before_minutes,
+		   r.min_duration_before_cancel_minutes,
+		   r.rating_lambda,
+		   r.slot_duration_minutes,
+		   r.first_slot_start_minutes,
+		   r.last_slot_start_minutes,
+		   r.award_grace_period_minutes,
+		   r.no_show_timeout_minutes,
+		   r.waitlist_offer_timeout_minutes,
+		   r.max_penalty_points,
+		   r.max_total_penalty_points,
+		   r.max_code_attempts,
+		   r.code_lockout_minutes
+	FROM   events e
+	JOIN   event_rules r ON r.event_id = e.id`
+
+func (r *pgEventsRepository) event(ctx context.Context, qx sqlx.QueryerContext, eventID string) (*sm.Event, error) {
+	var row eventRow
+	if err := sqlx.GetContext(ctx, qx, &row,
+		selectEventsQuery+` WHERE e.id = $1`, eventID,
+	); err != nil {
+		return nil, err
+	}
+	return r.unmarshallEvent(ctx, qx, row)
+}
+
+func (r *pgEventsRepository) currentEvent(ctx context.Context, qx sqlx.QueryerContext) (*sm.Event, error) {
+	var row eventRow
+	if err := sqlx.GetContext(ctx, qx, &row,
+		selectEventsQuery+` ORDER BY e.date DESC LIMIT 1`,
+	); err != nil {
+		return nil, err
+	}
+	return r.unmarshallEvent(ctx, qx, row)
+}
+
+func (r *pgEventsRepository) events(ctx context.Context, qx sqlx.QueryerContext) ([]*sm.Event, error) {
+	var rows []eventRow
+	if err := sqlx.SelectContext(ctx, qx, &rows,
+		selectEventsQuery+` ORDER BY e.date`,
+	); err != nil {
+		return nil, err
+	}
+
+	events := make([]*sm.Event, 0, len(rows))
+	for _, row := range rows {
+		event, err := r.unmarshallEvent(ctx, qx, row)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (r *pgEventsRepository) unmarshallEvent(ctx context.Context, qx sqlx.QueryerContext, row eventRow) (*sm.Event, error) {
+	var travelRows []travelTimeRow
+	if err := sqlx.SelectContext(ctx, qx, &travelRows,
+		`SELECT event_id, from_zone, to_zone, duration_minutes
+		 FROM   event_travel_times
+		 WHERE  event_id = $1`, row.ID,
+	); err != nil {
+		return nil, err
+	}
+
+	var skillRows []skillRow
+	if err := sqlx.SelectContext(ctx, qx, &skillRows,
+		`SELECT   event_id, name, emoji, category, display_order
+		 FROM     event_skills
+		 WHERE    event_id = $1
+		 ORDER BY display_order, name`, row.ID,
+	); err != nil {
+		return nil, err
+	}
+
+	var skillRatingRows []skillRatingRow
+	if err := sqlx.SelectContext(ctx, qx, &skillRatingRows,
+		`SELECT event_id, skill, weight, cap
+		 FROM   event_rating_skills
+		 WHERE  event_id = $1`, row.ID,
+	); err != nil {
+		return nil, err
+	}
+
+	return unmarshallEventFromRow(row, travelRows, skillRows, skillRatingRows)
+}
+
+func (r *pgEventsRepository) requireExecResult(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+
+	aff, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if aff == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+type eventRow struct {
+	ID                 string    `db:"id"`
+	Name               string    `db:"name"`
+	Date               time.Time `db:"date"`
+	Timezone           string    `db:"timezone"`
+	GroupNamePattern   string    `db:"group_name_pattern"`
+	RatingBalanceBonus float64   `db:"rating_balance_bonus"`
+	eventRulesRow
+}
+
+func marshallEventToRow(e *sm.Event, ratingPolicy *sm.SkillsRatingPolicy) eventRow {
+	return eventRow{
+		ID:                 e.ID,
+		Name:               e.Name,
+		Date:               time.Date(e.Date.Year(), e.Date.Month(), e.Date.Day(), 0, 0, 0, 0, time.UTC),
+		Timezone:           e.Timezone.String(),
+		GroupNamePattern:   e.GroupNamePattern,
+		RatingBalanceBonus: ratingPolicy.BalanceBonus(),
+		eventRulesRow:      marshallEventRulesToRow(e.ID, e.Rules),
+	}
+}
+
+func unmarshallEventFromRow(
+	r eventRow,
+	travelRows []travelTimeRow,
+	skillRows []skillRow,
+	skillRatingRows []skillRatingRow,
+) (*sm.Event, error) {
+	rules, err := unmarshallEventRulesFromRow(r.eventRulesRow)
+	if err != nil {
+		return nil, err
+	}
+	travelTimes, err := unmarshallTravelTimesFromRows(travelRows)
+	if err != nil {
+		return nil, err
+	}
+	skills, err := unmarshallSkillsFromRows(skillRows)
+	if err != nil {
+		return nil, err
+	}
+	skillRatings, err := unmarshallSkillRatingsFromRows(skillRatingRows)
+	if err != nil {
+		return nil, err
+	}
+	return sm.UnmarshallEventFromDB(
+		r.ID, r.Name, r.Date, r.Timezone, r.GroupNamePattern, rules, travelTimes, skills, skillRatings, r.RatingBalanceBonus,
+	)
+}
+
+type eventRulesRow struct {
+	EventID                        string  `db:"event_id"`
+	InstructionDurationMinutes     int     `db:"instruction_duration_minutes"`
+	MaxTakenSlots                  int     `db:"max_taken_slots"`
+	MinDurationBeforeMinutes       int     `db:"min_duration_before_minutes"`
+	MinDurationBeforeCancelMinutes int     `db:"min_duration_before_cancel_minutes"`
+	RatingLambda                   float64 `db:"rating_lambda"`
+	SlotDurationMinutes            int     `db:"slot_duration_minutes"`
+	FirstSlotStartMinutes          int     `db:"first_slot_start_minutes"`
+	LastSlotStartMinutes           int     `db:"last_slot_start_minutes"`
+	AwardGracePeriodMinutes        int     `db:"award_grace_period_minutes"`
+	NoShowTimeoutMinutes           int     `db:"no_show_timeout_minutes"`
+	WaitlistOfferTimeoutMinutes    int     `db:"waitlist_offer_timeout_minutes"`
+	MaxPenaltyPoints               int     `db:"max_penalty_points"`
+	MaxTotalPenaltyPoints          int     `db:"max_total_penalty_points"`
+	MaxCodeAttempts                int     `db:"max_code_attempts"`
+	CodeLockoutMinutes             int     `db:"code_lockout_minutes"`
+}
+
+func marshallEventRulesToRow(eventID string, r sm.EventRules) eventRulesRow {
+	return eventRulesRow{
+		EventID:                        eventID,
+		InstructionDurationMinutes:     int(r.InstructionDuration / time.Minute),
+		MaxTakenSlots:                  r.MaxTakenSlots,
+		MinDurationBeforeMinutes:       int(r.MinDurationBefore / time.Minute),
+		MinDurationBeforeCancelMinutes: int(r.MinDurationBeforeCancel / time.Minute),
+		RatingLambda:                   r.RatingLambda,
+		SlotDurationMinutes:            int(r.SlotDuration / time.Minute),
+		FirstSlotStartMinutes:          int(r.FirstSlotStart / time.Minute),
+		LastSlotStartMinutes:           int(r.LastSlotStart / time.Minute),
+		AwardGracePeriodMinutes:        int(r.AwardGracePeriod / time.Minute),
+		NoShowTimeoutMinutes:           int(r.NoShowTimeout / time.Minute),
+		WaitlistOfferTimeoutMinutes:    int(r.WaitlistOfferTimeout / time.Minute),
+		MaxPenaltyPoints:               r.MaxPenaltyPoints,
+		MaxTotalPenaltyPoints:          r.MaxTotalPenaltyPoints,
+		MaxCodeAttempts:                r.MaxCodeAttempts,
+		CodeLockoutMinutes:             int(r.CodeLockout / time.Minute),
+	}
+}
+
+func unmarshallEventRulesFromRow(r eventRulesRow) (sm.EventRules, error) {
+	return sm.UnmarshallEventRulesFromDB(sm.EventRules{
+		InstructionDuration:     time.Duration(r.InstructionDurationMinutes) * time.Minute,
+		MaxTakenSlots:           r.MaxTakenSlots,
+		MinDurationBefore:       time.Duration(r.MinDurationBeforeMinutes) * time.Minute,
+		MinDurationBeforeCancel: time.Duration(r.MinDurationBeforeCancelMinutes) * time.Minute,
+		RatingLambda:            r.RatingLambda,
+		SlotDuration:            time.Duration(r.SlotDurationMinutes) * time.Minute,
+		FirstSlotStart:          time.Duration(r.FirstSlotStartMinutes) * time.Minute,
+		LastSlotStart:           time.Duration(r.LastSlotStartMinutes) * time.Minute,
+		AwardGracePeriod:        time.Duration(r.AwardGracePeriodMinutes) * time.Minute,
+		NoShowTimeout:           time.Duration(r.NoShowTimeoutMinutes) * time.Minute,
+		WaitlistOfferTimeout:    time.Duration(r.WaitlistOfferTimeoutMinutes) * time.Minute,
+		MaxPenaltyPoints:        r.MaxPenaltyPoints,
+		MaxTotalPenaltyPoints:   r.MaxTotalPenaltyPoints,
+		MaxCodeAttempts:         r.MaxCodeAttempts,
+		CodeLockout:             time.Duration(r.CodeLockoutMinutes) * time.Minute,
+	})
+}
+
+type travelTimeRow struct {
+	EventID         string `db:"event_id"`
+	FromZone        string `db:"from_zone"`
+	ToZone          string `db:"to_zone"`
+	DurationMinutes int    `db:"duration_minutes"`
+}
+
+func marshallTravelTimesToRows(eventID string, t sm.TravelTimes) []travelTimeRow {
+	list := t.List()
+	res := make([]travelTimeRow, len(list))
+	for i, tt := range list {
+		res[i] = travelTimeRow{
+			EventID:         eventID,
+			FromZone:        tt.From,
+			ToZone:          tt.To,
+			DurationMinutes: int(tt.Duration / time.Minute),
+		}
+	}
+	return res
+}
+
+func unmarshallTravelTimesFromRows(rows []travelTimeRow) (sm.TravelTimes, error) {
+	list := make([]sm.TravelTime, len(rows))
+	for i, row := range rows {
+		list[i] = sm.TravelTime{
+			From:     row.FromZone,
+			To:       row.ToZone,
+			Duration: time.Duration(row.DurationMinutes) * time.Minute,
+		}
+	}
+	return sm.NewTravelTimes(list)
+}
+
+type skillRow struct {
+	EventID      string `db:"event_id"`
+	Name         string `db:"name"`
+	Emoji        string `db:"emoji"`
+	Category     string `db:"category"`
+	DisplayOrder int    `db:"display_order"`
+}
+
+func marshallSkillsToRows(eventID string, skills []sm.Skill) []skillRow {
+	res := make([]skillRow, len(skills))
+	for i, s := range skills {
+		res[i] = skillRow{
+			EventID:      eventID,
+			Name:         s.Type.String(),
+			Emoji:        s.Emoji,
+			Category:     s.Category.String(),
+			DisplayOrder: s.Order,
+		}
+	}
+	return res
+}
+
+func unmarshallSkillsFromRows(rows []skillRow) ([]sm.Skill, error) {
+	res := make([]sm.Skill, len(rows))
+	for i, row := range rows {
+		s, err := sm.UnmarshallSkillFromDB(row.Name, row.Emoji, row.Category, row.DisplayOrder)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = s
+	}
+	return res, nil
+}
+
+type skillRatingRow struct {
+	EventID string  `db:"event_id"`
+	Skill   string  `db:"skill"`
+	Weight  float64 `db:"weight"`
+	Cap     int     `db:"cap"`
+}
+
+func marshallSkillRatingsToRows(eventID string, srs []sm.SkillRating) []skillRatingRow {
+	res := make([]skillRatingRow, len(srs))
+	for i, sr := range srs {
+		res[i] = skillRatingRow{
+			EventID: eventID,
+			Skill:   sr.Skill.String(),
+			Weight:  sr.Weight,
+			Cap:     sr.Cap,
+		}
+	}
+	return res
+}
+
+func unmarshallSkillRatingsFromRows(rows []skillRatingRow) ([]sm.SkillRating, error) {
+	res := make([]sm.SkillRating, len(rows))
+	for i, row := range rows {
+		skill, err := sm.NewSkillTypeFromString(row.Skill)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = sm.SkillRating{
+			Skill:  skill,
+			Weight: row.Weight,
+			Cap:    row.Cap,
+		}
+	}
+	return res, nil
+}
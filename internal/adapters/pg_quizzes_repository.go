@@ -0,0 +1,389 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type pgQuizzesRepository struct {
+	db *sqlx.DB
+}
+
+func NewPGQuizzesRepository() (sm.QuizzesRepository, func() error) {
+	uri := os.Getenv("DATABASE_URI")
+	if uri == "" {
+		panic("DATABASE_URI environment variable not set")
+	}
+	db := sqlx.MustConnect("postgres", uri)
+
+	return &pgQuizzesRepository{db: db}, db.Close
+}
+
+func (r *pgQuizzesRepository) Save(ctx context.Context, eventID string, quiz *sm.Quiz) error {
+	_, err := r.db.NamedExecContext(ctx,
+		`INSERT INTO
+			quiz_questions (event_id, activity_name, number, text, options, answers, skill_type, time_seconds)
+		 VALUES (:event_id, :activity_name, :number, :text, :options, :answers, :skill_type, :time_seconds)`,
+		marshallQuizQuestionsToRows(eventID, quiz),
+	)
+	if pgutils.IsUniqueViolationError(err) {
+		return sm.ErrQuizAlreadyExists
+	}
+	return err
+}
+
+func (r *pgQuizzesRepository) Quiz(ctx context.Context, eventID string, activityName string) (*sm.Quiz, error) {
+	var rows []quizQuestionRow
+	if err := sqlx.SelectContext(ctx, r.db, &rows,
+		`SELECT   event_id, activity_name, number, text, options, answers, skill_type, time_seconds
+		 FROM     quiz_questions
+		 WHERE    event_id = $1 AND activity_name = $2
+		 ORDER BY number`, eventID, activityName,
+	); err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, sm.ErrQuizNotFound
+	}
+
+	return unmarshallQuizFromRows(activityName, rows)
+}
+
+func (r *pgQuizzesRepository) Quizzes(ctx context.Context, eventID string) ([]*sm.Quiz, error) {
+	var rows []quizQuestionRow
+	if err := sqlx.SelectContext(ctx, r.db, &rows,
+		`SELECT   event_id, activity_name, number, text, options, answers, skill_type, time_seconds
+		 FROM     quiz_questions
+		 WHERE    event_id = $1
+		 ORDER BY activity_name, number`, eventID,
+	); err != nil {
+		return nil, err
+	}
+
+	res := make([]*sm.Quiz, 0)
+	for i := 0; i < len(rows); {
+		j := i
+		for j < len(rows) && rows[j].ActivityName == rows[i].ActivityName {
+			j++
+		}
+
+		quiz, err := unmarshallQuizFromRows(rows[i].ActivityName, rows[i:j])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, quiz)
+
+		i = j
+	}
+	return res, nil
+}
+
+func (r *pgQuizzesRepository) SaveAttempt(ctx context.Context, eventID string, at *sm.QuizAttempt) error {
+	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if _, err := tx.NamedExecContext(ctx,
+			`INSERT INTO
+				quiz_attempts (event_id, activity_name, character_id, username, started_at, awarded)
+			 VALUES (
+				:event_id, :activity_name,
+				(SELECT id FROM characters WHERE event_id = :event_id AND group_name = :group_name),
+				:username, :started_at, :awarded
+			 )`,
+			marshallQuizAttemptToRow(eventID, at),
+		); pgutils.IsUniqueViolationError(err) {
+			return sm.ErrQuizAlreadyStarted
+		} else if err != nil {
+			return err
+		}
+
+		return r.insertAnswers(ctx, tx, eventID, at)
+	})
+}
+
+func (r *pgQuizzesRepository) Attempt(
+	ctx context.Context,
+	eventID string,
+	activityName string,
+	groupName string,
+) (*sm.QuizAttempt, error) {
+	var res *sm.QuizAttempt
+	var err error
+	if err = pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		res, err = r.attempt(ctx, tx, eventID, activityName, groupName, false)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *pgQuizzesRepository) Attempts(
+	ctx context.Context,
+	eventID string,
+	activityName string,
+) ([]*sm.QuizAttempt, error) {
+	var res []*sm.QuizAttempt
+	if err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		var rows []quizAttemptRow
+		if err := sqlx.SelectContext(ctx, tx, &rows,
+			`SELECT   a.event_id, a.activity_name, c.group_name, a.username, a.started_at, a.awarded
+			 FROM     quiz_attempts a
+			 JOIN     characters c ON c.event_id = a.event_id AND c.id = a.character_id
+			 WHERE    a.event_id = $1 AND a.activity_name = $2
+			 ORDER BY a.started_at`, eventID, activityName,
+		); err != nil {
+			return err
+		}
+
+		res = make([]*sm.QuizAttempt, len(rows))
+		for i, row := range rows {
+			at, err := r.unmarshallAttempt(ctx, tx, row)
+			if err != nil {
+				return err
+			}
+			res[i] = at
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *pgQuizzesRepository) UpdateAttempt(
+	ctx context.Context,
+	eventID string,
+	activityName string,
+	groupName string,
+	updateFn func(innerCtx context.Context, at *sm.QuizAttempt) error,
+) error {
+	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		at, err := r.attempt(ctx, tx, eventID, activityName, groupName, true)
+		if err != nil {
+			return err
+		}
+
+		if err = updateFn(ctx, at); err != nil {
+			return err
+		}
+
+		if _, err = tx.NamedExecContext(ctx,
+			`UPDATE quiz_attempts
+			 SET    awarded = :awarded
+			 WHERE  event_id = :event_id AND activity_name = :activity_name AND character_id = (
+				SELECT id FROM characters WHERE event_id = :event_id AND group_name = :group_name
+			 )`,
+			marshallQuizAttemptToRow(eventID, at),
+		); err != nil {
+			return err
+		}
+
+		return r.insertAnswers(ctx, tx, eventID, at)
+	})
+}
+
+// attempt загружает прохождение викторины. При forUpdate строка блокируется,
+// чтобы ответы участников одной группы не перемешались.
+func (r *pgQuizzesRepository) attempt(
+	ctx context.Context,
+	qx sqlx.QueryerContext,
+	eventID string,
+	activityName string,
+	groupName string,
+	forUpdate bool,
+) (*sm.QuizAttempt, error) {
+	q := `SELECT a.event_id, a.activity_name, c.group_name, a.username, a.started_at, a.awarded
+		  FROM   quiz_attempts a
+		  JOIN   characters c ON c.event_id = a.event_id AND c.id = a.character_id
+		  WHERE  a.event_id = $1 AND a.activity_name = $2 AND c.group_name = $3`
+	if forUpdate {
+		q += ` FOR UPDATE OF a`
+	}
+
+	var row quizAttemptRow
+	err := sqlx.GetContext(ctx, qx, &row, q, eventID, activityName, groupName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, sm.ErrQuizNotStarted
+	} else if err != nil {
+		return nil, err
+	}
+
+	return r.unmarshallAttempt(ctx, qx, row)
+}
+
+func (r *pgQuizzesRepository) unmarshallAttempt(
+	ctx context.Context,
+	qx sqlx.QueryerContext,
+	row quizAttemptRow,
+) (*sm.QuizAttempt, error) {
+	loc, err := eventLocation(ctx, qx, row.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	var answerRows []quizAnswerRow
+	if err = sqlx.SelectContext(ctx, qx, &answerRows,
+		`SELECT   a.event_id, a.activity_name, c.group_name, a.number, a.text, a.username, a.correct, a.timed_out, a.time
+		 FROM     quiz_answers a
+		 JOIN     characters c ON c.event_id = a.event_id AND c.id = a.character_id
+		 WHERE    a.event_id = $1 AND a.activity_name = $2 AND c.group_name = $3
+		 ORDER BY a.number`, row.EventID, row.ActivityName, row.GroupName,
+	); err != nil {
+		return nil, err
+	}
+
+	return sm.UnmarshallQuizAttemptFromDB(
+		row.ActivityName,
+		row.GroupName,
+		row.Username,
+		row.StartedAt.In(loc),
+		unmarshallQuizAnswersFromRows(answerRows, loc),
+		row.Awarded,
+	)
+}
+
+// insertAnswers дописывает новые ответы: уже данные ответы не меняются.
+func (r *pgQuizzesRepository) insertAnswers(
+	ctx context.Context,
+	ex sqlx.ExtContext,
+	eventID string,
+	at *sm.QuizAttempt,
+) error {
+	if len(at.Answers) == 0 {
+		return nil
+	}
+
+	_, err := sqlx.NamedExecContext(ctx, ex,
+		`INSERT INTO
+			quiz_answers (event_id, activity_name, character_id, number, text, username, correct, timed_out, time)
+		 VALUES (
+			:event_id, :activity_name,
+			(SELECT id FROM characters WHERE event_id = :event_id AND group_name = :group_name),
+			:number, :text, :username, :correct, :timed_out, :time
+		 )
+		 ON CONFLICT DO NOTHING`,
+		marshallQuizAnswersToRows(eventID, at),
+	)
+	return err
+}
+
+type quizQuestionRow struct {
+	EventID      string         `db:"event_id"`
+	ActivityName string         `db:"activity_name"`
+	Number       int            `db:"number"`
+	Text         string         `db:"text"`
+	Options      pq.StringArray `db:"options"`
+	Answers      pq.StringArray `db:"answers"`
+	SkillType    string         `db:"skill_type"`
+	TimeSeconds  int            `db:"time_seconds"`
+}
+
+func marshallQuizQuestionsToRows(eventID string, quiz *sm.Quiz) []quizQuestionRow {
+	res := make([]quizQuestionRow, len(quiz.Questions))
+	for i, q := range quiz.Questions {
+		res[i] = quizQuestionRow{
+			EventID:      eventID,
+			ActivityName: quiz.ActivityName,
+			Number:       i,
+			Text:         q.Text,
+			Options:      q.Options,
+			Answers:      q.Answers,
+			SkillType:    q.Skill.String(),
+			TimeSeconds:  int(q.Time.Seconds()),
+		}
+	}
+	return res
+}
+
+func unmarshallQuizFromRows(activityName string, rows []quizQuestionRow) (*sm.Quiz, error) {
+	questions := make([]sm.QuizQuestion, len(rows))
+	for i, row := range rows {
+		skill, err := sm.NewSkillTypeFromString(row.SkillType)
+		if err != nil {
+			return nil, err
+		}
+
+		q, err := sm.NewQuizQuestion(
+			row.Text, row.Options, row.Answers, skill, time.Duration(row.TimeSeconds)*time.Second,
+		)
+		if err != nil {
+			return nil, err
+		}
+		questions[i] = q
+	}
+
+	return sm.UnmarshallQuizFromDB(activityName, questions)
+}
+
+type quizAttemptRow struct {
+	EventID      string    `db:"event_id"`
+	ActivityName string    `db:"activity_name"`
+	GroupName    string    `db:"group_name"`
+	Username     string    `db:"username"`
+	StartedAt    time.Time `db:"started_at"`
+	Awarded      bool      `db:"awarded"`
+}
+
+func marshallQuizAttemptToRow(eventID string, at *sm.QuizAttempt) quizAttemptRow {
+	return quizAttemptRow{
+		EventID:      eventID,
+		ActivityName: at.ActivityName,
+		GroupName:    at.GroupName,
+		Username:     at.Username,
+		StartedAt:    at.StartedAt.UTC(),
+		Awarded:      at.Awarded,
+	}
+}
+
+type quizAnswerRow struct {
+	EventID      string    `db:"event_id"`
+	ActivityName string    `db:"activity_name"`
+	GroupName    string    `db:"group_name"`
+	Number       int       `db:"number"`
+	Text         string    `db:"text"`
+	Username     string    `db:"username"`
+	Correct      bool      `db:"correct"`
+	TimedOut     bool      `db:"timed_out"`
+	Time         time.Time `db:"time"`
+}
+
+func marshallQuizAnswersToRows(eventID string, at *sm.QuizAttempt) []quizAnswerRow {
+	res := make([]quizAnswerRow, len(at.Answers))
+	for i, a := range at.Answers {
+		res[i] = quizAnswerRow{
+			EventID:      eventID,
+			ActivityName: at.ActivityName,
+			GroupName:    at.GroupName,
+			Number:       i,
+			Text:         a.Text,
+			Username:     a.Username,
+			Correct:      a.Correct,
+			TimedOut:     a.TimedOut,
+			Time:         a.Time.UTC(),
+		}
+	}
+	return res
+}
+
+func unmarshallQuizAnswersFromRows(rows []quizAnswerRow, loc *time.Location) []sm.QuizAnswer {
+	res := make([]sm.QuizAnswer, len(rows))
+	for i, row := range rows {
+		res[i] = sm.QuizAnswer{
+			Text:     row.Text,
+			Username: row.Username,
+			Correct:  row.Correct,
+			TimedOut: row.TimedOut,
+			Time:     row.Time.In(loc),
+		}
+	}
+	return res
+}
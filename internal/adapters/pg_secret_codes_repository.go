@@ -0,0 +1,275 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type pgSecretCodesRepository struct {
+	db *sqlx.DB
+}
+
+func NewPGSecretCodesRepository() (sm.SecretCodesRepository, func() error) {
+	uri := os.Getenv("DATABASE_URI")
+	if uri == "" {
+		panic("DATABASE_URI environment variable not set")
+	}
+	db := sqlx.MustConnect("postgres", uri)
+
+	return &pgSecretCodesRepository{db: db}, db.Close
+}
+
+func (r *pgSecretCodesRepository) Save(ctx context.Context, eventID string, code *sm.SecretCode) error {
+	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		if err := r.requireExecResult(tx.NamedExecContext(ctx,
+			`INSERT INTO
+				secret_codes (
+					event_id, code, activity_name, skill_type, points, max_redemptions, max_group_redemptions, created_by
+				)
+			 VALUES (
+					:event_id, :code, :activity_name, :skill_type, :points, :max_redemptions, :max_group_redemptions, :created_by
+				)`,
+			marshallSecretCodeToRow(eventID, code),
+		)); pgutils.IsUniqueViolationError(err) {
+			return sm.ErrSecretCodeAlreadyExists
+		} else if err != nil {
+			return err
+		}
+
+		return r.insertRedemptions(ctx, tx, eventID, code)
+	})
+}
+
+func (r *pgSecretCodesRepository) SecretCodesByActivity(
+	ctx context.Context,
+	eventID string,
+	activityName string,
+) ([]*sm.SecretCode, error) {
+	var res []*sm.SecretCode
+	var err error
+	if err = pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		res, err = r.secretCodesByActivity(ctx, tx, eventID, activityName)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *pgSecretCodesRepository) Update(
+	ctx context.Context,
+	eventID string,
+	code string,
+	updateFn func(innerCtx context.Context, code *sm.SecretCode) error,
+) error {
+	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		c, err := r.secretCodeForUpdate(ctx, tx, eventID, code)
+		if errors.Is(err, sql.ErrNoRows) {
+			return sm.ErrSecretCodeNotFound
+		} else if err != nil {
+			return err
+		}
+
+		if err = updateFn(ctx, c); err != nil {
+			return err
+		}
+
+		if _, err = tx.ExecContext(ctx,
+			`DELETE FROM secret_code_redemptions WHERE event_id = $1 AND code = $2`, eventID, c.Code,
+		); err != nil {
+			return err
+		}
+
+		return r.insertRedemptions(ctx, tx, eventID, c)
+	})
+}
+
+// secretCodeForUpdate блокирует код, чтобы параллельные вводы не превысили его
+// ограничения.
+func (r *pgSecretCodesRepository) secretCodeForUpdate(
+	ctx context.Context,
+	qx sqlx.QueryerContext,
+	eventID string,
+	code string,
+) (*sm.SecretCode, error) {
+	var row secretCodeRow
+	if err := sqlx.GetContext(ctx, qx, &row,
+		`SELECT event_id, code, activity_name, skill_type, points, max_redemptions, max_group_redemptions, created_by
+		 FROM   secret_codes
+		 WHERE  event_id = $1 AND code = $2
+		 FOR UPDATE`, eventID, code,
+	); err != nil {
+		return nil, err
+	}
+
+	return r.unmarshallSecretCode(ctx, qx, row)
+}
+
+func (r *pgSecretCodesRepository) secretCodesByActivity(
+	ctx context.Context,
+	qx sqlx.QueryerContext,
+	eventID string,
+	activityName string,
+) ([]*sm.SecretCode, error) {
+	var rows []secretCodeRow
+	if err := sqlx.SelectContext(ctx, qx, &rows,
+		`SELECT   event_id, code, activity_name, skill_type, points, max_redemptions, max_group_redemptions, created_by
+		 FROM     secret_codes
+		 WHERE    event_id = $1 AND activity_name = $2
+		 ORDER BY code`, eventID, activityName,
+	); err != nil {
+		return nil, err
+	}
+
+	res := make([]*sm.SecretCode, len(rows))
+	for i, row := range rows {
+		c, err := r.unmarshallSecretCode(ctx, qx, row)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = c
+	}
+	return res, nil
+}
+
+func (r *pgSecretCodesRepository) unmarshallSecretCode(
+	ctx context.Context,
+	qx sqlx.QueryerContext,
+	row secretCodeRow,
+) (*sm.SecretCode, error) {
+	loc, err := eventLocation(ctx, qx, row.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	var redemptionRows []secretCodeRedemptionRow
+	if err = sqlx.SelectContext(ctx, qx, &redemptionRows,
+		`SELECT   r.event_id, r.code, c.group_name, r.username, r.time
+		 FROM     secret_code_redemptions r
+		 JOIN     characters c ON c.event_id = r.event_id AND c.id = r.character_id
+		 WHERE    r.event_id = $1 AND r.code = $2
+		 ORDER BY r.time`, row.EventID, row.Code,
+	); err != nil {
+		return nil, err
+	}
+
+	return sm.UnmarshallSecretCodeFromDB(
+		row.Code,
+		row.ActivityName,
+		row.SkillType,
+		row.Points,
+		row.MaxRedemptions,
+		row.MaxGroupRedemptions,
+		row.CreatedBy,
+		unmarshallSecretCodeRedemptionsFromRows(redemptionRows, loc),
+	)
+}
+
+func (r *pgSecretCodesRepository) insertRedemptions(
+	ctx context.Context,
+	ex sqlx.ExtContext,
+	eventID string,
+	code *sm.SecretCode,
+) error {
+	if len(code.Redemptions) == 0 {
+		return nil
+	}
+
+	return r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
+		`INSERT INTO
+			secret_code_redemptions (event_id, code, character_id, username, time)
+		 VALUES (
+			:event_id, :code,
+			(SELECT id FROM characters WHERE event_id = :event_id AND group_name = :group_name),
+			:username, :time
+		 )`,
+		marshallSecretCodeRedemptionsToRows(eventID, code.Code, code.Redemptions),
+	))
+}
+
+func (r *pgSecretCodesRepository) requireExecResult(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+
+	aff, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if aff == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+type secretCodeRow struct {
+	EventID             string `db:"event_id"`
+	Code                string `db:"code"`
+	ActivityName        string `db:"activity_name"`
+	SkillType           string `db:"skill_type"`
+	Points              int    `db:"points"`
+	MaxRedemptions      int    `db:"max_redemptions"`
+	MaxGroupRedemptions int    `db:"max_group_redemptions"`
+	CreatedBy           string `db:"created_by"`
+}
+
+func marshallSecretCodeToRow(eventID string, c *sm.SecretCode) secretCodeRow {
+	return secretCodeRow{
+		EventID:             eventID,
+		Code:                c.Code,
+		ActivityName:        c.ActivityName,
+		SkillType:           c.Skill.String(),
+		Points:              c.Points,
+		MaxRedemptions:      c.MaxRedemptions,
+		MaxGroupRedemptions: c.MaxGroupRedemptions,
+		CreatedBy:           c.CreatedBy,
+	}
+}
+
+type secretCodeRedemptionRow struct {
+	EventID   string    `db:"event_id"`
+	Code      string    `db:"code"`
+	GroupName string    `db:"group_name"`
+	Username  string    `db:"username"`
+	Time      time.Time `db:"time"`
+}
+
+func marshallSecretCodeRedemptionsToRows(
+	eventID string,
+	code string,
+	rs []sm.CodeRedemption,
+) []secretCodeRedemptionRow {
+	res := make([]secretCodeRedemptionRow, len(rs))
+	for i, r := range rs {
+		res[i] = secretCodeRedemptionRow{
+			EventID:   eventID,
+			Code:      code,
+			GroupName: r.GroupName,
+			Username:  r.Username,
+			Time:      r.Time.UTC(),
+		}
+	}
+	return res
+}
+
+func unmarshallSecretCodeRedemptionsFromRows(rows []secretCodeRedemptionRow, loc *time.Location) []sm.CodeRedemption {
+	res := make([]sm.CodeRedemption, len(rows))
+	for i, row := range rows {
+		res[i] = sm.CodeRedemption{
+			GroupName: row.GroupName,
+			Username:  row.Username,
+			Time:      row.Time.In(loc),
+		}
+	}
+	return res
+}
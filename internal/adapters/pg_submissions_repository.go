@@ -0,0 +1,219 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type pgSubmissionsRepository struct {
+	db *sqlx.DB
+}
+
+func NewPGSubmissionsRepository() (sm.SubmissionsRepository, func() error) {
+	uri := os.Getenv("DATABASE_URI")
+	if uri == "" {
+		panic("DATABASE_URI environment variable not set")
+	}
+	db := sqlx.MustConnect("postgres", uri)
+
+	return &pgSubmissionsRepository{db: db}, db.Close
+}
+
+func (r *pgSubmissionsRepository) Save(ctx context.Context, eventID string, s *sm.Submission) error {
+	_, err := r.db.NamedExecContext(ctx,
+		`INSERT INTO
+			submissions (
+				event_id, id, activity_name, character_id, username, kind, text, file_id, status, submitted_at,
+				reviewed_by, skill_type, points, reason, reviewed_at
+			)
+		 VALUES (
+				:event_id, :id, :activity_name,
+				(SELECT id FROM characters WHERE event_id = :event_id AND group_name = :group_name),
+				:username, :kind, :text, :file_id, :status, :submitted_at,
+				:reviewed_by, :skill_type, :points, :reason, :reviewed_at
+			)`,
+		marshallSubmissionToRow(eventID, s),
+	)
+	// Уникальный индекс не даёт сохранить второе непроверенное решение группы.
+	if pgutils.IsUniqueViolationError(err) {
+		return sm.ErrSubmissionAlreadyPending
+	}
+	return err
+}
+
+func (r *pgSubmissionsRepository) PendingSubmissions(
+	ctx context.Context,
+	eventID string,
+	activityName string,
+) ([]*sm.Submission, error) {
+	var res []*sm.Submission
+	if err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		loc, err := eventLocation(ctx, tx, eventID)
+		if err != nil {
+			return err
+		}
+
+		var rows []submissionRow
+		if err = sqlx.SelectContext(ctx, tx, &rows,
+			`SELECT   s.event_id, s.id, s.activity_name, c.group_name, s.username, s.kind, s.text, s.file_id,
+			          s.status, s.submitted_at, s.reviewed_by, s.skill_type, s.points, s.reason, s.reviewed_at
+			 FROM     submissions s
+			 JOIN     characters c ON c.event_id = s.event_id AND c.id = s.character_id
+			 WHERE    s.event_id = $1 AND s.activity_name = $2 AND s.status = $3
+			 ORDER BY s.submitted_at`, eventID, activityName, sm.SubmissionPending.String(),
+		); err != nil {
+			return err
+		}
+
+		res = make([]*sm.Submission, len(rows))
+		for i, row := range rows {
+			if res[i], err = unmarshallSubmissionFromRow(row, loc); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *pgSubmissionsRepository) Update(
+	ctx context.Context,
+	eventID string,
+	id string,
+	updateFn func(innerCtx context.Context, s *sm.Submission) error,
+) error {
+	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		loc, err := eventLocation(ctx, tx, eventID)
+		if err != nil {
+			return err
+		}
+
+		// Решение блокируется, чтобы два администратора не проверили его одновременно.
+		var row submissionRow
+		err = sqlx.GetContext(ctx, tx, &row,
+			`SELECT s.event_id, s.id, s.activity_name, c.group_name, s.username, s.kind, s.text, s.file_id,
+			        s.status, s.submitted_at, s.reviewed_by, s.skill_type, s.points, s.reason, s.reviewed_at
+			 FROM   submissions s
+			 JOIN   characters c ON c.event_id = s.event_id AND c.id = s.character_id
+			 WHERE  s.event_id = $1 AND s.id = $2
+			 FOR UPDATE OF s`, eventID, id,
+		)
+		if errors.Is(err, sql.ErrNoRows) {
+			return sm.ErrSubmissionNotFound
+		} else if err != nil {
+			return err
+		}
+
+		s, err := unmarshallSubmissionFromRow(row, loc)
+		if err != nil {
+			return err
+		}
+
+		if err = updateFn(ctx, s); err != nil {
+			return err
+		}
+
+		_, err = tx.NamedExecContext(ctx,
+			`UPDATE submissions
+			 SET    status = :status,
+			        reviewed_by = :reviewed_by,
+			        skill_type = :skill_type,
+			        points = :points,
+			        reason = :reason,
+			        reviewed_at = :reviewed_at
+			 WHERE  event_id = :event_id AND id = :id`,
+			marshallSubmissionToRow(eventID, s),
+		)
+		return err
+	})
+}
+
+type submissionRow struct {
+	EventID      string     `db:"event_id"`
+	ID           string     `db:"id"`
+	ActivityName string     `db:"activity_name"`
+	GroupName    string     `db:"group_name"`
+	Username     string     `db:"username"`
+	Kind         string     `db:"kind"`
+	Text         string     `db:"text"`
+	FileID       string     `db:"file_id"`
+	Status       string     `db:"status"`
+	SubmittedAt  time.Time  `db:"submitted_at"`
+	ReviewedBy   *string    `db:"reviewed_by"`
+	SkillType    *string    `db:"skill_type"`
+	Points       *int       `db:"points"`
+	Reason       *string    `db:"reason"`
+	ReviewedAt   *time.Time `db:"reviewed_at"`
+}
+
+func marshallSubmissionToRow(eventID string, s *sm.Submission) submissionRow {
+	row := submissionRow{
+		EventID:      eventID,
+		ID:           s.ID,
+		ActivityName: s.ActivityName,
+		GroupName:    s.GroupName,
+		Username:     s.Username,
+		Kind:         s.Kind.String(),
+		Text:         s.Text,
+		FileID:       s.FileID,
+		Status:       s.Status.String(),
+		SubmittedAt:  s.SubmittedAt.UTC(),
+	}
+	if s.Review != nil {
+		row.ReviewedBy = &s.Review.Username
+		row.ReviewedAt = timeUTCOrNil(&s.Review.Time)
+		if !s.Review.Skill.IsZero() {
+			skill := s.Review.Skill.String()
+			row.SkillType = &skill
+			row.Points = &s.Review.Points
+		}
+		if s.Review.Reason != "" {
+			row.Reason = &s.Review.Reason
+		}
+	}
+	return row
+}
+
+func unmarshallSubmissionFromRow(row submissionRow, loc *time.Location) (*sm.Submission, error) {
+	var review *sm.SubmissionReview
+	if row.ReviewedAt != nil {
+		review = &sm.SubmissionReview{
+			Username: derefOrEmpty(row.ReviewedBy),
+			Reason:   derefOrEmpty(row.Reason),
+			Time:     row.ReviewedAt.In(loc),
+		}
+		if row.SkillType != nil {
+			skill, err := sm.NewSkillTypeFromString(*row.SkillType)
+			if err != nil {
+				return nil, err
+			}
+			review.Skill = skill
+		}
+		if row.Points != nil {
+			review.Points = *row.Points
+		}
+	}
+
+	return sm.UnmarshallSubmissionFromDB(
+		row.ID,
+		row.ActivityName,
+		row.GroupName,
+		row.Username,
+		row.Kind,
+		row.Text,
+		row.FileID,
+		row.Status,
+		row.SubmittedAt.In(loc),
+		review,
+	)
+}
@@ -26,9 +26,9 @@ func NewPGUsersRepository() (sm.UsersRepository, func() error) {
 	return &pgUsersRepository{db: db}, db.Close
 }
 
-func (r *pgUsersRepository) Save(ctx context.Context, user sm.User) error {
+func (r *pgUsersRepository) Save(ctx context.Context, eventID string, user sm.User) error {
 	if err := pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
-		return r.save(ctx, tx, user)
+		return r.save(ctx, tx, eventID, user)
 	}); pgutils.IsUniqueViolationError(err) {
 		return sm.ErrUserAlreadyExists
 	} else if err != nil {
@@ -37,11 +37,11 @@ func (r *pgUsersRepository) Save(ctx context.Context, user sm.User) error {
 	return nil
 }
 
-func (r *pgUsersRepository) User(ctx context.Context, username string) (sm.User, error) {
+func (r *pgUsersRepository) User(ctx context.Context, eventID string, username string) (sm.User, error) {
 	var user sm.User
 	var err error
 	if err = pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
-		user, err = r.user(ctx, tx, username)
+		user, err = r.user(ctx, tx, eventID, username)
 		return err
 	}); errors.Is(err, sql.ErrNoRows) {
 		return sm.User{}, sm.ErrUserNotFound
@@ -51,16 +51,52 @@ func (r *pgUsersRepository) User(ctx context.Context, username string) (sm.User,
 	return user, nil
 }
 
-func (r *pgUsersRepository) save(ctx context.Context, ex sqlx.ExtContext, user sm.User) error {
+func (r *pgUsersRepository) Update(
+	ctx context.Context,
+	eventID string,
+	username string,
+	updateFn func(innerCtx context.Context, user *sm.User) error,
+) error {
+	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		user, err := r.user(ctx, tx, eventID, username)
+		if errors.Is(err, sql.ErrNoRows) {
+			return sm.ErrUserNotFound
+		} else if err != nil {
+			return err
+		}
+
+		err = updateFn(ctx, &user)
+		if err != nil {
+			return err
+		}
+
+		return r.update(ctx, tx, eventID, user)
+	})
+}
+
+func (r *pgUsersRepository) save(ctx context.Context, ex sqlx.ExtContext, eventID string, user sm.User) error {
+	return r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
+		`INSERT INTO users (event_id, username, role, chat_id) VALUES (:event_id, :username, :role, :chat_id)`,
+		marshallUserToRow(eventID, user),
+	))
+}
+
+func (r *pgUsersRepository) update(ctx context.Context, ex sqlx.ExtContext, eventID string, user sm.User) error {
 	return r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
-		`INSERT INTO users (username, role) VALUES (:username, :role)`, marshallUserToRow(user),
+		`UPDATE users SET role = :role, chat_id = :chat_id WHERE event_id = :event_id AND username = :username`,
+		marshallUserToRow(eventID, user),
 	))
 }
 
-func (r *pgUsersRepository) user(ctx context.Context, qx sqlx.QueryerContext, username string) (sm.User, error) {
+func (r *pgUsersRepository) user(
+	ctx context.Context,
+	qx sqlx.QueryerContext,
+	eventID string,
+	username string,
+) (sm.User, error) {
 	var userRow userRow
 	if err := sqlx.GetContext(ctx, qx, &userRow,
-		`SELECT username, role FROM users WHERE username = $1`, username,
+		`SELECT event_id, username, role, chat_id FROM users WHERE event_id = $1 AND username = $2`, eventID, username,
 	); err != nil {
 		return sm.User{}, err
 	}
@@ -85,17 +121,35 @@ func (r *pgUsersRepository) requireExecResult(res sql.Result, err error) error {
 }
 
 type userRow struct {
+	EventID  string `db:"event_id"`
 	Username string `db:"username"`
 	Role     string `db:"role"`
+	ChatID   *int64 `db:"chat_id"`
 }
 
-func marshallUserToRow(u sm.User) userRow {
+func marshallUserToRow(eventID string, u sm.User) userRow {
 	return userRow{
+		EventID:  eventID,
 		Username: u.Username,
 		Role:     u.Role.String(),
+		ChatID:   nilIfZero(u.ChatID),
 	}
 }
 
 func unmarshallUserFromRow(u userRow) (sm.User, error) {
-	return sm.UnmarshallUserFromDB(u.Username, u.Role)
+	return sm.UnmarshallUserFromDB(u.Username, u.Role, derefOrZero(u.ChatID))
+}
+
+func nilIfZero(v int64) *int64 {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
+
+func derefOrZero(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
 }
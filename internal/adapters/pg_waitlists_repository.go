@@ -0,0 +1,271 @@
+package adapters
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/zhikh23/pgutils"
+
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type pgWaitlistsRepository struct {
+	db *sqlx.DB
+}
+
+func NewPGWaitlistsRepository() (sm.WaitlistsRepository, func() error) {
+	uri := os.Getenv("DATABASE_URI")
+	if uri == "" {
+		panic("DATABASE_URI environment variable not set")
+	}
+	db := sqlx.MustConnect("postgres", uri)
+
+	return &pgWaitlistsRepository{db: db}, db.Close
+}
+
+func (r *pgWaitlistsRepository) Waitlist(
+	ctx context.Context,
+	eventID string,
+	activityName string,
+) (*sm.Waitlist, error) {
+	var w *sm.Waitlist
+	var err error
+	if err = pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		w, err = r.waitlist(ctx, tx, eventID, activityName)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (r *pgWaitlistsRepository) Waitlists(ctx context.Context, eventID string) ([]*sm.Waitlist, error) {
+	var ws []*sm.Waitlist
+	var err error
+	if err = pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		ws, err = r.waitlists(ctx, tx, eventID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+func (r *pgWaitlistsRepository) Update(
+	ctx context.Context,
+	eventID string,
+	activityName string,
+	updateFn func(innerCtx context.Context, waitlist *sm.Waitlist) error,
+) error {
+	return pgutils.RunTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		// Блокируем точку, чтобы очередь не менялась параллельно.
+		var exists bool
+		err := sqlx.GetContext(ctx, tx, &exists,
+			`SELECT true FROM activities WHERE event_id = $1 AND name = $2 FOR UPDATE`, eventID, activityName,
+		)
+		if errors.Is(err, sql.ErrNoRows) {
+			return sm.ErrActivityNotFound
+		} else if err != nil {
+			return err
+		}
+
+		w, err := r.waitlist(ctx, tx, eventID, activityName)
+		if err != nil {
+			return err
+		}
+
+		err = updateFn(ctx, w)
+		if err != nil {
+			return err
+		}
+
+		return r.update(ctx, tx, eventID, w)
+	})
+}
+
+func (r *pgWaitlistsRepository) waitlist(
+	ctx context.Context,
+	qx sqlx.QueryerContext,
+	eventID string,
+	activityName string,
+) (*sm.Waitlist, error) {
+	var rows []waitlistEntryRow
+	if err := sqlx.SelectContext(ctx, qx, &rows,
+		`SELECT w.event_id, w.activity_name, c.group_name, w.from_, w.to_, w.joined_at,
+		        w.offer_start, w.offered_at, w.offer_expires_at
+		 FROM   waitlist_entries w
+		 JOIN   characters c ON c.event_id = w.event_id AND c.id = w.character_id
+		 WHERE  w.event_id = $1 AND w.activity_name = $2
+		 ORDER BY w.joined_at`, eventID, activityName,
+	); err != nil {
+		return nil, err
+	}
+
+	loc, err := eventLocation(ctx, qx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := unmarshallWaitlistEntriesFromRows(rows, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	return sm.UnmarshallWaitlistFromDB(activityName, entries)
+}
+
+func (r *pgWaitlistsRepository) waitlists(
+	ctx context.Context,
+	qx sqlx.QueryerContext,
+	eventID string,
+) ([]*sm.Waitlist, error) {
+	var rows []waitlistEntryRow
+	if err := sqlx.SelectContext(ctx, qx, &rows,
+		`SELECT w.event_id, w.activity_name, c.group_name, w.from_, w.to_, w.joined_at,
+		        w.offer_start, w.offered_at, w.offer_expires_at
+		 FROM   waitlist_entries w
+		 JOIN   characters c ON c.event_id = w.event_id AND c.id = w.character_id
+		 WHERE  w.event_id = $1
+		 ORDER BY w.activity_name, w.joined_at`, eventID,
+	); err != nil {
+		return nil, err
+	}
+
+	loc, err := eventLocation(ctx, qx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*sm.Waitlist, 0)
+	var last *sm.Waitlist
+	for _, row := range rows {
+		if last == nil || last.ActivityName != row.ActivityName {
+			w, err := sm.NewWaitlist(row.ActivityName)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, w)
+			last = w
+		}
+
+		e, err := unmarshallWaitlistEntryFromRow(row, loc)
+		if err != nil {
+			return nil, err
+		}
+		last.Entries = append(last.Entries, e)
+	}
+
+	return res, nil
+}
+
+func (r *pgWaitlistsRepository) update(
+	ctx context.Context,
+	ex sqlx.ExtContext,
+	eventID string,
+	w *sm.Waitlist,
+) error {
+	if _, err := ex.ExecContext(ctx,
+		`DELETE FROM waitlist_entries WHERE event_id = $1 AND activity_name = $2`, eventID, w.ActivityName,
+	); err != nil {
+		return err
+	}
+
+	if len(w.Entries) == 0 {
+		return nil
+	}
+
+	return r.requireExecResult(sqlx.NamedExecContext(ctx, ex,
+		`INSERT INTO
+			waitlist_entries (
+				event_id, activity_name, character_id, from_, to_, joined_at, offer_start, offered_at, offer_expires_at
+			)
+		 VALUES (
+				:event_id, :activity_name,
+				(SELECT id FROM characters WHERE event_id = :event_id AND group_name = :group_name),
+				:from_, :to_, :joined_at, :offer_start, :offered_at, :offer_expires_at
+			)`,
+		marshallWaitlistEntriesToRows(eventID, w.ActivityName, w.Entries),
+	))
+}
+
+func (r *pgWaitlistsRepository) requireExecResult(res sql.Result, err error) error {
+	if err != nil {
+		return err
+	}
+
+	aff, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if aff == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+type waitlistEntryRow struct {
+	EventID        string     `db:"event_id"`
+	ActivityName   string     `db:"activity_name"`
+	GroupName      string     `db:"group_name"`
+	From           *time.Time `db:"from_"`
+	To             *time.Time `db:"to_"`
+	JoinedAt       time.Time  `db:"joined_at"`
+	OfferStart     *time.Time `db:"offer_start"`
+	OfferedAt      *time.Time `db:"offered_at"`
+	OfferExpiresAt *time.Time `db:"offer_expires_at"`
+}
+
+func marshallWaitlistEntryToRow(eventID string, activityName string, e *sm.WaitlistEntry) waitlistEntryRow {
+	row := waitlistEntryRow{
+		EventID:      eventID,
+		ActivityName: activityName,
+		GroupName:    e.GroupName,
+		From:         timeUTCOrNil(e.From),
+		To:           timeUTCOrNil(e.To),
+		JoinedAt:     e.JoinedAt.UTC(),
+	}
+	if e.Offer != nil {
+		row.OfferStart = timeUTCOrNil(&e.Offer.Start)
+		row.OfferedAt = timeUTCOrNil(&e.Offer.OfferedAt)
+		row.OfferExpiresAt = timeUTCOrNil(&e.Offer.ExpiresAt)
+	}
+	return row
+}
+
+func marshallWaitlistEntriesToRows(eventID string, activityName string, es []*sm.WaitlistEntry) []waitlistEntryRow {
+	res := make([]waitlistEntryRow, len(es))
+	for i, e := range es {
+		res[i] = marshallWaitlistEntryToRow(eventID, activityName, e)
+	}
+	return res
+}
+
+func unmarshallWaitlistEntryFromRow(row waitlistEntryRow, loc *time.Location) (*sm.WaitlistEntry, error) {
+	return sm.UnmarshallWaitlistEntryFromDB(
+		row.GroupName,
+		timeInOrNil(row.From, loc),
+		timeInOrNil(row.To, loc),
+		row.JoinedAt.In(loc),
+		timeInOrNil(row.OfferStart, loc),
+		timeInOrNil(row.OfferedAt, loc),
+		timeInOrNil(row.OfferExpiresAt, loc),
+	)
+}
+
+func unmarshallWaitlistEntriesFromRows(rows []waitlistEntryRow, loc *time.Location) ([]*sm.WaitlistEntry, error) {
+	res := make([]*sm.WaitlistEntry, len(rows))
+	for i, row := range rows {
+		e, err := unmarshallWaitlistEntryFromRow(row, loc)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = e
+	}
+	return res, nil
+}
@@ -11,20 +11,68 @@ type Application struct {
 }
 
 type Commands struct {
-	StartInstruction command.StartInstructionHandler
-	AwardCharacter   command.AwardCharacterHandler
-	TakeSlot         command.TakeSlotHandler
+	StartInstruction         command.StartInstructionHandler
+	AwardCharacter           command.AwardCharacterHandler
+	TakeSlot                 command.TakeSlotHandler
+	CancelSlot               command.CancelSlotHandler
+	RevokeGrade              command.RevokeGradeHandler
+	CorrectGrade             command.CorrectGradeHandler
+	CheckInSlot              command.CheckInSlotHandler
+	MarkSlotNoShow           command.MarkSlotNoShowHandler
+	ReleaseSlot              command.ReleaseSlotHandler
+	ReleaseNoShows           command.ReleaseNoShowsHandler
+	RegisterChat             command.RegisterChatHandler
+	JoinWaitlist             command.JoinWaitlistHandler
+	LeaveWaitlist            command.LeaveWaitlistHandler
+	OfferWaitlistSlots       command.OfferWaitlistSlotsHandler
+	AcceptWaitlistOffer      command.AcceptWaitlistOfferHandler
+	DeclineWaitlistOffer     command.DeclineWaitlistOfferHandler
+	ApplyTimetable           command.ApplyTimetableHandler
+	UnlockAchievements       command.UnlockAchievementsHandler
+	JoinTeam                 command.JoinTeamHandler
+	ForceTakeSlot            command.ForceTakeSlotHandler
+	ForceCancelSlot          command.ForceCancelSlotHandler
+	PenalizeCharacter        command.PenalizeCharacterHandler
+	RenameCharacter          command.RenameCharacterHandler
+	ScheduleInstructionStart command.ScheduleInstructionStartHandler
+	RestartInstruction       command.RestartInstructionHandler
+	ExtendInstruction        command.ExtendInstructionHandler
+	PauseInstruction         command.PauseInstructionHandler
+	ResumeInstruction        command.ResumeInstructionHandler
+	IssueSecretCode          command.IssueSecretCodeHandler
+	RedeemSecretCode         command.RedeemSecretCodeHandler
+	SubmitProof              command.SubmitProofHandler
+	ApproveSubmission        command.ApproveSubmissionHandler
+	RejectSubmission         command.RejectSubmissionHandler
+	StartQuiz                command.StartQuizHandler
+	AnswerQuiz               command.AnswerQuizHandler
 }
 
 type Queries struct {
 	GetUser              query.GetUserHandler
 	CharacterByUsername  query.CharacterByUsernameHandler
+	GroupName            query.GroupNameHandler
 	GetCharacter         query.GetCharacterHandler
 	Rating               query.RatingHandler
 	GetActivity          query.GetActivityHandler
 	AdminActivity        query.AdminActivityHandler
+	AdminActivities      query.AdminActivitiesHandler
 	Activities           query.ActivitiesHandler
 	AvailableActivities  query.AvailableActivitiesHandler
 	AdditionalActivities query.AdditionalActivitiesHandler
 	AvailableSlots       query.AvailableSlotsHandler
+	GetEvent             query.GetEventHandler
+	CurrentEvent         query.CurrentEventHandler
+	LastActivityGrade    query.LastActivityGradeHandler
+	OverdueSlots         query.OverdueSlotsHandler
+	GroupWaitlists       query.GroupWaitlistsHandler
+	WaitlistOffers       query.WaitlistOffersHandler
+	WaitlistActivities   query.WaitlistActivitiesHandler
+	PlanTimetable        query.PlanTimetableHandler
+	EventOverview        query.EventOverviewHandler
+	SecretCodes          query.SecretCodesHandler
+	PendingSubmissions   query.PendingSubmissionsHandler
+	AvailableQuizzes     query.AvailableQuizzesHandler
+	GroupQuizAttempt     query.GroupQuizAttemptHandler
+	QuizResults          query.QuizResultsHandler
 }
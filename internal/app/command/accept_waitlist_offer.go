@@ -0,0 +1,148 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type AcceptWaitlistOffer struct {
+	EventID      string
+	GroupName    string
+	ActivityName string
+	Username     string
+}
+
+type AcceptWaitlistOfferHandler decorator.CommandHandler[AcceptWaitlistOffer]
+
+type acceptWaitlistOfferHandler struct {
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+	waitlists  sm.WaitlistsRepository
+	events     sm.EventsRepository
+}
+
+func NewAcceptWaitlistOfferHandler(
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	waitlists sm.WaitlistsRepository,
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) AcceptWaitlistOfferHandler {
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if waitlists == nil {
+		panic("waitlists repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[AcceptWaitlistOffer](
+		&acceptWaitlistOfferHandler{chars, activities, waitlists, events},
+		log, metricsClient,
+	)
+}
+
+func (h *acceptWaitlistOfferHandler) Handle(ctx context.Context, cmd AcceptWaitlistOffer) error {
+	event, err := h.events.Event(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	// Проверяем до обновления очереди: иначе отказ сняли бы с группы как
+	// неудачное бронирование.
+	char, err := h.chars.Character(ctx, cmd.EventID, cmd.GroupName)
+	if err != nil {
+		return err
+	}
+
+	if !char.IsCaptain(cmd.Username) {
+		return sm.ErrNotCaptain
+	}
+
+	zones, err := activitiesZones(ctx, h.activities, event)
+	if err != nil {
+		return err
+	}
+
+	w, err := h.waitlists.Waitlist(ctx, cmd.EventID, cmd.ActivityName)
+	if err != nil {
+		return err
+	}
+
+	offer, err := w.ActiveOffer(cmd.GroupName, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// Очередь и слоты точки блокируют одну и ту же строку, поэтому слот
+	// бронируется вне транзакции очереди. Пока предложение действует, место
+	// придержано за группой.
+	takeErr := h.takeSlot(ctx, cmd, w, offer.Start, event, zones)
+
+	err = h.waitlists.Update(ctx, cmd.EventID, cmd.ActivityName, func(_ context.Context, w *sm.Waitlist) error {
+		if !w.Has(cmd.GroupName) {
+			return nil
+		}
+		if takeErr != nil {
+			// Слот успели занять: группа остаётся в очереди и ждёт следующего.
+			return w.WithdrawOffer(cmd.GroupName)
+		}
+		return w.Leave(cmd.GroupName)
+	})
+	if err != nil {
+		return err
+	}
+
+	return takeErr
+}
+
+func (h *acceptWaitlistOfferHandler) takeSlot(
+	ctx context.Context,
+	cmd AcceptWaitlistOffer,
+	w *sm.Waitlist,
+	start time.Time,
+	event *sm.Event,
+	zones map[string]string,
+) error {
+	return h.chars.Update(
+		ctx,
+		cmd.EventID,
+		cmd.GroupName,
+		func(innerCtx1 context.Context, char *sm.Character) error {
+			if !char.IsCaptain(cmd.Username) {
+				return sm.ErrNotCaptain
+			}
+			return h.activities.UpdateSlots(
+				innerCtx1,
+				cmd.EventID,
+				cmd.ActivityName,
+				func(innerCtx2 context.Context, activity *sm.Activity) error {
+					err := w.CanTakeSlot(activity, start, cmd.GroupName, time.Now())
+					if err != nil {
+						return err
+					}
+					err = char.CanReachSlotAt(start, cmd.ActivityName, zones, event.TravelTimes)
+					if err != nil {
+						return err
+					}
+					err = char.TakeSlot(start, cmd.ActivityName, event.Rules)
+					if err != nil {
+						return err
+					}
+					return activity.TakeSlot(start, cmd.GroupName)
+				})
+		})
+}
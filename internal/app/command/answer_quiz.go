@@ -0,0 +1,112 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// AnswerQuiz засчитывает ответ группы на текущий вопрос викторины. После
+// последнего ответа группе начисляются баллы.
+type AnswerQuiz struct {
+	EventID      string
+	GroupName    string
+	ActivityName string
+	Answer       string
+	Username     string
+}
+
+type AnswerQuizHandler decorator.CommandHandler[AnswerQuiz]
+
+type answerQuizHandler struct {
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+	quizzes    sm.QuizzesRepository
+	events     sm.EventsRepository
+}
+
+func NewAnswerQuizHandler(
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	quizzes sm.QuizzesRepository,
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) AnswerQuizHandler {
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if quizzes == nil {
+		panic("quizzes repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[AnswerQuiz](
+		&answerQuizHandler{chars, activities, quizzes, events},
+		log, metricsClient,
+	)
+}
+
+func (h *answerQuizHandler) Handle(ctx context.Context, cmd AnswerQuiz) error {
+	event, err := h.events.Event(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	quiz, err := h.quizzes.Quiz(ctx, cmd.EventID, cmd.ActivityName)
+	if err != nil {
+		return err
+	}
+
+	finished := false
+	if err = h.quizzes.UpdateAttempt(
+		ctx,
+		cmd.EventID,
+		cmd.ActivityName,
+		cmd.GroupName,
+		func(_ context.Context, at *sm.QuizAttempt) error {
+			if _, err := at.Answer(quiz, cmd.Answer, cmd.Username, time.Now()); err != nil {
+				return err
+			}
+			finished = at.IsFinished(quiz)
+			return nil
+		},
+	); err != nil {
+		return err
+	}
+
+	if !finished {
+		return nil
+	}
+
+	// Баллы начисляются отдельной транзакцией: если начислить их не удалось,
+	// ответы группы всё равно сохраняются и видны администратору точки.
+	return h.quizzes.UpdateAttempt(
+		ctx,
+		cmd.EventID,
+		cmd.ActivityName,
+		cmd.GroupName,
+		func(innerCtx1 context.Context, at *sm.QuizAttempt) error {
+			return h.chars.Update(innerCtx1, cmd.EventID, cmd.GroupName, func(innerCtx2 context.Context, char *sm.Character) error {
+				return h.activities.UpdateSlots(
+					innerCtx2,
+					cmd.EventID,
+					cmd.ActivityName,
+					func(_ context.Context, act *sm.Activity) error {
+						return act.AwardQuiz(char, quiz, at, event.Rules)
+					})
+			})
+		},
+	)
+}
@@ -0,0 +1,106 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// ApplyTimetable бронирует слоты по плану расписания. Каждая бронь сохраняется
+// в своей транзакции: если часть броней не удалась, остальные остаются в силе.
+type ApplyTimetable struct {
+	EventID     string
+	Assignments []TimetableAssignment
+}
+
+type TimetableAssignment struct {
+	GroupName    string
+	ActivityName string
+	Start        time.Time
+}
+
+type ApplyTimetableHandler decorator.CommandHandler[ApplyTimetable]
+
+type applyTimetableHandler struct {
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+	events     sm.EventsRepository
+}
+
+func NewApplyTimetableHandler(
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) ApplyTimetableHandler {
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[ApplyTimetable](
+		&applyTimetableHandler{chars, activities, events},
+		log, metricsClient,
+	)
+}
+
+// Handle бронирует слоты по готовому расписанию. Каждая бронь проверяется заново,
+// поэтому брони, ставшие невозможными после построения расписания, пропускаются,
+// а ошибки по ним возвращаются вместе.
+func (h *applyTimetableHandler) Handle(ctx context.Context, cmd ApplyTimetable) error {
+	event, err := h.events.Event(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	zones, err := activitiesZones(ctx, h.activities, event)
+	if err != nil {
+		return err
+	}
+
+	var errs error
+	for _, a := range cmd.Assignments {
+		err = h.chars.Update(ctx, cmd.EventID, a.GroupName, func(innerCtx1 context.Context, char *sm.Character) error {
+			return h.activities.UpdateSlots(
+				innerCtx1,
+				cmd.EventID,
+				a.ActivityName,
+				func(innerCtx2 context.Context, activity *sm.Activity) error {
+					// План мог устареть, поэтому каждая бронь проверяется заново.
+					err := char.CanFitInstructionWindow(a.Start, event.Rules)
+					if err != nil {
+						return err
+					}
+					err = char.CanReachSlotAt(a.Start, a.ActivityName, zones, event.TravelTimes)
+					if err != nil {
+						return err
+					}
+					err = char.TakeSlot(a.Start, a.ActivityName, event.Rules)
+					if err != nil {
+						return err
+					}
+					return activity.TakeSlot(a.Start, a.GroupName)
+				})
+		})
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf(
+				"failed to book %q at %s for %s: %w", a.ActivityName, a.Start.Format(sm.TimeFormat), a.GroupName, err,
+			))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,80 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// ApproveSubmission принимает решение группы и начисляет за него баллы
+// командой AwardCharacter.
+type ApproveSubmission struct {
+	EventID      string
+	SubmissionID string
+	SkillType    string
+	Points       int
+	Username     string
+}
+
+type ApproveSubmissionHandler decorator.CommandHandler[ApproveSubmission]
+
+type approveSubmissionHandler struct {
+	submissions sm.SubmissionsRepository
+	events      sm.EventsRepository
+	award       AwardCharacterHandler
+}
+
+func NewApproveSubmissionHandler(
+	submissions sm.SubmissionsRepository,
+	events sm.EventsRepository,
+	award AwardCharacterHandler,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) ApproveSubmissionHandler {
+	if submissions == nil {
+		panic("submissions repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	if award == nil {
+		panic("award character handler is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[ApproveSubmission](
+		&approveSubmissionHandler{submissions, events, award},
+		log, metricsClient,
+	)
+}
+
+func (h *approveSubmissionHandler) Handle(ctx context.Context, cmd ApproveSubmission) error {
+	event, err := h.events.Event(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	st, err := event.Skills.Parse(cmd.SkillType)
+	if err != nil {
+		return err
+	}
+
+	// Если начисление не прошло, решение остаётся в очереди.
+	return h.submissions.Update(ctx, cmd.EventID, cmd.SubmissionID, func(innerCtx context.Context, s *sm.Submission) error {
+		if err := s.Approve(st, cmd.Points, cmd.Username); err != nil {
+			return err
+		}
+
+		return h.award.Handle(innerCtx, AwardCharacter{
+			EventID:      cmd.EventID,
+			GroupName:    s.GroupName,
+			ActivityName: s.ActivityName,
+			SkillType:    cmd.SkillType,
+			Points:       cmd.Points,
+			Username:     cmd.Username,
+		})
+	})
+}
@@ -2,31 +2,45 @@ package command
 
 import (
 	"context"
+	"log/slog"
+
 	"github.com/zhikh23/sm-instruction/internal/common/decorator"
 	"github.com/zhikh23/sm-instruction/internal/domain/sm"
-	"log/slog"
 )
 
 type AwardCharacter struct {
+	EventID      string
 	GroupName    string
 	ActivityName string
 	SkillType    string
 	Points       int
+	// Username — кто начисляет баллы: администратор точки или организатор.
+	Username string
+	// Override позволяет организатору начислить баллы без проверки посещения.
+	Override bool
 }
 
 type AwardCharacterHandler decorator.CommandHandler[AwardCharacter]
 
 type awardCharacterHandler struct {
+	users      sm.UsersRepository
 	chars      sm.CharactersRepository
 	activities sm.ActivitiesRepository
+	events     sm.EventsRepository
 }
 
 func NewAwardCharacterHandler(
+	users sm.UsersRepository,
 	chars sm.CharactersRepository,
 	activities sm.ActivitiesRepository,
+	events sm.EventsRepository,
 	log *slog.Logger,
 	metricsClient decorator.MetricsClient,
 ) AwardCharacterHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
 	if chars == nil {
 		panic("characters repository is nil")
 	}
@@ -35,24 +49,58 @@ func NewAwardCharacterHandler(
 		panic("activities repository is nil")
 	}
 
+	if events == nil {
+		panic("events repository is nil")
+	}
+
 	return decorator.ApplyCommandDecorators[AwardCharacter](
-		&awardCharacterHandler{chars, activities},
+		&awardCharacterHandler{users, chars, activities, events},
 		log, metricsClient,
 	)
 }
 
 func (h *awardCharacterHandler) Handle(ctx context.Context, cmd AwardCharacter) error {
-	st, err := sm.NewSkillTypeFromString(cmd.SkillType)
+	event, err := h.events.Event(ctx, cmd.EventID)
 	if err != nil {
 		return err
 	}
 
-	act, err := h.activities.Activity(ctx, cmd.ActivityName)
+	st, err := event.Skills.Parse(cmd.SkillType)
 	if err != nil {
 		return err
 	}
 
-	return h.chars.Update(ctx, cmd.GroupName, func(innerCtx context.Context, char *sm.Character) error {
-		return act.Award(char, st, cmd.Points)
-	})
+	user, err := h.users.User(ctx, cmd.EventID, cmd.Username)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Override {
+		act, err := h.activities.Activity(ctx, cmd.EventID, cmd.ActivityName)
+		if err != nil {
+			return err
+		}
+
+		return h.chars.Update(ctx, cmd.EventID, cmd.GroupName, func(innerCtx context.Context, char *sm.Character) error {
+			return act.AwardWithoutVisit(user, char, st, cmd.Points)
+		})
+	}
+
+	// Начисление завершает посещение, поэтому обновляются и слоты активности.
+	return h.chars.Update(
+		ctx,
+		cmd.EventID,
+		cmd.GroupName,
+		func(innerCtx1 context.Context, char *sm.Character) error {
+			return h.activities.UpdateSlots(
+				innerCtx1,
+				cmd.EventID,
+				cmd.ActivityName,
+				func(innerCtx2 context.Context, activity *sm.Activity) error {
+					if err := sm.CanUserManageActivity(user, activity); err != nil {
+						return err
+					}
+					return activity.Award(char, st, cmd.Points, event.Rules)
+				})
+		})
 }
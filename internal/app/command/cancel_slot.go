@@ -0,0 +1,84 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type CancelSlot struct {
+	EventID      string
+	GroupName    string
+	ActivityName string
+	Start        time.Time
+	// Отменять брони может только капитан группы.
+	Username string
+}
+
+type CancelSlotHandler decorator.CommandHandler[CancelSlot]
+
+type cancelSlotHandler struct {
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+	events     sm.EventsRepository
+}
+
+func NewCancelSlotHandler(
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) CancelSlotHandler {
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[CancelSlot](
+		&cancelSlotHandler{chars, activities, events},
+		log, metricsClient,
+	)
+}
+
+func (h *cancelSlotHandler) Handle(ctx context.Context, cmd CancelSlot) error {
+	event, err := h.events.Event(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	err = h.chars.Update(ctx, cmd.EventID, cmd.GroupName, func(_ context.Context, char *sm.Character) error {
+		if !char.IsCaptain(cmd.Username) {
+			return sm.ErrNotCaptain
+		}
+		return char.CancelSlot(cmd.Start, cmd.ActivityName, event.Rules)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Репозитории не делят транзакцию, поэтому при ошибке бронь группы
+	// возвращается, чтобы она не расходилась с бронью точки.
+	err = h.activities.UpdateSlots(ctx, cmd.EventID, cmd.ActivityName, func(_ context.Context, activity *sm.Activity) error {
+		return activity.CancelSlot(cmd.Start, cmd.GroupName)
+	})
+	if err != nil {
+		undoErr := h.chars.Update(ctx, cmd.EventID, cmd.GroupName, func(_ context.Context, char *sm.Character) error {
+			return char.ForceTakeSlot(cmd.Start, cmd.ActivityName)
+		})
+		return errors.Join(err, undoErr)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type CorrectGrade struct {
+	EventID      string
+	GroupName    string
+	ActivityName string
+	GradeID      string
+	Points       int
+	Reason       string
+	Username     string
+}
+
+type CorrectGradeHandler decorator.CommandHandler[CorrectGrade]
+
+type correctGradeHandler struct {
+	users      sm.UsersRepository
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+}
+
+func NewCorrectGradeHandler(
+	users sm.UsersRepository,
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) CorrectGradeHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[CorrectGrade](
+		&correctGradeHandler{users, chars, activities},
+		log, metricsClient,
+	)
+}
+
+func (h *correctGradeHandler) Handle(ctx context.Context, cmd CorrectGrade) error {
+	user, err := h.users.User(ctx, cmd.EventID, cmd.Username)
+	if err != nil {
+		return err
+	}
+
+	act, err := h.activities.Activity(ctx, cmd.EventID, cmd.ActivityName)
+	if err != nil {
+		return err
+	}
+
+	if err = sm.CanUserManageActivity(user, act); err != nil {
+		return err
+	}
+
+	return h.chars.Update(ctx, cmd.EventID, cmd.GroupName, func(innerCtx context.Context, char *sm.Character) error {
+		return act.CorrectGrade(char, cmd.GradeID, cmd.Points, cmd.Reason, cmd.Username)
+	})
+}
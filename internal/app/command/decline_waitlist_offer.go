@@ -0,0 +1,58 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type DeclineWaitlistOffer struct {
+	EventID      string
+	GroupName    string
+	ActivityName string
+	Username     string
+}
+
+type DeclineWaitlistOfferHandler decorator.CommandHandler[DeclineWaitlistOffer]
+
+type declineWaitlistOfferHandler struct {
+	chars     sm.CharactersRepository
+	waitlists sm.WaitlistsRepository
+}
+
+func NewDeclineWaitlistOfferHandler(
+	chars sm.CharactersRepository,
+	waitlists sm.WaitlistsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) DeclineWaitlistOfferHandler {
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if waitlists == nil {
+		panic("waitlists repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[DeclineWaitlistOffer](
+		&declineWaitlistOfferHandler{chars, waitlists},
+		log, metricsClient,
+	)
+}
+
+func (h *declineWaitlistOfferHandler) Handle(ctx context.Context, cmd DeclineWaitlistOffer) error {
+	char, err := h.chars.Character(ctx, cmd.EventID, cmd.GroupName)
+	if err != nil {
+		return err
+	}
+
+	if !char.IsCaptain(cmd.Username) {
+		return sm.ErrNotCaptain
+	}
+
+	return h.waitlists.Update(ctx, cmd.EventID, cmd.ActivityName, func(_ context.Context, w *sm.Waitlist) error {
+		return w.DeclineOffer(cmd.GroupName)
+	})
+}
@@ -0,0 +1,71 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// ExtendInstruction продлевает окно Инструкции группы на Duration.
+type ExtendInstruction struct {
+	EventID   string
+	GroupName string
+	Duration  time.Duration
+	Username  string
+}
+
+type ExtendInstructionHandler decorator.CommandHandler[ExtendInstruction]
+
+type extendInstructionHandler struct {
+	users  sm.UsersRepository
+	chars  sm.CharactersRepository
+	events sm.EventsRepository
+}
+
+func NewExtendInstructionHandler(
+	users sm.UsersRepository,
+	chars sm.CharactersRepository,
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) ExtendInstructionHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[ExtendInstruction](
+		&extendInstructionHandler{users, chars, events},
+		log, metricsClient,
+	)
+}
+
+func (h *extendInstructionHandler) Handle(ctx context.Context, cmd ExtendInstruction) error {
+	user, err := h.users.User(ctx, cmd.EventID, cmd.Username)
+	if err != nil {
+		return err
+	}
+
+	if err = sm.CanUserManageEvent(user); err != nil {
+		return err
+	}
+
+	event, err := h.events.Event(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	return h.chars.Update(ctx, cmd.EventID, cmd.GroupName, func(innerCtx context.Context, char *sm.Character) error {
+		return char.Extend(cmd.Duration, cmd.Username, event.EmptySlots())
+	})
+}
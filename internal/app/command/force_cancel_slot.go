@@ -0,0 +1,80 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// ForceCancelSlot снимает бронь группы без ограничения на время до начала слота. Доступно только организаторам.
+type ForceCancelSlot struct {
+	EventID      string
+	GroupName    string
+	ActivityName string
+	Start        time.Time
+	Username     string
+}
+
+type ForceCancelSlotHandler decorator.CommandHandler[ForceCancelSlot]
+
+type forceCancelSlotHandler struct {
+	users      sm.UsersRepository
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+}
+
+func NewForceCancelSlotHandler(
+	users sm.UsersRepository,
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) ForceCancelSlotHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[ForceCancelSlot](
+		&forceCancelSlotHandler{users, chars, activities},
+		log, metricsClient,
+	)
+}
+
+func (h *forceCancelSlotHandler) Handle(ctx context.Context, cmd ForceCancelSlot) error {
+	user, err := h.users.User(ctx, cmd.EventID, cmd.Username)
+	if err != nil {
+		return err
+	}
+
+	if err = sm.CanUserManageEvent(user); err != nil {
+		return err
+	}
+
+	return h.chars.Update(
+		ctx,
+		cmd.EventID,
+		cmd.GroupName,
+		func(innerCtx1 context.Context, char *sm.Character) error {
+			return h.activities.UpdateSlots(
+				innerCtx1,
+				cmd.EventID,
+				cmd.ActivityName,
+				func(innerCtx2 context.Context, activity *sm.Activity) error {
+					if err := char.ForceCancelSlot(cmd.Start, cmd.ActivityName); err != nil {
+						return err
+					}
+					return activity.CancelSlot(cmd.Start, cmd.GroupName)
+				})
+		})
+}
@@ -0,0 +1,80 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// ForceTakeSlot бронирует слот за группу в обход правил мероприятия. Доступно только организаторам.
+type ForceTakeSlot struct {
+	EventID      string
+	GroupName    string
+	ActivityName string
+	Start        time.Time
+	Username     string
+}
+
+type ForceTakeSlotHandler decorator.CommandHandler[ForceTakeSlot]
+
+type forceTakeSlotHandler struct {
+	users      sm.UsersRepository
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+}
+
+func NewForceTakeSlotHandler(
+	users sm.UsersRepository,
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) ForceTakeSlotHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[ForceTakeSlot](
+		&forceTakeSlotHandler{users, chars, activities},
+		log, metricsClient,
+	)
+}
+
+func (h *forceTakeSlotHandler) Handle(ctx context.Context, cmd ForceTakeSlot) error {
+	user, err := h.users.User(ctx, cmd.EventID, cmd.Username)
+	if err != nil {
+		return err
+	}
+
+	if err = sm.CanUserManageEvent(user); err != nil {
+		return err
+	}
+
+	return h.chars.Update(
+		ctx,
+		cmd.EventID,
+		cmd.GroupName,
+		func(innerCtx1 context.Context, char *sm.Character) error {
+			return h.activities.UpdateSlots(
+				innerCtx1,
+				cmd.EventID,
+				cmd.ActivityName,
+				func(innerCtx2 context.Context, activity *sm.Activity) error {
+					if err := char.ForceTakeSlot(cmd.Start, cmd.ActivityName); err != nil {
+						return err
+					}
+					return activity.TakeSlot(cmd.Start, cmd.GroupName)
+				})
+		})
+}
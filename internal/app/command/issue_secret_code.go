@@ -0,0 +1,96 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// IssueSecretCode выпускает секретный код задания. За ввод кода группа получает
+// Points баллов в навык SkillType.
+type IssueSecretCode struct {
+	EventID      string
+	ActivityName string
+	Code         string
+	SkillType    string
+	Points       int
+	// Сколько раз код можно ввести всего; ноль — без ограничений.
+	MaxRedemptions int
+	// Сколько раз код может ввести одна группа.
+	MaxGroupRedemptions int
+	Username            string
+}
+
+type IssueSecretCodeHandler decorator.CommandHandler[IssueSecretCode]
+
+type issueSecretCodeHandler struct {
+	users      sm.UsersRepository
+	activities sm.ActivitiesRepository
+	codes      sm.SecretCodesRepository
+	events     sm.EventsRepository
+}
+
+func NewIssueSecretCodeHandler(
+	users sm.UsersRepository,
+	activities sm.ActivitiesRepository,
+	codes sm.SecretCodesRepository,
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) IssueSecretCodeHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if codes == nil {
+		panic("secret codes repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[IssueSecretCode](
+		&issueSecretCodeHandler{users, activities, codes, events},
+		log, metricsClient,
+	)
+}
+
+func (h *issueSecretCodeHandler) Handle(ctx context.Context, cmd IssueSecretCode) error {
+	user, err := h.users.User(ctx, cmd.EventID, cmd.Username)
+	if err != nil {
+		return err
+	}
+
+	act, err := h.activities.Activity(ctx, cmd.EventID, cmd.ActivityName)
+	if err != nil {
+		return err
+	}
+
+	if err = sm.CanUserManageActivity(user, act); err != nil {
+		return err
+	}
+
+	event, err := h.events.Event(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	st, err := event.Skills.Parse(cmd.SkillType)
+	if err != nil {
+		return err
+	}
+
+	code, err := act.IssueSecretCode(cmd.Code, st, cmd.Points, cmd.MaxRedemptions, cmd.MaxGroupRedemptions, cmd.Username)
+	if err != nil {
+		return err
+	}
+
+	return h.codes.Save(ctx, cmd.EventID, code)
+}
@@ -0,0 +1,85 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// JoinTeam добавляет пользователя в группу по коду из ссылки-приглашения.
+type JoinTeam struct {
+	EventID    string
+	InviteCode string
+	Username   string
+}
+
+type JoinTeamHandler decorator.CommandHandler[JoinTeam]
+
+type joinTeamHandler struct {
+	users sm.UsersRepository
+	chars sm.CharactersRepository
+}
+
+func NewJoinTeamHandler(
+	users sm.UsersRepository,
+	chars sm.CharactersRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) JoinTeamHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[JoinTeam](
+		&joinTeamHandler{users, chars},
+		log, metricsClient,
+	)
+}
+
+func (h *joinTeamHandler) Handle(ctx context.Context, cmd JoinTeam) error {
+	if cmd.InviteCode == "" {
+		return sm.ErrInvalidInviteCode
+	}
+
+	team, err := h.chars.CharacterByInviteCode(ctx, cmd.EventID, cmd.InviteCode)
+	if errors.Is(err, sm.ErrCharacterNotFound) {
+		return sm.ErrInvalidInviteCode
+	} else if err != nil {
+		return err
+	}
+
+	current, err := h.chars.CharacterByUsername(ctx, cmd.EventID, cmd.Username)
+	if err == nil && current.GroupName != team.GroupName {
+		return sm.ErrAlreadyInAnotherTeam
+	} else if err != nil && !errors.Is(err, sm.ErrCharacterNotFound) {
+		return err
+	}
+
+	user, err := h.users.User(ctx, cmd.EventID, cmd.Username)
+	if errors.Is(err, sm.ErrUserNotFound) {
+		user, err = sm.NewUser(cmd.Username, sm.Participant)
+		if err != nil {
+			return err
+		}
+		if err = h.users.Save(ctx, cmd.EventID, user); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	if user.Role != sm.Participant {
+		return sm.ErrUserIsNotParticipant
+	}
+
+	return h.chars.Update(ctx, cmd.EventID, team.GroupName, func(_ context.Context, char *sm.Character) error {
+		return char.JoinByInvite(cmd.Username, cmd.InviteCode)
+	})
+}
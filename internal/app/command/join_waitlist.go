@@ -0,0 +1,76 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type JoinWaitlist struct {
+	EventID      string
+	GroupName    string
+	ActivityName string
+	From         *time.Time
+	To           *time.Time
+	Username     string
+}
+
+type JoinWaitlistHandler decorator.CommandHandler[JoinWaitlist]
+
+type joinWaitlistHandler struct {
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+	waitlists  sm.WaitlistsRepository
+}
+
+func NewJoinWaitlistHandler(
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	waitlists sm.WaitlistsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) JoinWaitlistHandler {
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if waitlists == nil {
+		panic("waitlists repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[JoinWaitlist](
+		&joinWaitlistHandler{chars, activities, waitlists},
+		log, metricsClient,
+	)
+}
+
+func (h *joinWaitlistHandler) Handle(ctx context.Context, cmd JoinWaitlist) error {
+	char, err := h.chars.Character(ctx, cmd.EventID, cmd.GroupName)
+	if err != nil {
+		return err
+	}
+
+	if !char.IsCaptain(cmd.Username) {
+		return sm.ErrNotCaptain
+	}
+
+	activity, err := h.activities.Activity(ctx, cmd.EventID, cmd.ActivityName)
+	if err != nil {
+		return err
+	}
+
+	if activity.HasTaken(cmd.GroupName) {
+		return sm.ErrActivityAlreadyTaken
+	}
+
+	return h.waitlists.Update(ctx, cmd.EventID, cmd.ActivityName, func(_ context.Context, w *sm.Waitlist) error {
+		return w.Join(cmd.GroupName, cmd.From, cmd.To)
+	})
+}
@@ -0,0 +1,58 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type LeaveWaitlist struct {
+	EventID      string
+	GroupName    string
+	ActivityName string
+	Username     string
+}
+
+type LeaveWaitlistHandler decorator.CommandHandler[LeaveWaitlist]
+
+type leaveWaitlistHandler struct {
+	chars     sm.CharactersRepository
+	waitlists sm.WaitlistsRepository
+}
+
+func NewLeaveWaitlistHandler(
+	chars sm.CharactersRepository,
+	waitlists sm.WaitlistsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) LeaveWaitlistHandler {
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if waitlists == nil {
+		panic("waitlists repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[LeaveWaitlist](
+		&leaveWaitlistHandler{chars, waitlists},
+		log, metricsClient,
+	)
+}
+
+func (h *leaveWaitlistHandler) Handle(ctx context.Context, cmd LeaveWaitlist) error {
+	char, err := h.chars.Character(ctx, cmd.EventID, cmd.GroupName)
+	if err != nil {
+		return err
+	}
+
+	if !char.IsCaptain(cmd.Username) {
+		return sm.ErrNotCaptain
+	}
+
+	return h.waitlists.Update(ctx, cmd.EventID, cmd.ActivityName, func(_ context.Context, w *sm.Waitlist) error {
+		return w.Leave(cmd.GroupName)
+	})
+}
@@ -0,0 +1,79 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type MarkSlotNoShow struct {
+	EventID      string
+	GroupName    string
+	ActivityName string
+	Start        time.Time
+	// Username — администратор точки или организатор.
+	Username string
+}
+
+type MarkSlotNoShowHandler decorator.CommandHandler[MarkSlotNoShow]
+
+type markSlotNoShowHandler struct {
+	users      sm.UsersRepository
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+}
+
+func NewMarkSlotNoShowHandler(
+	users sm.UsersRepository,
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) MarkSlotNoShowHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[MarkSlotNoShow](
+		&markSlotNoShowHandler{users, chars, activities},
+		log, metricsClient,
+	)
+}
+
+func (h *markSlotNoShowHandler) Handle(ctx context.Context, cmd MarkSlotNoShow) error {
+	user, err := h.users.User(ctx, cmd.EventID, cmd.Username)
+	if err != nil {
+		return err
+	}
+
+	return h.chars.Update(
+		ctx,
+		cmd.EventID,
+		cmd.GroupName,
+		func(innerCtx1 context.Context, char *sm.Character) error {
+			return h.activities.UpdateSlots(
+				innerCtx1,
+				cmd.EventID,
+				cmd.ActivityName,
+				func(innerCtx2 context.Context, activity *sm.Activity) error {
+					if err := sm.CanUserManageActivity(user, activity); err != nil {
+						return err
+					}
+					if err := activity.MarkSlotNoShow(cmd.Start, cmd.GroupName); err != nil {
+						return err
+					}
+					return char.MarkSlotNoShow(cmd.Start, cmd.ActivityName)
+				})
+		})
+}
@@ -0,0 +1,134 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// OfferWaitlistSlots снимает просроченные предложения и предлагает свободные слоты
+// группам из листов ожидания.
+type OfferWaitlistSlots struct {
+	EventID string
+}
+
+type OfferWaitlistSlotsHandler decorator.CommandHandler[OfferWaitlistSlots]
+
+type offerWaitlistSlotsHandler struct {
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+	waitlists  sm.WaitlistsRepository
+	events     sm.EventsRepository
+}
+
+func NewOfferWaitlistSlotsHandler(
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	waitlists sm.WaitlistsRepository,
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) OfferWaitlistSlotsHandler {
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if waitlists == nil {
+		panic("waitlists repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[OfferWaitlistSlots](
+		&offerWaitlistSlotsHandler{chars, activities, waitlists, events},
+		log, metricsClient,
+	)
+}
+
+func (h *offerWaitlistSlotsHandler) Handle(ctx context.Context, cmd OfferWaitlistSlots) error {
+	event, err := h.events.Event(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	waitlists, err := h.waitlists.Waitlists(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	if len(waitlists) == 0 {
+		return nil
+	}
+
+	chars, err := h.chars.Characters(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	zones, err := activitiesZones(ctx, h.activities, event)
+	if err != nil {
+		return err
+	}
+
+	byGroup := make(map[string]*sm.Character, len(chars))
+	for _, char := range chars {
+		byGroup[char.GroupName] = char
+	}
+
+	now := time.Now()
+
+	// Одной группе не предлагаем несколько слотов на одно и то же время.
+	type groupSlot struct {
+		groupName string
+		start     int64
+	}
+	offered := make(map[groupSlot]bool)
+	for _, w := range waitlists {
+		for _, e := range w.Entries {
+			if e.HasOffer(now) {
+				offered[groupSlot{e.GroupName, e.Offer.Start.Unix()}] = true
+			}
+		}
+	}
+
+	for _, w := range waitlists {
+		activity, err := h.activities.Activity(ctx, cmd.EventID, w.ActivityName)
+		if err != nil {
+			return err
+		}
+
+		err = h.waitlists.Update(ctx, cmd.EventID, w.ActivityName, func(_ context.Context, w *sm.Waitlist) error {
+			w.ExpireOffers(now)
+
+			for _, slot := range activity.AvailableSlots() {
+				e, ok := w.OfferSlot(slot.Start, now, event.Rules, func(groupName string) bool {
+					char, ok := byGroup[groupName]
+					return ok &&
+						!offered[groupSlot{groupName, slot.Start.Unix()}] &&
+						!activity.HasTaken(groupName) &&
+						char.CanTakeSlotAt(slot.Start, event.Rules) == nil &&
+						char.CanReachSlotAt(slot.Start, activity.Name, zones, event.TravelTimes) == nil
+				})
+				if ok {
+					offered[groupSlot{e.GroupName, slot.Start.Unix()}] = true
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
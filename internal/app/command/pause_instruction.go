@@ -0,0 +1,59 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// PauseInstruction останавливает отсчёт окна Инструкции группы.
+type PauseInstruction struct {
+	EventID   string
+	GroupName string
+	Username  string
+}
+
+type PauseInstructionHandler decorator.CommandHandler[PauseInstruction]
+
+type pauseInstructionHandler struct {
+	users sm.UsersRepository
+	chars sm.CharactersRepository
+}
+
+func NewPauseInstructionHandler(
+	users sm.UsersRepository,
+	chars sm.CharactersRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) PauseInstructionHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[PauseInstruction](
+		&pauseInstructionHandler{users, chars},
+		log, metricsClient,
+	)
+}
+
+func (h *pauseInstructionHandler) Handle(ctx context.Context, cmd PauseInstruction) error {
+	user, err := h.users.User(ctx, cmd.EventID, cmd.Username)
+	if err != nil {
+		return err
+	}
+
+	if err = sm.CanUserManageEvent(user); err != nil {
+		return err
+	}
+
+	return h.chars.Update(ctx, cmd.EventID, cmd.GroupName, func(innerCtx context.Context, char *sm.Character) error {
+		return char.Pause(cmd.Username, time.Now())
+	})
+}
@@ -0,0 +1,89 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// PenalizeCharacter выписывает группе штраф. Администратор штрафует от имени
+// своей точки, организатор может штрафовать и без точки.
+type PenalizeCharacter struct {
+	EventID      string
+	GroupName    string
+	ActivityName string
+	Points       int
+	Reason       string
+	Username     string
+}
+
+type PenalizeCharacterHandler decorator.CommandHandler[PenalizeCharacter]
+
+type penalizeCharacterHandler struct {
+	users      sm.UsersRepository
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+	events     sm.EventsRepository
+}
+
+func NewPenalizeCharacterHandler(
+	users sm.UsersRepository,
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) PenalizeCharacterHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[PenalizeCharacter](
+		&penalizeCharacterHandler{users, chars, activities, events},
+		log, metricsClient,
+	)
+}
+
+func (h *penalizeCharacterHandler) Handle(ctx context.Context, cmd PenalizeCharacter) error {
+	user, err := h.users.User(ctx, cmd.EventID, cmd.Username)
+	if err != nil {
+		return err
+	}
+
+	if cmd.ActivityName == "" {
+		err = sm.CanUserManageEvent(user)
+	} else {
+		var act *sm.Activity
+		act, err = h.activities.Activity(ctx, cmd.EventID, cmd.ActivityName)
+		if err != nil {
+			return err
+		}
+		err = sm.CanUserManageActivity(user, act)
+	}
+	if err != nil {
+		return err
+	}
+
+	event, err := h.events.Event(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	return h.chars.Update(ctx, cmd.EventID, cmd.GroupName, func(innerCtx context.Context, char *sm.Character) error {
+		return char.Penalize(cmd.Points, cmd.Reason, cmd.ActivityName, cmd.Username, event.Rules)
+	})
+}
@@ -0,0 +1,107 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// RedeemSecretCode начисляет группе баллы за задание ActivityName по
+// секретному коду, который ввёл участник.
+type RedeemSecretCode struct {
+	EventID      string
+	GroupName    string
+	ActivityName string
+	Code         string
+	Username     string
+}
+
+type RedeemSecretCodeHandler decorator.CommandHandler[RedeemSecretCode]
+
+type redeemSecretCodeHandler struct {
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+	codes      sm.SecretCodesRepository
+	events     sm.EventsRepository
+}
+
+func NewRedeemSecretCodeHandler(
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	codes sm.SecretCodesRepository,
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) RedeemSecretCodeHandler {
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if codes == nil {
+		panic("secret codes repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[RedeemSecretCode](
+		&redeemSecretCodeHandler{chars, activities, codes, events},
+		log, metricsClient,
+	)
+}
+
+func (h *redeemSecretCodeHandler) Handle(ctx context.Context, cmd RedeemSecretCode) error {
+	event, err := h.events.Event(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	// Неверный код сохраняется как попытка, поэтому транзакция группы не
+	// откатывается, а ошибка возвращается после неё.
+	var wrongCodeErr error
+	err = h.chars.Update(ctx, cmd.EventID, cmd.GroupName, func(innerCtx1 context.Context, char *sm.Character) error {
+		now := time.Now()
+		if err := char.CanEnterCode(now, event.Rules); err != nil {
+			return err
+		}
+
+		err := h.codes.Update(
+			innerCtx1,
+			cmd.EventID,
+			sm.NormalizeSecretCode(cmd.Code),
+			func(innerCtx2 context.Context, code *sm.SecretCode) error {
+				if code.ActivityName != cmd.ActivityName {
+					return sm.ErrSecretCodeNotFound
+				}
+
+				// Начисление может завершить посещение, поэтому обновляются и слоты активности.
+				return h.activities.UpdateSlots(
+					innerCtx2,
+					cmd.EventID,
+					code.ActivityName,
+					func(_ context.Context, act *sm.Activity) error {
+						return act.RedeemCode(char, code, cmd.Username, event.Rules)
+					})
+			})
+		if errors.Is(err, sm.ErrSecretCodeNotFound) {
+			char.FailCodeAttempt(now, event.Rules)
+			wrongCodeErr = err
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return wrongCodeErr
+}
@@ -0,0 +1,45 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type RegisterChat struct {
+	EventID  string
+	Username string
+	ChatID   int64
+}
+
+type RegisterChatHandler decorator.CommandHandler[RegisterChat]
+
+type registerChatHandler struct {
+	users sm.UsersRepository
+}
+
+func NewRegisterChatHandler(
+	users sm.UsersRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) RegisterChatHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[RegisterChat](
+		&registerChatHandler{users},
+		log, metricsClient,
+	)
+}
+
+func (h *registerChatHandler) Handle(ctx context.Context, cmd RegisterChat) error {
+	return h.users.Update(ctx, cmd.EventID, cmd.Username, func(_ context.Context, user *sm.User) error {
+		if user.ChatID == cmd.ChatID {
+			return nil
+		}
+		return user.RegisterChat(cmd.ChatID)
+	})
+}
@@ -0,0 +1,69 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type RejectSubmission struct {
+	EventID      string
+	SubmissionID string
+	Reason       string
+	Username     string
+}
+
+type RejectSubmissionHandler decorator.CommandHandler[RejectSubmission]
+
+type rejectSubmissionHandler struct {
+	users       sm.UsersRepository
+	activities  sm.ActivitiesRepository
+	submissions sm.SubmissionsRepository
+}
+
+func NewRejectSubmissionHandler(
+	users sm.UsersRepository,
+	activities sm.ActivitiesRepository,
+	submissions sm.SubmissionsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) RejectSubmissionHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if submissions == nil {
+		panic("submissions repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[RejectSubmission](
+		&rejectSubmissionHandler{users, activities, submissions},
+		log, metricsClient,
+	)
+}
+
+func (h *rejectSubmissionHandler) Handle(ctx context.Context, cmd RejectSubmission) error {
+	user, err := h.users.User(ctx, cmd.EventID, cmd.Username)
+	if err != nil {
+		return err
+	}
+
+	return h.submissions.Update(ctx, cmd.EventID, cmd.SubmissionID, func(innerCtx context.Context, s *sm.Submission) error {
+		act, err := h.activities.Activity(innerCtx, cmd.EventID, s.ActivityName)
+		if err != nil {
+			return err
+		}
+
+		if err = sm.CanUserManageActivity(user, act); err != nil {
+			return err
+		}
+
+		return s.Reject(cmd.Reason, cmd.Username)
+	})
+}
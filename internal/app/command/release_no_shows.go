@@ -0,0 +1,102 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// ReleaseNoShows освобождает слоты, на которые группы не пришли. Рассматриваются
+// только брони, срок отметки которых истёк за последний Window: при первом
+// запуске не снимаются брони, которые администраторы не отметили раньше.
+type ReleaseNoShows struct {
+	EventID string
+	Window  time.Duration
+	// OnReleased вызывается один раз после прохода, если какие-то слоты освобождены.
+	OnReleased func(ctx context.Context, slots []ReleasedSlot)
+}
+
+type ReleasedSlot struct {
+	ActivityName string
+	GroupName    string
+	Start        time.Time
+}
+
+type ReleaseNoShowsHandler decorator.CommandHandler[ReleaseNoShows]
+
+type releaseNoShowsHandler struct {
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+	events     sm.EventsRepository
+}
+
+func NewReleaseNoShowsHandler(
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) ReleaseNoShowsHandler {
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[ReleaseNoShows](
+		&releaseNoShowsHandler{chars, activities, events},
+		log, metricsClient,
+	)
+}
+
+func (h *releaseNoShowsHandler) Handle(ctx context.Context, cmd ReleaseNoShows) error {
+	event, err := h.events.Event(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	activities, err := h.activities.Activities(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	released := make([]ReleasedSlot, 0)
+	var errs error
+	for _, activity := range activities {
+		for _, slot := range activity.RecentlyOverdueSlots(now, cmd.Window, event.Rules) {
+			for _, booking := range slot.Overdue(now, event.Rules.NoShowTimeout) {
+				err = releaseSlot(ctx, h.chars, h.activities, event, booking.Whom, activity.Name, slot.Start)
+				// Группа могла отметиться, пока шёл проход.
+				if errors.Is(err, sm.ErrSlotIsNotOverdue) || errors.Is(err, sm.ErrInvalidSlotTransition) {
+					continue
+				} else if err != nil {
+					errs = errors.Join(errs, err)
+					continue
+				}
+
+				released = append(released, ReleasedSlot{
+					ActivityName: activity.Name,
+					GroupName:    booking.Whom,
+					Start:        slot.Start,
+				})
+			}
+		}
+	}
+
+	if len(released) > 0 && cmd.OnReleased != nil {
+		cmd.OnReleased(ctx, released)
+	}
+
+	return errs
+}
@@ -0,0 +1,87 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type ReleaseSlot struct {
+	EventID      string
+	GroupName    string
+	ActivityName string
+	Start        time.Time
+}
+
+type ReleaseSlotHandler decorator.CommandHandler[ReleaseSlot]
+
+type releaseSlotHandler struct {
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+	events     sm.EventsRepository
+}
+
+func NewReleaseSlotHandler(
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) ReleaseSlotHandler {
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[ReleaseSlot](
+		&releaseSlotHandler{chars, activities, events},
+		log, metricsClient,
+	)
+}
+
+func (h *releaseSlotHandler) Handle(ctx context.Context, cmd ReleaseSlot) error {
+	event, err := h.events.Event(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	return releaseSlot(ctx, h.chars, h.activities, event, cmd.GroupName, cmd.ActivityName, cmd.Start)
+}
+
+// releaseSlot снимает просроченную бронь и у группы, и у точки.
+func releaseSlot(
+	ctx context.Context,
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	event *sm.Event,
+	groupName string,
+	activityName string,
+	start time.Time,
+) error {
+	return chars.Update(
+		ctx,
+		event.ID,
+		groupName,
+		func(innerCtx1 context.Context, char *sm.Character) error {
+			return activities.UpdateSlots(
+				innerCtx1,
+				event.ID,
+				activityName,
+				func(innerCtx2 context.Context, activity *sm.Activity) error {
+					if err := activity.ReleaseSlot(start, groupName, event.Rules); err != nil {
+						return err
+					}
+					return char.ReleaseSlot(start, activityName)
+				})
+		})
+}
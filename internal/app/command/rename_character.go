@@ -0,0 +1,71 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// RenameCharacter меняет название группы. Переименовывать группы может только
+// организатор, новое название проверяется по формату мероприятия.
+type RenameCharacter struct {
+	EventID      string
+	GroupName    string
+	NewGroupName string
+	Username     string
+}
+
+type RenameCharacterHandler decorator.CommandHandler[RenameCharacter]
+
+type renameCharacterHandler struct {
+	users  sm.UsersRepository
+	chars  sm.CharactersRepository
+	events sm.EventsRepository
+}
+
+func NewRenameCharacterHandler(
+	users sm.UsersRepository,
+	chars sm.CharactersRepository,
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) RenameCharacterHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[RenameCharacter](
+		&renameCharacterHandler{users, chars, events},
+		log, metricsClient,
+	)
+}
+
+func (h *renameCharacterHandler) Handle(ctx context.Context, cmd RenameCharacter) error {
+	user, err := h.users.User(ctx, cmd.EventID, cmd.Username)
+	if err != nil {
+		return err
+	}
+
+	if err = sm.CanUserManageEvent(user); err != nil {
+		return err
+	}
+
+	event, err := h.events.Event(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	return h.chars.Update(ctx, cmd.EventID, cmd.GroupName, func(innerCtx context.Context, char *sm.Character) error {
+		return char.Rename(cmd.NewGroupName, event.GroupNamePattern)
+	})
+}
@@ -0,0 +1,69 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// RestartInstruction начинает окно Инструкции группы заново с текущего момента.
+type RestartInstruction struct {
+	EventID   string
+	GroupName string
+	Username  string
+}
+
+type RestartInstructionHandler decorator.CommandHandler[RestartInstruction]
+
+type restartInstructionHandler struct {
+	users  sm.UsersRepository
+	chars  sm.CharactersRepository
+	events sm.EventsRepository
+}
+
+func NewRestartInstructionHandler(
+	users sm.UsersRepository,
+	chars sm.CharactersRepository,
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) RestartInstructionHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[RestartInstruction](
+		&restartInstructionHandler{users, chars, events},
+		log, metricsClient,
+	)
+}
+
+func (h *restartInstructionHandler) Handle(ctx context.Context, cmd RestartInstruction) error {
+	user, err := h.users.User(ctx, cmd.EventID, cmd.Username)
+	if err != nil {
+		return err
+	}
+
+	if err = sm.CanUserManageEvent(user); err != nil {
+		return err
+	}
+
+	event, err := h.events.Event(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	return h.chars.Update(ctx, cmd.EventID, cmd.GroupName, func(innerCtx context.Context, char *sm.Character) error {
+		return char.Restart(cmd.Username, event.EmptySlots())
+	})
+}
@@ -0,0 +1,59 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// ResumeInstruction возобновляет отсчёт окна Инструкции группы после паузы.
+type ResumeInstruction struct {
+	EventID   string
+	GroupName string
+	Username  string
+}
+
+type ResumeInstructionHandler decorator.CommandHandler[ResumeInstruction]
+
+type resumeInstructionHandler struct {
+	users sm.UsersRepository
+	chars sm.CharactersRepository
+}
+
+func NewResumeInstructionHandler(
+	users sm.UsersRepository,
+	chars sm.CharactersRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) ResumeInstructionHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[ResumeInstruction](
+		&resumeInstructionHandler{users, chars},
+		log, metricsClient,
+	)
+}
+
+func (h *resumeInstructionHandler) Handle(ctx context.Context, cmd ResumeInstruction) error {
+	user, err := h.users.User(ctx, cmd.EventID, cmd.Username)
+	if err != nil {
+		return err
+	}
+
+	if err = sm.CanUserManageEvent(user); err != nil {
+		return err
+	}
+
+	return h.chars.Update(ctx, cmd.EventID, cmd.GroupName, func(innerCtx context.Context, char *sm.Character) error {
+		return char.Resume(cmd.Username, time.Now())
+	})
+}
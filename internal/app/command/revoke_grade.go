@@ -0,0 +1,71 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type RevokeGrade struct {
+	EventID      string
+	GroupName    string
+	ActivityName string
+	GradeID      string
+	Reason       string
+	Username     string
+}
+
+type RevokeGradeHandler decorator.CommandHandler[RevokeGrade]
+
+type revokeGradeHandler struct {
+	users      sm.UsersRepository
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+}
+
+func NewRevokeGradeHandler(
+	users sm.UsersRepository,
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) RevokeGradeHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[RevokeGrade](
+		&revokeGradeHandler{users, chars, activities},
+		log, metricsClient,
+	)
+}
+
+func (h *revokeGradeHandler) Handle(ctx context.Context, cmd RevokeGrade) error {
+	user, err := h.users.User(ctx, cmd.EventID, cmd.Username)
+	if err != nil {
+		return err
+	}
+
+	act, err := h.activities.Activity(ctx, cmd.EventID, cmd.ActivityName)
+	if err != nil {
+		return err
+	}
+
+	if err = sm.CanUserManageActivity(user, act); err != nil {
+		return err
+	}
+
+	return h.chars.Update(ctx, cmd.EventID, cmd.GroupName, func(innerCtx context.Context, char *sm.Character) error {
+		return act.RevokeGrade(char, cmd.GradeID, cmd.Reason, cmd.Username)
+	})
+}
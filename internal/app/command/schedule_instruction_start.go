@@ -0,0 +1,81 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// ScheduleInstructionStart назначает волну старта: группы GroupNames начнут
+// Инструкцию не раньше Start. Если хотя бы одна группа уже начала Инструкцию,
+// волна не назначается никому.
+type ScheduleInstructionStart struct {
+	EventID    string
+	GroupNames []string
+	Start      time.Time
+	Username   string
+}
+
+type ScheduleInstructionStartHandler decorator.CommandHandler[ScheduleInstructionStart]
+
+type scheduleInstructionStartHandler struct {
+	users sm.UsersRepository
+	chars sm.CharactersRepository
+}
+
+func NewScheduleInstructionStartHandler(
+	users sm.UsersRepository,
+	chars sm.CharactersRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) ScheduleInstructionStartHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[ScheduleInstructionStart](
+		&scheduleInstructionStartHandler{users, chars},
+		log, metricsClient,
+	)
+}
+
+func (h *scheduleInstructionStartHandler) Handle(ctx context.Context, cmd ScheduleInstructionStart) error {
+	user, err := h.users.User(ctx, cmd.EventID, cmd.Username)
+	if err != nil {
+		return err
+	}
+
+	if err = sm.CanUserManageEvent(user); err != nil {
+		return err
+	}
+
+	// Сначала проверяем все группы, чтобы волна не оказалась назначена только
+	// части из них.
+	for _, groupName := range cmd.GroupNames {
+		char, err := h.chars.Character(ctx, cmd.EventID, groupName)
+		if err != nil {
+			return err
+		}
+		if err = char.ScheduleStart(cmd.Start); err != nil {
+			return err
+		}
+	}
+
+	for _, groupName := range cmd.GroupNames {
+		err = h.chars.Update(ctx, cmd.EventID, groupName, func(innerCtx context.Context, char *sm.Character) error {
+			return char.ScheduleStart(cmd.Start)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -9,31 +9,41 @@ import (
 )
 
 type StartInstruction struct {
+	EventID   string
 	GroupName string
+	// Инструкцию начинает капитан, остальные участники только наблюдают.
+	Username string
 }
 
 type StartInstructionHandler decorator.CommandHandler[StartInstruction]
 
 type startInstructionHandler struct {
-	users sm.UsersRepository
-	chars sm.CharactersRepository
+	users  sm.UsersRepository
+	chars  sm.CharactersRepository
+	events sm.EventsRepository
 }
 
 func NewStartInstructionHandler(
 	users sm.UsersRepository,
 	chars sm.CharactersRepository,
+	events sm.EventsRepository,
 	logs *slog.Logger,
 	metricsClient decorator.MetricsClient,
 ) StartInstructionHandler {
 	return decorator.ApplyCommandDecorators[StartInstruction](
-		&startInstructionHandler{users: users, chars: chars},
+		&startInstructionHandler{users: users, chars: chars, events: events},
 		logs,
 		metricsClient,
 	)
 }
 
+// Handle начинает Инструкцию группы. Повторный вызов ничего не меняет, поэтому
+// его можно выполнять при каждом /start.
 func (h *startInstructionHandler) Handle(ctx context.Context, cmd StartInstruction) error {
-	return h.chars.Update(ctx, cmd.GroupName, func(innerCtx context.Context, char *sm.Character) error {
+	return h.chars.Update(ctx, cmd.EventID, cmd.GroupName, func(innerCtx context.Context, char *sm.Character) error {
+		if !char.IsCaptain(cmd.Username) {
+			return sm.ErrNotCaptain
+		}
 		return char.Start()
 	})
 }
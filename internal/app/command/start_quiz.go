@@ -0,0 +1,85 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type StartQuiz struct {
+	EventID      string
+	GroupName    string
+	ActivityName string
+	Username     string
+}
+
+type StartQuizHandler decorator.CommandHandler[StartQuiz]
+
+type startQuizHandler struct {
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+	quizzes    sm.QuizzesRepository
+	events     sm.EventsRepository
+}
+
+func NewStartQuizHandler(
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	quizzes sm.QuizzesRepository,
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) StartQuizHandler {
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if quizzes == nil {
+		panic("quizzes repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[StartQuiz](
+		&startQuizHandler{chars, activities, quizzes, events},
+		log, metricsClient,
+	)
+}
+
+func (h *startQuizHandler) Handle(ctx context.Context, cmd StartQuiz) error {
+	event, err := h.events.Event(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	char, err := h.chars.Character(ctx, cmd.EventID, cmd.GroupName)
+	if err != nil {
+		return err
+	}
+
+	act, err := h.activities.Activity(ctx, cmd.EventID, cmd.ActivityName)
+	if err != nil {
+		return err
+	}
+
+	quiz, err := h.quizzes.Quiz(ctx, cmd.EventID, cmd.ActivityName)
+	if err != nil {
+		return err
+	}
+
+	at, err := act.StartQuiz(quiz, char.GroupName, cmd.Username, time.Now(), event.Rules)
+	if err != nil {
+		return err
+	}
+
+	return h.quizzes.SaveAttempt(ctx, cmd.EventID, at)
+}
@@ -0,0 +1,93 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// SubmitProof отправляет решение дополнительного задания на проверку
+// администраторам активности.
+type SubmitProof struct {
+	EventID      string
+	GroupName    string
+	ActivityName string
+	Kind         string
+	// Текст решения или подпись к фото и видео.
+	Text string
+	// Идентификатор файла в Telegram для фото и видео.
+	FileID   string
+	Username string
+}
+
+type SubmitProofHandler decorator.CommandHandler[SubmitProof]
+
+type submitProofHandler struct {
+	chars       sm.CharactersRepository
+	activities  sm.ActivitiesRepository
+	submissions sm.SubmissionsRepository
+}
+
+func NewSubmitProofHandler(
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	submissions sm.SubmissionsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) SubmitProofHandler {
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if submissions == nil {
+		panic("submissions repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[SubmitProof](
+		&submitProofHandler{chars, activities, submissions},
+		log, metricsClient,
+	)
+}
+
+func (h *submitProofHandler) Handle(ctx context.Context, cmd SubmitProof) error {
+	kind, err := sm.NewProofKindFromString(cmd.Kind)
+	if err != nil {
+		return err
+	}
+
+	char, err := h.chars.Character(ctx, cmd.EventID, cmd.GroupName)
+	if err != nil {
+		return err
+	}
+
+	act, err := h.activities.Activity(ctx, cmd.EventID, cmd.ActivityName)
+	if err != nil {
+		return err
+	}
+
+	// Пока решение группы не проверено, новое не принимается, чтобы не
+	// засорять очередь администраторов. Одновременные отправки отсекает
+	// уникальный индекс при сохранении.
+	pending, err := h.submissions.PendingSubmissions(ctx, cmd.EventID, act.Name)
+	if err != nil {
+		return err
+	}
+	for _, s := range pending {
+		if s.GroupName == char.GroupName {
+			return sm.ErrSubmissionAlreadyPending
+		}
+	}
+
+	s, err := act.Submit(char.GroupName, cmd.Username, kind, cmd.Text, cmd.FileID)
+	if err != nil {
+		return err
+	}
+
+	return h.submissions.Save(ctx, cmd.EventID, s)
+}
@@ -10,9 +10,12 @@ import (
 )
 
 type TakeSlot struct {
+	EventID      string
 	GroupName    string
 	ActivityName string
 	Start        time.Time
+	// Бронировать точки может только капитан группы.
+	Username string
 }
 
 type TakeSlotHandler decorator.CommandHandler[TakeSlot]
@@ -20,11 +23,15 @@ type TakeSlotHandler decorator.CommandHandler[TakeSlot]
 type takeSlotHandler struct {
 	chars      sm.CharactersRepository
 	activities sm.ActivitiesRepository
+	waitlists  sm.WaitlistsRepository
+	events     sm.EventsRepository
 }
 
 func NewTakeSlotHandler(
 	chars sm.CharactersRepository,
 	activities sm.ActivitiesRepository,
+	waitlists sm.WaitlistsRepository,
+	events sm.EventsRepository,
 	log *slog.Logger,
 	metricsClient decorator.MetricsClient,
 ) TakeSlotHandler {
@@ -36,22 +43,59 @@ func NewTakeSlotHandler(
 		panic("activities repository is nil")
 	}
 
+	if waitlists == nil {
+		panic("waitlists repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
 	return decorator.ApplyCommandDecorators[TakeSlot](
-		&takeSlotHandler{chars, activities},
+		&takeSlotHandler{chars, activities, waitlists, events},
 		log, metricsClient,
 	)
 }
 
 func (h *takeSlotHandler) Handle(ctx context.Context, cmd TakeSlot) error {
+	event, err := h.events.Event(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	zones, err := activitiesZones(ctx, h.activities, event)
+	if err != nil {
+		return err
+	}
+
+	w, err := h.waitlists.Waitlist(ctx, cmd.EventID, cmd.ActivityName)
+	if err != nil {
+		return err
+	}
+
 	return h.chars.Update(
 		ctx,
+		cmd.EventID,
 		cmd.GroupName,
 		func(innerCtx1 context.Context, char *sm.Character) error {
+			if !char.IsCaptain(cmd.Username) {
+				return sm.ErrNotCaptain
+			}
 			return h.activities.UpdateSlots(
 				innerCtx1,
+				cmd.EventID,
 				cmd.ActivityName,
 				func(innerCtx2 context.Context, activity *sm.Activity) error {
-					err := char.TakeSlot(cmd.Start, cmd.ActivityName)
+					// Место, предложенное группе из листа ожидания, занять нельзя.
+					err := w.CanTakeSlot(activity, cmd.Start, cmd.GroupName, time.Now())
+					if err != nil {
+						return err
+					}
+					err = char.CanReachSlotAt(cmd.Start, cmd.ActivityName, zones, event.TravelTimes)
+					if err != nil {
+						return err
+					}
+					err = char.TakeSlot(cmd.Start, cmd.ActivityName, event.Rules)
 					if err != nil {
 						return err
 					}
@@ -59,3 +103,21 @@ func (h *takeSlotHandler) Handle(ctx context.Context, cmd TakeSlot) error {
 				})
 		})
 }
+
+// activitiesZones возвращает зоны точек, если для события задано время на дорогу.
+func activitiesZones(
+	ctx context.Context,
+	activities sm.ActivitiesRepository,
+	event *sm.Event,
+) (map[string]string, error) {
+	if event.TravelTimes.IsZero() {
+		return nil, nil
+	}
+
+	acts, err := activities.Activities(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sm.ActivitiesZones(acts), nil
+}
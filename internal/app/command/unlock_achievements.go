@@ -0,0 +1,103 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// UnlockAchievements выдаёт группе достижения, условия которых выполнены.
+type UnlockAchievements struct {
+	EventID   string
+	GroupName string
+	// OnUnlocked вызывается после сохранения, если группа получила новые достижения.
+	OnUnlocked func(ctx context.Context, achievements []UnlockedAchievement)
+}
+
+type UnlockedAchievement struct {
+	ID    string
+	Title string
+}
+
+type UnlockAchievementsHandler decorator.CommandHandler[UnlockAchievements]
+
+type unlockAchievementsHandler struct {
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+	events     sm.EventsRepository
+}
+
+func NewUnlockAchievementsHandler(
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) UnlockAchievementsHandler {
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyCommandDecorators[UnlockAchievements](
+		&unlockAchievementsHandler{chars, activities, events},
+		log, metricsClient,
+	)
+}
+
+func (h *unlockAchievementsHandler) Handle(ctx context.Context, cmd UnlockAchievements) error {
+	event, err := h.events.Event(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	activities, err := h.activities.Activities(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	chars, err := h.chars.Characters(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+
+	facts := sm.AchievementFacts{
+		Activities: activities,
+		Characters: chars,
+		Rules:      event.Rules,
+		Skills:     event.Skills,
+	}
+
+	var unlocked []sm.Achievement
+	err = h.chars.Update(ctx, cmd.EventID, cmd.GroupName, func(innerCtx context.Context, char *sm.Character) error {
+		unlocked = char.UnlockAchievements(sm.AchievementRules, facts, time.Now())
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(unlocked) > 0 && cmd.OnUnlocked != nil {
+		res := make([]UnlockedAchievement, 0, len(unlocked))
+		for _, a := range unlocked {
+			rule, ok := sm.AchievementRuleByID(a.ID)
+			if !ok {
+				continue
+			}
+			res = append(res, UnlockedAchievement{ID: rule.ID, Title: rule.Title})
+		}
+		cmd.OnUnlocked(ctx, res)
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 )
 
 type Activities struct {
+	EventID string
 }
 
 type ActivitiesHandler decorator.QueryHandler[Activities, []Activity]
@@ -31,8 +32,8 @@ func NewActivitiesHandler(
 	)
 }
 
-func (h *activitiesHandler) Handle(ctx context.Context, _ Activities) ([]Activity, error) {
-	activities, err := h.activities.Activities(ctx)
+func (h *activitiesHandler) Handle(ctx context.Context, q Activities) ([]Activity, error) {
+	activities, err := h.activities.Activities(ctx, q.EventID)
 	if err != nil {
 		return nil, err
 	}
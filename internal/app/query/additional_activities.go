@@ -9,6 +9,7 @@ import (
 )
 
 type AdditionalActivities struct {
+	EventID   string
 	GroupName string
 }
 
@@ -34,9 +35,9 @@ func NewAdditionalActivitiesHandler(
 }
 
 func (h *additionalActivitiesHandler) Handle(
-	ctx context.Context, _ AdditionalActivities,
+	ctx context.Context, q AdditionalActivities,
 ) ([]Activity, error) {
-	activities, err := h.activities.AdditionalActivities(ctx)
+	activities, err := h.activities.AdditionalActivities(ctx, q.EventID)
 	if err != nil {
 		return nil, err
 	}
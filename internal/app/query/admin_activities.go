@@ -0,0 +1,45 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// AdminActivities — точки, на которые назначен администратор.
+type AdminActivities struct {
+	EventID  string
+	Username string
+}
+
+type AdminActivitiesHandler decorator.QueryHandler[AdminActivities, []Activity]
+
+type adminActivitiesHandler struct {
+	activities sm.ActivitiesRepository
+}
+
+func NewAdminActivitiesHandler(
+	activities sm.ActivitiesRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) AdminActivitiesHandler {
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[AdminActivities, []Activity](
+		&adminActivitiesHandler{activities},
+		log, metricsClient,
+	)
+}
+
+func (h *adminActivitiesHandler) Handle(ctx context.Context, q AdminActivities) ([]Activity, error) {
+	activities, err := h.activities.ActivitiesByAdmin(ctx, q.EventID, q.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertActivitiesToApp(activities), nil
+}
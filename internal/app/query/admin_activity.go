@@ -8,37 +8,55 @@ import (
 	"github.com/zhikh23/sm-instruction/internal/domain/sm"
 )
 
+// AdminActivity возвращает выбранную точку, если пользователь может ею управлять.
 type AdminActivity struct {
-	Username string
+	EventID      string
+	Username     string
+	ActivityName string
 }
 
 type AdminActivityHandler decorator.QueryHandler[AdminActivity, Activity]
 
 type adminActivityHandler struct {
+	users      sm.UsersRepository
 	activities sm.ActivitiesRepository
 }
 
 func NewAdminActivtyHandler(
+	users sm.UsersRepository,
 	activities sm.ActivitiesRepository,
 	log *slog.Logger,
 	metricsClient decorator.MetricsClient,
 ) AdminActivityHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
 	if activities == nil {
 		panic("activities repository is nil")
 	}
 
 	return decorator.ApplyQueryDecorators[AdminActivity, Activity](
-		&adminActivityHandler{activities},
+		&adminActivityHandler{users, activities},
 		log,
 		metricsClient,
 	)
 }
 
 func (h *adminActivityHandler) Handle(ctx context.Context, query AdminActivity) (Activity, error) {
-	act, err := h.activities.ActivityByAdmin(ctx, query.Username)
+	user, err := h.users.User(ctx, query.EventID, query.Username)
 	if err != nil {
 		return Activity{}, err
 	}
 
+	act, err := h.activities.Activity(ctx, query.EventID, query.ActivityName)
+	if err != nil {
+		return Activity{}, err
+	}
+
+	if err = sm.CanUserManageActivity(user, act); err != nil {
+		return Activity{}, err
+	}
+
 	return convertActivityToApp(act), nil
 }
@@ -10,6 +10,7 @@ import (
 )
 
 type AvailableActivities struct {
+	EventID   string
 	GroupName string
 }
 
@@ -18,11 +19,13 @@ type AvailableActivitiesHandler decorator.QueryHandler[AvailableActivities, []Ac
 type availableActivitiesHandler struct {
 	chars      sm.CharactersRepository
 	activities sm.ActivitiesRepository
+	events     sm.EventsRepository
 }
 
 func NewAvailableActivitiesHandler(
 	chars sm.CharactersRepository,
 	activities sm.ActivitiesRepository,
+	events sm.EventsRepository,
 	log *slog.Logger,
 	metricsClient decorator.MetricsClient,
 ) AvailableActivitiesHandler {
@@ -30,8 +33,12 @@ func NewAvailableActivitiesHandler(
 		panic("activities is nil")
 	}
 
+	if events == nil {
+		panic("events is nil")
+	}
+
 	return decorator.ApplyQueryDecorators[AvailableActivities, []Activity](
-		&availableActivitiesHandler{chars, activities},
+		&availableActivitiesHandler{chars, activities, events},
 		log, metricsClient,
 	)
 }
@@ -39,16 +46,21 @@ func NewAvailableActivitiesHandler(
 func (h *availableActivitiesHandler) Handle(
 	ctx context.Context, q AvailableActivities,
 ) ([]Activity, error) {
-	char, err := h.chars.Character(ctx, q.GroupName)
+	char, err := h.chars.Character(ctx, q.EventID, q.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := h.events.Event(ctx, q.EventID)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = char.CanTakeSlot(); err != nil {
+	if err = char.CanTakeSlot(event.Rules); err != nil {
 		return nil, err
 	}
 
-	activities, err := h.activities.AvailableActivities(ctx)
+	activities, err := h.activities.AvailableActivities(ctx, q.EventID)
 	if err != nil {
 		return nil, err
 	}
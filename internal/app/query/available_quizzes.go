@@ -0,0 +1,62 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// AvailableQuizzes возвращает викторины, которые группа ещё не прошла до конца.
+type AvailableQuizzes struct {
+	EventID   string
+	GroupName string
+}
+
+type AvailableQuizzesHandler decorator.QueryHandler[AvailableQuizzes, []Quiz]
+
+type availableQuizzesHandler struct {
+	quizzes sm.QuizzesRepository
+}
+
+func NewAvailableQuizzesHandler(
+	quizzes sm.QuizzesRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) AvailableQuizzesHandler {
+	if quizzes == nil {
+		panic("quizzes repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[AvailableQuizzes, []Quiz](
+		&availableQuizzesHandler{quizzes},
+		log,
+		metricsClient,
+	)
+}
+
+func (h *availableQuizzesHandler) Handle(ctx context.Context, query AvailableQuizzes) ([]Quiz, error) {
+	quizzes, err := h.quizzes.Quizzes(ctx, query.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Quiz, 0, len(quizzes))
+	for _, quiz := range quizzes {
+		at, err := h.quizzes.Attempt(ctx, query.EventID, quiz.ActivityName, query.GroupName)
+		if errors.Is(err, sm.ErrQuizNotStarted) {
+			res = append(res, convertQuizToApp(quiz))
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		if !at.IsFinished(quiz) {
+			res = append(res, convertQuizToApp(quiz))
+		}
+	}
+
+	return res, nil
+}
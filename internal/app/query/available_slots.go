@@ -11,6 +11,7 @@ import (
 )
 
 type AvailableSlots struct {
+	EventID      string
 	GroupName    string
 	ActivityName string
 }
@@ -20,11 +21,15 @@ type AvailableSlotsHandler decorator.QueryHandler[AvailableSlots, []Slot]
 type availableSlotsHandler struct {
 	chars      sm.CharactersRepository
 	activities sm.ActivitiesRepository
+	waitlists  sm.WaitlistsRepository
+	events     sm.EventsRepository
 }
 
 func NewAvailableSlotsHandler(
 	chars sm.CharactersRepository,
 	activities sm.ActivitiesRepository,
+	waitlists sm.WaitlistsRepository,
+	events sm.EventsRepository,
 	log *slog.Logger,
 	metricsClient decorator.MetricsClient,
 ) AvailableSlotsHandler {
@@ -32,28 +37,67 @@ func NewAvailableSlotsHandler(
 		panic("activities repository is nil")
 	}
 
+	if waitlists == nil {
+		panic("waitlists repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
 	return decorator.ApplyQueryDecorators[AvailableSlots, []Slot](
-		&availableSlotsHandler{chars, activities},
+		&availableSlotsHandler{chars, activities, waitlists, events},
 		log, metricsClient,
 	)
 }
 
 func (h *availableSlotsHandler) Handle(ctx context.Context, query AvailableSlots) ([]Slot, error) {
-	activity, err := h.activities.Activity(ctx, query.ActivityName)
+	activity, err := h.activities.Activity(ctx, query.EventID, query.ActivityName)
 	if err != nil {
 		return nil, err
 	}
 	activitySlots := activity.AvailableSlots()
 
-	char, err := h.chars.Character(ctx, query.GroupName)
+	// Места, предложенные другим группам из листа ожидания, не показываем,
+	// пока предложение не истечёт.
+	w, err := h.waitlists.Waitlist(ctx, query.EventID, query.ActivityName)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	activitySlots = funcs.Filter(activitySlots, func(slot *sm.Slot) bool {
+		return !w.IsHeldFor(slot, query.GroupName, now)
+	})
+
+	char, err := h.chars.Character(ctx, query.EventID, query.GroupName)
 	if err != nil {
 		return nil, err
 	}
 	charSlots := char.AvailableSlots()
 
+	event, err := h.events.Event(ctx, query.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	var zones map[string]string
+	if !event.TravelTimes.IsZero() {
+		activities, err := h.activities.Activities(ctx, query.EventID)
+		if err != nil {
+			return nil, err
+		}
+		zones = sm.ActivitiesZones(activities)
+	}
+
 	availableSlots := sm.SlotsIntersection(activitySlots, charSlots)
 	availableSlots = funcs.Filter(availableSlots, func(slot *sm.Slot) bool {
-		return slot.Start.Before(*char.EndTime()) && slot.Start.After(time.Now().Add(-sm.MinDurationBefore))
+		return !slot.Start.Before(*char.StartedAt) &&
+			slot.Start.Before(*char.EndTime(event.Rules)) &&
+			slot.Start.After(now.Add(-event.Rules.MinDurationBefore))
+	})
+	// Отбрасываем слоты, до которых группа не успеет дойти с соседних точек.
+	availableSlots = funcs.Filter(availableSlots, func(slot *sm.Slot) bool {
+		return char.CanReachSlotAt(slot.Start, query.ActivityName, zones, event.TravelTimes) == nil
 	})
 
 	return convertSlotsToApp(availableSlots), nil
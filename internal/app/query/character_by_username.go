@@ -9,17 +9,20 @@ import (
 )
 
 type CharacterByUsername struct {
+	EventID  string
 	Username string
 }
 
 type CharacterByUsernameHandler decorator.QueryHandler[CharacterByUsername, Character]
 
 type getCharacterByUsernameHandler struct {
-	chars sm.CharactersRepository
+	chars  sm.CharactersRepository
+	events sm.EventsRepository
 }
 
 func NewCharacterByUsernameHandler(
 	chars sm.CharactersRepository,
+	events sm.EventsRepository,
 	log *slog.Logger,
 	metricsClient decorator.MetricsClient,
 ) CharacterByUsernameHandler {
@@ -27,18 +30,27 @@ func NewCharacterByUsernameHandler(
 		panic("characters repository is nil")
 	}
 
+	if events == nil {
+		panic("events repository is nil")
+	}
+
 	return decorator.ApplyQueryDecorators[CharacterByUsername, Character](
-		&getCharacterByUsernameHandler{chars: chars},
+		&getCharacterByUsernameHandler{chars: chars, events: events},
 		log,
 		metricsClient,
 	)
 }
 
 func (h getCharacterByUsernameHandler) Handle(ctx context.Context, query CharacterByUsername) (Character, error) {
-	char, err := h.chars.CharacterByUsername(ctx, query.Username)
+	char, err := h.chars.CharacterByUsername(ctx, query.EventID, query.Username)
+	if err != nil {
+		return Character{}, err
+	}
+
+	event, err := h.events.Event(ctx, query.EventID)
 	if err != nil {
 		return Character{}, err
 	}
 
-	return convertCharacterToApp(char), nil
+	return convertCharacterToApp(char, event), nil
 }
@@ -0,0 +1,42 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type CurrentEvent struct {
+}
+
+type CurrentEventHandler decorator.QueryHandler[CurrentEvent, Event]
+
+type currentEventHandler struct {
+	events sm.EventsRepository
+}
+
+func NewCurrentEventHandler(
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) CurrentEventHandler {
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[CurrentEvent, Event](
+		&currentEventHandler{events},
+		log, metricsClient,
+	)
+}
+
+func (h *currentEventHandler) Handle(ctx context.Context, _ CurrentEvent) (Event, error) {
+	event, err := h.events.CurrentEvent(ctx)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return convertEventToApp(event), nil
+}
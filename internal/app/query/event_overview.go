@@ -0,0 +1,92 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// EventOverview — все точки и группы мероприятия для организатора.
+type EventOverview struct {
+	EventID  string
+	Username string
+}
+
+type Overview struct {
+	Activities []Activity
+	// Группы в порядке рейтинга.
+	Characters []Character
+}
+
+type EventOverviewHandler decorator.QueryHandler[EventOverview, Overview]
+
+type eventOverviewHandler struct {
+	users      sm.UsersRepository
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+	events     sm.EventsRepository
+}
+
+func NewEventOverviewHandler(
+	users sm.UsersRepository,
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) EventOverviewHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[EventOverview, Overview](
+		&eventOverviewHandler{users, chars, activities, events},
+		log, metricsClient,
+	)
+}
+
+func (h *eventOverviewHandler) Handle(ctx context.Context, q EventOverview) (Overview, error) {
+	user, err := h.users.User(ctx, q.EventID, q.Username)
+	if err != nil {
+		return Overview{}, err
+	}
+
+	if err = sm.CanUserManageEvent(user); err != nil {
+		return Overview{}, err
+	}
+
+	event, err := h.events.Event(ctx, q.EventID)
+	if err != nil {
+		return Overview{}, err
+	}
+
+	activities, err := h.activities.Activities(ctx, q.EventID)
+	if err != nil {
+		return Overview{}, err
+	}
+
+	chars, err := h.chars.Characters(ctx, q.EventID)
+	if err != nil {
+		return Overview{}, err
+	}
+	sm.SortByRating(chars, event.RatingPolicy)
+
+	return Overview{
+		Activities: convertActivitiesToApp(activities),
+		Characters: convertCharactersToApp(chars, event),
+	}, nil
+}
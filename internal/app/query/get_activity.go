@@ -9,6 +9,7 @@ import (
 )
 
 type GetActivity struct {
+	EventID      string
 	ActivityName string
 }
 
@@ -35,7 +36,7 @@ func NewGetActivityHandler(
 }
 
 func (h *getActivityHandler) Handle(ctx context.Context, query GetActivity) (Activity, error) {
-	act, err := h.activities.Activity(ctx, query.ActivityName)
+	act, err := h.activities.Activity(ctx, query.EventID, query.ActivityName)
 	if err != nil {
 		return Activity{}, err
 	}
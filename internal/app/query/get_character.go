@@ -9,17 +9,20 @@ import (
 )
 
 type GetCharacter struct {
+	EventID   string
 	GroupName string
 }
 
 type GetCharacterHandler decorator.QueryHandler[GetCharacter, Character]
 
 type getCharacterHandler struct {
-	chars sm.CharactersRepository
+	chars  sm.CharactersRepository
+	events sm.EventsRepository
 }
 
 func NewGetCharacterHandler(
 	chars sm.CharactersRepository,
+	events sm.EventsRepository,
 	log *slog.Logger,
 	metricsClient decorator.MetricsClient,
 ) GetCharacterHandler {
@@ -27,18 +30,27 @@ func NewGetCharacterHandler(
 		panic("characters repository is nil")
 	}
 
+	if events == nil {
+		panic("events repository is nil")
+	}
+
 	return decorator.ApplyQueryDecorators[GetCharacter, Character](
-		&getCharacterHandler{chars: chars},
+		&getCharacterHandler{chars: chars, events: events},
 		log,
 		metricsClient,
 	)
 }
 
 func (h getCharacterHandler) Handle(ctx context.Context, query GetCharacter) (Character, error) {
-	char, err := h.chars.Character(ctx, query.GroupName)
+	char, err := h.chars.Character(ctx, query.EventID, query.GroupName)
+	if err != nil {
+		return Character{}, err
+	}
+
+	event, err := h.events.Event(ctx, query.EventID)
 	if err != nil {
 		return Character{}, err
 	}
 
-	return convertCharacterToApp(char), nil
+	return convertCharacterToApp(char, event), nil
 }
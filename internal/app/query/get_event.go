@@ -0,0 +1,43 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type GetEvent struct {
+	EventID string
+}
+
+type GetEventHandler decorator.QueryHandler[GetEvent, Event]
+
+type getEventHandler struct {
+	events sm.EventsRepository
+}
+
+func NewGetEventHandler(
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) GetEventHandler {
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[GetEvent, Event](
+		&getEventHandler{events},
+		log, metricsClient,
+	)
+}
+
+func (h *getEventHandler) Handle(ctx context.Context, q GetEvent) (Event, error) {
+	event, err := h.events.Event(ctx, q.EventID)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return convertEventToApp(event), nil
+}
@@ -8,6 +8,7 @@ import (
 )
 
 type GetUser struct {
+	EventID  string
 	Username string
 }
 
@@ -33,7 +34,7 @@ func NewGetUserHandler(
 }
 
 func (h *getUserHandler) Handle(ctx context.Context, q GetUser) (User, error) {
-	user, err := h.users.User(ctx, q.Username)
+	user, err := h.users.User(ctx, q.EventID, q.Username)
 	if err != nil {
 		return User{}, err
 	}
@@ -0,0 +1,40 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type GroupName struct {
+	EventID     string
+	CharacterID string
+}
+
+type GroupNameHandler decorator.QueryHandler[GroupName, string]
+
+type groupNameHandler struct {
+	chars sm.CharactersRepository
+}
+
+func NewGroupNameHandler(
+	chars sm.CharactersRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) GroupNameHandler {
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[GroupName, string](
+		&groupNameHandler{chars: chars},
+		log,
+		metricsClient,
+	)
+}
+
+func (h groupNameHandler) Handle(ctx context.Context, query GroupName) (string, error) {
+	return h.chars.GroupNameByID(ctx, query.EventID, query.CharacterID)
+}
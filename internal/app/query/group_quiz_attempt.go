@@ -0,0 +1,64 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// GroupQuizAttempt возвращает прохождение викторины группой вместе с текущим
+// вопросом.
+type GroupQuizAttempt struct {
+	EventID      string
+	GroupName    string
+	ActivityName string
+}
+
+type GroupQuizAttemptHandler decorator.QueryHandler[GroupQuizAttempt, QuizAttempt]
+
+type groupQuizAttemptHandler struct {
+	activities sm.ActivitiesRepository
+	quizzes    sm.QuizzesRepository
+}
+
+func NewGroupQuizAttemptHandler(
+	activities sm.ActivitiesRepository,
+	quizzes sm.QuizzesRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) GroupQuizAttemptHandler {
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if quizzes == nil {
+		panic("quizzes repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[GroupQuizAttempt, QuizAttempt](
+		&groupQuizAttemptHandler{activities, quizzes},
+		log,
+		metricsClient,
+	)
+}
+
+func (h *groupQuizAttemptHandler) Handle(ctx context.Context, query GroupQuizAttempt) (QuizAttempt, error) {
+	act, err := h.activities.Activity(ctx, query.EventID, query.ActivityName)
+	if err != nil {
+		return QuizAttempt{}, err
+	}
+
+	quiz, err := h.quizzes.Quiz(ctx, query.EventID, query.ActivityName)
+	if err != nil {
+		return QuizAttempt{}, err
+	}
+
+	at, err := h.quizzes.Attempt(ctx, query.EventID, query.ActivityName, query.GroupName)
+	if err != nil {
+		return QuizAttempt{}, err
+	}
+
+	return convertQuizAttemptToApp(act, quiz, at), nil
+}
@@ -0,0 +1,53 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type GroupWaitlists struct {
+	EventID   string
+	GroupName string
+}
+
+type GroupWaitlistsHandler decorator.QueryHandler[GroupWaitlists, []WaitlistEntry]
+
+type groupWaitlistsHandler struct {
+	waitlists sm.WaitlistsRepository
+}
+
+func NewGroupWaitlistsHandler(
+	waitlists sm.WaitlistsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) GroupWaitlistsHandler {
+	if waitlists == nil {
+		panic("waitlists repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[GroupWaitlists, []WaitlistEntry](
+		&groupWaitlistsHandler{waitlists},
+		log, metricsClient,
+	)
+}
+
+func (h *groupWaitlistsHandler) Handle(ctx context.Context, q GroupWaitlists) ([]WaitlistEntry, error) {
+	waitlists, err := h.waitlists.Waitlists(ctx, q.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]WaitlistEntry, 0)
+	for _, w := range waitlists {
+		for _, e := range w.Entries {
+			if e.GroupName == q.GroupName {
+				res = append(res, convertWaitlistEntryToApp(w, e))
+			}
+		}
+	}
+
+	return res, nil
+}
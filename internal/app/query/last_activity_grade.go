@@ -0,0 +1,61 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type LastActivityGrade struct {
+	EventID      string
+	ActivityName string
+}
+
+type LastActivityGradeHandler decorator.QueryHandler[LastActivityGrade, CharacterGrade]
+
+type lastActivityGradeHandler struct {
+	chars sm.CharactersRepository
+}
+
+func NewLastActivityGradeHandler(
+	chars sm.CharactersRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) LastActivityGradeHandler {
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[LastActivityGrade, CharacterGrade](
+		&lastActivityGradeHandler{chars: chars},
+		log,
+		metricsClient,
+	)
+}
+
+func (h lastActivityGradeHandler) Handle(ctx context.Context, query LastActivityGrade) (CharacterGrade, error) {
+	chars, err := h.chars.Characters(ctx, query.EventID)
+	if err != nil {
+		return CharacterGrade{}, err
+	}
+
+	var lastGroup string
+	var last sm.Grade
+	for _, char := range chars {
+		grade, ok := char.LastGrade(query.ActivityName)
+		if ok && (last.IsZero() || grade.Time.After(last.Time)) {
+			lastGroup, last = char.GroupName, grade
+		}
+	}
+
+	if last.IsZero() {
+		return CharacterGrade{}, sm.ErrGradeNotFound
+	}
+
+	return CharacterGrade{
+		GroupName: lastGroup,
+		Grade:     convertGradeToApp(last),
+	}, nil
+}
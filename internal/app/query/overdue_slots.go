@@ -0,0 +1,70 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type OverdueSlots struct {
+	EventID string
+}
+
+type OverdueSlotsHandler decorator.QueryHandler[OverdueSlots, []BookedSlot]
+
+type overdueSlotsHandler struct {
+	activities sm.ActivitiesRepository
+	events     sm.EventsRepository
+}
+
+func NewOverdueSlotsHandler(
+	activities sm.ActivitiesRepository,
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) OverdueSlotsHandler {
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[OverdueSlots, []BookedSlot](
+		&overdueSlotsHandler{activities, events},
+		log, metricsClient,
+	)
+}
+
+func (h *overdueSlotsHandler) Handle(ctx context.Context, q OverdueSlots) ([]BookedSlot, error) {
+	event, err := h.events.Event(ctx, q.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	activities, err := h.activities.Activities(ctx, q.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	res := make([]BookedSlot, 0)
+	for _, activity := range activities {
+		for _, slot := range activity.OverdueSlots(now, event.Rules) {
+			for _, booking := range slot.Overdue(now, event.Rules.NoShowTimeout) {
+				res = append(res, BookedSlot{
+					ActivityName: activity.Name,
+					GroupName:    booking.Whom,
+					Start:        slot.Start,
+					End:          slot.End,
+				})
+			}
+		}
+	}
+
+	return res, nil
+}
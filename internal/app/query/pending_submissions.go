@@ -0,0 +1,74 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// PendingSubmissions возвращает очередь непроверенных решений по активности,
+// если пользователь может ею управлять.
+type PendingSubmissions struct {
+	EventID      string
+	Username     string
+	ActivityName string
+}
+
+type PendingSubmissionsHandler decorator.QueryHandler[PendingSubmissions, []Submission]
+
+type pendingSubmissionsHandler struct {
+	users       sm.UsersRepository
+	activities  sm.ActivitiesRepository
+	submissions sm.SubmissionsRepository
+}
+
+func NewPendingSubmissionsHandler(
+	users sm.UsersRepository,
+	activities sm.ActivitiesRepository,
+	submissions sm.SubmissionsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) PendingSubmissionsHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if submissions == nil {
+		panic("submissions repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[PendingSubmissions, []Submission](
+		&pendingSubmissionsHandler{users, activities, submissions},
+		log,
+		metricsClient,
+	)
+}
+
+func (h *pendingSubmissionsHandler) Handle(ctx context.Context, query PendingSubmissions) ([]Submission, error) {
+	user, err := h.users.User(ctx, query.EventID, query.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	act, err := h.activities.Activity(ctx, query.EventID, query.ActivityName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = sm.CanUserManageActivity(user, act); err != nil {
+		return nil, err
+	}
+
+	submissions, err := h.submissions.PendingSubmissions(ctx, query.EventID, query.ActivityName)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertSubmissionsToApp(submissions), nil
+}
@@ -0,0 +1,111 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+type PlanTimetable struct {
+	EventID     string
+	Constraints map[string]GroupConstraints
+}
+
+type GroupConstraints struct {
+	NotBefore          *time.Time
+	NotAfter           *time.Time
+	ExcludedActivities []string
+}
+
+type PlanTimetableHandler decorator.QueryHandler[PlanTimetable, TimetablePlan]
+
+type planTimetableHandler struct {
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+	events     sm.EventsRepository
+}
+
+func NewPlanTimetableHandler(
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	events sm.EventsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) PlanTimetableHandler {
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if events == nil {
+		panic("events repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[PlanTimetable, TimetablePlan](
+		&planTimetableHandler{chars, activities, events},
+		log, metricsClient,
+	)
+}
+
+// Handle строит расписание на загруженных копиях персонажей и точек, ничего не сохраняя.
+func (h *planTimetableHandler) Handle(ctx context.Context, q PlanTimetable) (TimetablePlan, error) {
+	event, err := h.events.Event(ctx, q.EventID)
+	if err != nil {
+		return TimetablePlan{}, err
+	}
+
+	chars, err := h.chars.Characters(ctx, q.EventID)
+	if err != nil {
+		return TimetablePlan{}, err
+	}
+
+	activities, err := h.activities.Activities(ctx, q.EventID)
+	if err != nil {
+		return TimetablePlan{}, err
+	}
+
+	constraints := make(map[string]sm.GroupConstraints, len(q.Constraints))
+	for groupName, c := range q.Constraints {
+		constraints[groupName] = sm.GroupConstraints{
+			NotBefore:          c.NotBefore,
+			NotAfter:           c.NotAfter,
+			ExcludedActivities: c.ExcludedActivities,
+		}
+	}
+
+	assignments := sm.PlanTimetable(chars, activities, event.Rules, event.TravelTimes, constraints)
+
+	skillsByActivity := make(map[string][]sm.SkillType, len(activities))
+	for _, a := range activities {
+		skillsByActivity[a.Name] = a.Skills
+	}
+
+	groups := make([]GroupTimetable, len(chars))
+	for i, char := range chars {
+		skills := make(map[string]int)
+		for _, slot := range char.Slots {
+			for _, b := range slot.Bookings {
+				for _, skill := range skillsByActivity[b.Whom] {
+					skills[skill.String()]++
+				}
+			}
+		}
+		groups[i] = GroupTimetable{
+			GroupName:    char.GroupName,
+			TakenSlots:   char.TakenSlots(),
+			MissingSlots: max(event.Rules.MaxTakenSlots-char.TakenSlots(), 0),
+			Skills:       skills,
+		}
+	}
+
+	return TimetablePlan{
+		Assignments: convertTimetableAssignmentsToApp(assignments),
+		Groups:      groups,
+	}, nil
+}
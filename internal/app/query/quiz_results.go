@@ -0,0 +1,83 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// QuizResults возвращает результаты викторины точки, если пользователь может
+// ею управлять.
+type QuizResults struct {
+	EventID      string
+	Username     string
+	ActivityName string
+}
+
+type QuizResultsHandler decorator.QueryHandler[QuizResults, []QuizAttempt]
+
+type quizResultsHandler struct {
+	users      sm.UsersRepository
+	activities sm.ActivitiesRepository
+	quizzes    sm.QuizzesRepository
+}
+
+func NewQuizResultsHandler(
+	users sm.UsersRepository,
+	activities sm.ActivitiesRepository,
+	quizzes sm.QuizzesRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) QuizResultsHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if quizzes == nil {
+		panic("quizzes repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[QuizResults, []QuizAttempt](
+		&quizResultsHandler{users, activities, quizzes},
+		log,
+		metricsClient,
+	)
+}
+
+func (h *quizResultsHandler) Handle(ctx context.Context, query QuizResults) ([]QuizAttempt, error) {
+	user, err := h.users.User(ctx, query.EventID, query.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	act, err := h.activities.Activity(ctx, query.EventID, query.ActivityName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = sm.CanUserManageActivity(user, act); err != nil {
+		return nil, err
+	}
+
+	quiz, err := h.quizzes.Quiz(ctx, query.EventID, query.ActivityName)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := h.quizzes.Attempts(ctx, query.EventID, query.ActivityName)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]QuizAttempt, len(attempts))
+	for i, at := range attempts {
+		res[i] = convertQuizAttemptToApp(act, quiz, at)
+	}
+	return res, nil
+}
@@ -3,24 +3,25 @@ package query
 import (
 	"context"
 	"log/slog"
-	"math"
-	"slices"
 
 	"github.com/zhikh23/sm-instruction/internal/common/decorator"
 	"github.com/zhikh23/sm-instruction/internal/domain/sm"
 )
 
 type Rating struct {
+	EventID string
 }
 
 type RatingHandler decorator.QueryHandler[Rating, []Character]
 
 type ratingHandler struct {
-	chars sm.CharactersRepository
+	chars  sm.CharactersRepository
+	events sm.EventsRepository
 }
 
 func NewRatingHandler(
 	chars sm.CharactersRepository,
+	events sm.EventsRepository,
 	log *slog.Logger,
 	metricsClient decorator.MetricsClient,
 ) RatingHandler {
@@ -28,25 +29,28 @@ func NewRatingHandler(
 		panic("chars repository is nil")
 	}
 
+	if events == nil {
+		panic("events repository is nil")
+	}
+
 	return decorator.ApplyQueryDecorators[Rating, []Character](
-		&ratingHandler{chars},
+		&ratingHandler{chars, events},
 		log, metricsClient,
 	)
 }
 
-func (h *ratingHandler) Handle(ctx context.Context, _ Rating) ([]Character, error) {
-	chars, err := h.chars.Characters(ctx)
+func (h *ratingHandler) Handle(ctx context.Context, q Rating) ([]Character, error) {
+	chars, err := h.chars.Characters(ctx, q.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := h.events.Event(ctx, q.EventID)
 	if err != nil {
 		return nil, err
 	}
 
-	slices.SortFunc(chars, func(a, b *sm.Character) int {
-		diff := b.Rating() - a.Rating()
-		if diff == 0.0 {
-			return 0
-		}
-		return int(math.Ceil(diff / math.Abs(diff))) // Получение знака
-	})
+	sm.SortByRating(chars, event.RatingPolicy)
 
-	return convertCharactersToApp(chars), nil
+	return convertCharactersToApp(chars, event), nil
 }
@@ -0,0 +1,73 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// SecretCodes возвращает коды точки, если пользователь может ею управлять.
+type SecretCodes struct {
+	EventID      string
+	Username     string
+	ActivityName string
+}
+
+type SecretCodesHandler decorator.QueryHandler[SecretCodes, []SecretCode]
+
+type secretCodesHandler struct {
+	users      sm.UsersRepository
+	activities sm.ActivitiesRepository
+	codes      sm.SecretCodesRepository
+}
+
+func NewSecretCodesHandler(
+	users sm.UsersRepository,
+	activities sm.ActivitiesRepository,
+	codes sm.SecretCodesRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) SecretCodesHandler {
+	if users == nil {
+		panic("users repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	if codes == nil {
+		panic("secret codes repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[SecretCodes, []SecretCode](
+		&secretCodesHandler{users, activities, codes},
+		log,
+		metricsClient,
+	)
+}
+
+func (h *secretCodesHandler) Handle(ctx context.Context, query SecretCodes) ([]SecretCode, error) {
+	user, err := h.users.User(ctx, query.EventID, query.Username)
+	if err != nil {
+		return nil, err
+	}
+
+	act, err := h.activities.Activity(ctx, query.EventID, query.ActivityName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = sm.CanUserManageActivity(user, act); err != nil {
+		return nil, err
+	}
+
+	codes, err := h.codes.SecretCodesByActivity(ctx, query.EventID, query.ActivityName)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertSecretCodesToApp(codes), nil
+}
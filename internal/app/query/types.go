@@ -9,30 +9,232 @@ import (
 type User struct {
 	Username string
 	Role     string
+	ChatID   int64
 }
 
 type Slot struct {
-	Start time.Time
-	End   time.Time
-	Whom  *string
+	Start    time.Time
+	End      time.Time
+	Capacity int
+	Bookings []Booking
+}
+
+type Booking struct {
+	Whom   string
+	Status string
 }
 
 type Grade struct {
+	ID           string
 	SkillType    string
 	Points       int
 	ActivityName string
 	Time         time.Time
+	Revoked      bool
+	RevokeReason *string
 }
 
-type Character struct {
+type Penalty struct {
+	ID           string
+	Points       int
+	Reason       string
+	ActivityName *string
+	Time         time.Time
+}
+
+type WindowAdjustment struct {
+	Kind     string
+	Duration time.Duration
+	Username string
+	Time     time.Time
+}
+
+type SecretCode struct {
+	Code                string
+	ActivityName        string
+	Skill               string
+	Points              int
+	MaxRedemptions      int
+	MaxGroupRedemptions int
+	Redemptions         int
+}
+
+type Submission struct {
+	ID           string
+	ActivityName string
+	GroupName    string
+	Username     string
+	Kind         string
+	Text         string
+	FileID       string
+	SubmittedAt  time.Time
+}
+
+type Quiz struct {
+	ActivityName string
+	Questions    int
+}
+
+type QuizAttempt struct {
+	ActivityName string
+	GroupName    string
+	StartedAt    time.Time
+	Answered     int
+	Correct      int
+	Total        int
+	Finished     bool
+	Awarded      bool
+	// Вопрос, на который группа отвечает сейчас; nil, если викторина пройдена.
+	Question   *QuizQuestion
+	LastAnswer *QuizAnswer
+	Scores     []QuizScore
+}
+
+type QuizQuestion struct {
+	Number   int
+	Text     string
+	Options  []string
+	Deadline time.Time
+}
+
+type QuizAnswer struct {
+	Text     string
+	Username string
+	Correct  bool
+	TimedOut bool
+}
+
+type QuizScore struct {
+	Skill   string
+	Correct int
+	Total   int
+	Points  int
+}
+
+type BookedSlot struct {
+	ActivityName string
+	GroupName    string
+	Start        time.Time
+	End          time.Time
+}
+
+type WaitlistEntry struct {
+	ActivityName   string
+	GroupName      string
+	Position       int
+	From           *time.Time
+	To             *time.Time
+	JoinedAt       time.Time
+	OfferStart     *time.Time
+	OfferExpiresAt *time.Time
+}
+
+type CharacterGrade struct {
 	GroupName string
-	Username  string
-	Skills    map[string]int
-	Rating    float64
-	Slots     []Slot
-	Grades    []Grade
-	Start     *time.Time
-	End       *time.Time
+	Grade     Grade
+}
+
+type Character struct {
+	ID         string
+	GroupName  string
+	Username   string
+	Members    []Member
+	InviteCode string
+	Skills     map[string]int
+	Rating     float64
+	// Как сложился рейтинг группы.
+	RatingBreakdown RatingBreakdown
+	Slots           []Slot
+	Grades          []Grade
+	Penalties       []Penalty
+	Achievements    []Achievement
+	Start           *time.Time
+	End             *time.Time
+	// Время старта волны, если его назначил организатор.
+	ScheduledStart *time.Time
+	// Начало текущей паузы, если отсчёт окна остановлен.
+	PausedAt          *time.Time
+	WindowAdjustments []WindowAdjustment
+}
+
+type Member struct {
+	Username string
+	Role     string
+}
+
+type Achievement struct {
+	ID         string
+	Title      string
+	UnlockedAt time.Time
+}
+
+type RatingBreakdown struct {
+	Skills       []SkillScore
+	Base         float64
+	Factor       float64
+	BalanceBonus float64
+	Penalty      float64
+	Total        float64
+}
+
+type SkillScore struct {
+	Skill   string
+	Emoji   string
+	General bool
+	Points  int
+	Counted int
+	Weight  float64
+}
+
+type EventRules struct {
+	InstructionDuration     time.Duration
+	MaxTakenSlots           int
+	MinDurationBefore       time.Duration
+	MinDurationBeforeCancel time.Duration
+	SlotDuration            time.Duration
+	AwardGracePeriod        time.Duration
+	NoShowTimeout           time.Duration
+	WaitlistOfferTimeout    time.Duration
+	MaxPenaltyPoints        int
+	MaxTotalPenaltyPoints   int
+	MaxCodeAttempts         int
+	CodeLockout             time.Duration
+}
+
+type Event struct {
+	ID       string
+	Name     string
+	Date     time.Time
+	Timezone *time.Location
+	Rules    EventRules
+	// Навыки мероприятия в порядке вывода.
+	Skills []Skill
+}
+
+type Skill struct {
+	Name    string
+	Emoji   string
+	General bool
+}
+
+type TimetablePlan struct {
+	Assignments []TimetableAssignment
+	Groups      []GroupTimetable
+}
+
+type TimetableAssignment struct {
+	GroupName    string
+	ActivityName string
+	Start        time.Time
+}
+
+// GroupTimetable — итог распределения для группы: сколько броней набрано
+// и сколько точек приходится на каждый навык.
+type GroupTimetable struct {
+	GroupName    string
+	TakenSlots   int
+	MissingSlots int
+	Skills       map[string]int
 }
 
 type Activity struct {
@@ -40,6 +242,7 @@ type Activity struct {
 	FullName    string
 	Description *string
 	Location    *string
+	Zone        *string
 	Admins      []User
 	Skills      []string
 	MaxPoints   int
@@ -50,6 +253,7 @@ func convertUserToApp(u sm.User) User {
 	return User{
 		Username: u.Username,
 		Role:     u.Role.String(),
+		ChatID:   u.ChatID,
 	}
 }
 
@@ -82,10 +286,18 @@ func convertSkillsToApp(m map[sm.SkillType]int) map[string]int {
 }
 
 func convertSlotToApp(slot *sm.Slot) Slot {
+	bookings := make([]Booking, len(slot.Bookings))
+	for i, b := range slot.Bookings {
+		bookings[i] = Booking{
+			Whom:   b.Whom,
+			Status: b.Status.String(),
+		}
+	}
 	return Slot{
-		Start: slot.Start,
-		End:   slot.End,
-		Whom:  slot.Whom,
+		Start:    slot.Start,
+		End:      slot.End,
+		Capacity: slot.Capacity,
+		Bookings: bookings,
 	}
 }
 
@@ -98,12 +310,18 @@ func convertSlotsToApp(slots []*sm.Slot) []Slot {
 }
 
 func convertGradeToApp(g sm.Grade) Grade {
-	return Grade{
+	grade := Grade{
+		ID:           g.ID,
 		SkillType:    g.SkillType.String(),
 		Points:       g.Points,
 		ActivityName: g.ActivityName,
 		Time:         g.Time,
 	}
+	if g.IsRevoked() {
+		grade.Revoked = true
+		grade.RevokeReason = &g.Revocation.Reason
+	}
+	return grade
 }
 
 func convertGradesToApp(gs []sm.Grade) []Grade {
@@ -114,33 +332,181 @@ func convertGradesToApp(gs []sm.Grade) []Grade {
 	return res
 }
 
-func convertCharacterToApp(c *sm.Character) Character {
+func convertPenaltiesToApp(ps []sm.Penalty) []Penalty {
+	res := make([]Penalty, len(ps))
+	for i, p := range ps {
+		res[i] = Penalty{
+			ID:     p.ID,
+			Points: p.Points,
+			Reason: p.Reason,
+			Time:   p.Time,
+		}
+		if p.ActivityName != "" {
+			res[i].ActivityName = &p.ActivityName
+		}
+	}
+	return res
+}
+
+func convertWindowAdjustmentsToApp(as []sm.WindowAdjustment) []WindowAdjustment {
+	res := make([]WindowAdjustment, len(as))
+	for i, a := range as {
+		res[i] = WindowAdjustment{
+			Kind:     a.Kind.String(),
+			Duration: a.Duration,
+			Username: a.Username,
+			Time:     a.Time,
+		}
+	}
+	return res
+}
+
+func convertSecretCodesToApp(cs []*sm.SecretCode) []SecretCode {
+	res := make([]SecretCode, len(cs))
+	for i, c := range cs {
+		res[i] = SecretCode{
+			Code:                c.Code,
+			ActivityName:        c.ActivityName,
+			Skill:               c.Skill.String(),
+			Points:              c.Points,
+			MaxRedemptions:      c.MaxRedemptions,
+			MaxGroupRedemptions: c.MaxGroupRedemptions,
+			Redemptions:         len(c.Redemptions),
+		}
+	}
+	return res
+}
+
+func convertCharacterToApp(c *sm.Character, event *sm.Event) Character {
+	breakdown := convertRatingBreakdownToApp(event.RatingPolicy.Rate(c), event.Skills)
 	return Character{
-		Username:  c.Username,
-		GroupName: c.GroupName,
-		Skills:    convertSkillsToApp(c.Skills()),
-		Rating:    c.Rating(),
-		Slots:     convertSlotsToApp(c.Slots),
-		Grades:    convertGradesToApp(c.Grades),
-		Start:     c.StartedAt,
-		End:       c.EndTime(),
+		ID:                c.ID,
+		Username:          c.Username,
+		GroupName:         c.GroupName,
+		Members:           convertMembersToApp(c.Members),
+		InviteCode:        c.InviteCode,
+		Skills:            convertSkillsToApp(c.Skills()),
+		Rating:            breakdown.Total,
+		RatingBreakdown:   breakdown,
+		Slots:             convertSlotsToApp(c.Slots),
+		Grades:            convertGradesToApp(c.Grades),
+		Penalties:         convertPenaltiesToApp(c.Penalties),
+		Achievements:      convertAchievementsToApp(c.Achievements),
+		Start:             c.StartedAt,
+		End:               c.EndTime(event.Rules),
+		ScheduledStart:    c.ScheduledStart,
+		PausedAt:          c.PausedAt,
+		WindowAdjustments: convertWindowAdjustmentsToApp(c.WindowAdjustments),
 	}
 }
 
-func convertCharactersToApp(cs []*sm.Character) []Character {
+func convertCharactersToApp(cs []*sm.Character, event *sm.Event) []Character {
 	res := make([]Character, len(cs))
 	for i, c := range cs {
-		res[i] = convertCharacterToApp(c)
+		res[i] = convertCharacterToApp(c, event)
+	}
+	return res
+}
+
+func convertMembersToApp(ms []sm.Member) []Member {
+	res := make([]Member, len(ms))
+	for i, m := range ms {
+		res[i] = Member{
+			Username: m.Username,
+			Role:     m.Role.String(),
+		}
+	}
+	return res
+}
+
+func convertAchievementsToApp(as []sm.Achievement) []Achievement {
+	res := make([]Achievement, 0, len(as))
+	for _, a := range as {
+		// Достижения, убранные из каталога, не показываются.
+		rule, ok := sm.AchievementRuleByID(a.ID)
+		if !ok {
+			continue
+		}
+		res = append(res, Achievement{
+			ID:         a.ID,
+			Title:      rule.Title,
+			UnlockedAt: a.UnlockedAt,
+		})
+	}
+	return res
+}
+
+func convertRatingBreakdownToApp(b sm.RatingBreakdown, catalogue sm.SkillCatalogue) RatingBreakdown {
+	skills := make([]SkillScore, len(b.Skills))
+	for i, s := range b.Skills {
+		skill, _ := catalogue.Skill(s.Skill)
+		skills[i] = SkillScore{
+			Skill:   s.Skill.String(),
+			Emoji:   skill.Emoji,
+			General: skill.IsGeneral(),
+			Points:  s.Points,
+			Counted: s.Counted,
+			Weight:  s.Weight,
+		}
+	}
+	return RatingBreakdown{
+		Skills:       skills,
+		Base:         b.Base,
+		Factor:       b.Factor,
+		BalanceBonus: b.BalanceBonus,
+		Penalty:      b.Penalty,
+		Total:        b.Total,
+	}
+}
+
+func convertEventToApp(e *sm.Event) Event {
+	return Event{
+		ID:       e.ID,
+		Name:     e.Name,
+		Date:     e.Date,
+		Timezone: e.Timezone,
+		Rules:    convertEventRulesToApp(e.Rules),
+		Skills:   convertSkillCatalogueToApp(e.Skills),
+	}
+}
+
+func convertSkillCatalogueToApp(c sm.SkillCatalogue) []Skill {
+	skills := c.Skills()
+	res := make([]Skill, len(skills))
+	for i, s := range skills {
+		res[i] = Skill{
+			Name:    s.Type.String(),
+			Emoji:   s.Emoji,
+			General: s.IsGeneral(),
+		}
 	}
 	return res
 }
 
+func convertEventRulesToApp(r sm.EventRules) EventRules {
+	return EventRules{
+		InstructionDuration:     r.InstructionDuration,
+		MaxTakenSlots:           r.MaxTakenSlots,
+		MinDurationBefore:       r.MinDurationBefore,
+		MinDurationBeforeCancel: r.MinDurationBeforeCancel,
+		SlotDuration:            r.SlotDuration,
+		AwardGracePeriod:        r.AwardGracePeriod,
+		NoShowTimeout:           r.NoShowTimeout,
+		WaitlistOfferTimeout:    r.WaitlistOfferTimeout,
+		MaxPenaltyPoints:        r.MaxPenaltyPoints,
+		MaxTotalPenaltyPoints:   r.MaxTotalPenaltyPoints,
+		MaxCodeAttempts:         r.MaxCodeAttempts,
+		CodeLockout:             r.CodeLockout,
+	}
+}
+
 func convertActivityToApp(a *sm.Activity) Activity {
 	return Activity{
 		Name:        a.Name,
 		FullName:    a.FullName,
 		Description: a.Description,
 		Location:    a.Location,
+		Zone:        a.Zone,
 		Admins:      convertUsersToApp(a.Admins),
 		Skills:      convertSkillTypesToApp(a.Skills),
 		MaxPoints:   a.MaxPoints,
@@ -155,3 +521,105 @@ func convertActivitiesToApp(as []*sm.Activity) []Activity {
 	}
 	return res
 }
+
+func convertWaitlistEntryToApp(w *sm.Waitlist, e *sm.WaitlistEntry) WaitlistEntry {
+	position, _ := w.Position(e.GroupName)
+	entry := WaitlistEntry{
+		ActivityName: w.ActivityName,
+		GroupName:    e.GroupName,
+		Position:     position,
+		From:         e.From,
+		To:           e.To,
+		JoinedAt:     e.JoinedAt,
+	}
+	if e.Offer != nil {
+		entry.OfferStart = &e.Offer.Start
+		entry.OfferExpiresAt = &e.Offer.ExpiresAt
+	}
+	return entry
+}
+
+func convertTimetableAssignmentsToApp(as []sm.TimetableAssignment) []TimetableAssignment {
+	res := make([]TimetableAssignment, len(as))
+	for i, a := range as {
+		res[i] = TimetableAssignment{
+			GroupName:    a.GroupName,
+			ActivityName: a.ActivityName,
+			Start:        a.Start,
+		}
+	}
+	return res
+}
+
+func convertSubmissionsToApp(ss []*sm.Submission) []Submission {
+	res := make([]Submission, len(ss))
+	for i, s := range ss {
+		res[i] = Submission{
+			ID:           s.ID,
+			ActivityName: s.ActivityName,
+			GroupName:    s.GroupName,
+			Username:     s.Username,
+			Kind:         s.Kind.String(),
+			Text:         s.Text,
+			FileID:       s.FileID,
+			SubmittedAt:  s.SubmittedAt,
+		}
+	}
+	return res
+}
+
+func convertQuizToApp(q *sm.Quiz) Quiz {
+	return Quiz{
+		ActivityName: q.ActivityName,
+		Questions:    len(q.Questions),
+	}
+}
+
+func convertQuizAttemptToApp(act *sm.Activity, quiz *sm.Quiz, at *sm.QuizAttempt) QuizAttempt {
+	res := QuizAttempt{
+		ActivityName: at.ActivityName,
+		GroupName:    at.GroupName,
+		StartedAt:    at.StartedAt,
+		Answered:     len(at.Answers),
+		Correct:      at.CorrectAnswers(),
+		Total:        len(quiz.Questions),
+		Finished:     at.IsFinished(quiz),
+		Awarded:      at.Awarded,
+	}
+
+	if i, ok := at.CurrentQuestion(quiz); ok {
+		deadline, _ := at.Deadline(quiz)
+		q := quiz.Questions[i]
+		res.Question = &QuizQuestion{
+			Number:   i + 1,
+			Text:     q.Text,
+			Options:  q.Options,
+			Deadline: deadline,
+		}
+	}
+
+	if len(at.Answers) > 0 {
+		a := at.Answers[len(at.Answers)-1]
+		res.LastAnswer = &QuizAnswer{
+			Text:     a.Text,
+			Username: a.Username,
+			Correct:  a.Correct,
+			TimedOut: a.TimedOut,
+		}
+	}
+
+	if res.Finished {
+		scores := act.QuizScores(quiz, at)
+		res.Scores = make([]QuizScore, len(scores))
+		for i, s := range scores {
+			res.Scores[i] = QuizScore{
+				Skill:   s.Skill.String(),
+				Correct: s.Correct,
+				Total:   s.Total,
+				Points:  s.Points,
+			}
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,64 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+	"github.com/zhikh23/sm-instruction/pkg/funcs"
+)
+
+// WaitlistActivities возвращает точки, на которые группа не может записаться
+// из-за отсутствия свободных слотов, но может встать в лист ожидания.
+type WaitlistActivities struct {
+	EventID   string
+	GroupName string
+}
+
+type WaitlistActivitiesHandler decorator.QueryHandler[WaitlistActivities, []Activity]
+
+type waitlistActivitiesHandler struct {
+	chars      sm.CharactersRepository
+	activities sm.ActivitiesRepository
+}
+
+func NewWaitlistActivitiesHandler(
+	chars sm.CharactersRepository,
+	activities sm.ActivitiesRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) WaitlistActivitiesHandler {
+	if chars == nil {
+		panic("characters repository is nil")
+	}
+
+	if activities == nil {
+		panic("activities repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[WaitlistActivities, []Activity](
+		&waitlistActivitiesHandler{chars, activities},
+		log, metricsClient,
+	)
+}
+
+func (h *waitlistActivitiesHandler) Handle(ctx context.Context, q WaitlistActivities) ([]Activity, error) {
+	char, err := h.chars.Character(ctx, q.EventID, q.GroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	activities, err := h.activities.AvailableActivities(ctx, q.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	activities = funcs.Filter(activities, func(act *sm.Activity) bool {
+		return len(act.Slots) > 0 &&
+			len(sm.SlotsIntersection(act.AvailableSlots(), char.AvailableSlots())) == 0 &&
+			!act.HasTaken(q.GroupName)
+	})
+
+	return convertActivitiesToApp(activities), nil
+}
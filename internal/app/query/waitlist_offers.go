@@ -0,0 +1,56 @@
+package query
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/decorator"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// WaitlistOffers возвращает действующие предложения, сделанные не раньше OfferedSince.
+type WaitlistOffers struct {
+	EventID      string
+	OfferedSince time.Time
+}
+
+type WaitlistOffersHandler decorator.QueryHandler[WaitlistOffers, []WaitlistEntry]
+
+type waitlistOffersHandler struct {
+	waitlists sm.WaitlistsRepository
+}
+
+func NewWaitlistOffersHandler(
+	waitlists sm.WaitlistsRepository,
+	log *slog.Logger,
+	metricsClient decorator.MetricsClient,
+) WaitlistOffersHandler {
+	if waitlists == nil {
+		panic("waitlists repository is nil")
+	}
+
+	return decorator.ApplyQueryDecorators[WaitlistOffers, []WaitlistEntry](
+		&waitlistOffersHandler{waitlists},
+		log, metricsClient,
+	)
+}
+
+func (h *waitlistOffersHandler) Handle(ctx context.Context, q WaitlistOffers) ([]WaitlistEntry, error) {
+	waitlists, err := h.waitlists.Waitlists(ctx, q.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	res := make([]WaitlistEntry, 0)
+	for _, w := range waitlists {
+		for _, e := range w.Entries {
+			if e.HasOffer(now) && !e.Offer.OfferedAt.Before(q.OfferedSince) {
+				res = append(res, convertWaitlistEntryToApp(w, e))
+			}
+		}
+	}
+
+	return res, nil
+}
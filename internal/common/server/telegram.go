@@ -11,7 +11,7 @@ import (
 	"github.com/vitaliy-ukiru/fsm-telebot/v2/pkg/storage/memory"
 )
 
-func RunTelegramServer(setupFn func(m *fsm.Manager, dp fsm.Dispatcher)) {
+func RunTelegramServer(setupFn func(bot *telebot.Bot, m *fsm.Manager, dp fsm.Dispatcher)) {
 	token := os.Getenv("TELEGRAM_TOKEN")
 	if token == "" {
 		panic("TELEGRAM_TOKEN environment variable not set")
@@ -19,7 +19,7 @@ func RunTelegramServer(setupFn func(m *fsm.Manager, dp fsm.Dispatcher)) {
 	RunTelegramServerWithToken(token, setupFn)
 }
 
-func RunTelegramServerWithToken(token string, setupFn func(m *fsm.Manager, dp fsm.Dispatcher)) {
+func RunTelegramServerWithToken(token string, setupFn func(bot *telebot.Bot, m *fsm.Manager, dp fsm.Dispatcher)) {
 	bot, err := telebot.NewBot(telebot.Settings{
 		Token:  token,
 		Poller: &telebot.LongPoller{Timeout: 10 * time.Second},
@@ -34,7 +34,7 @@ func RunTelegramServerWithToken(token string, setupFn func(m *fsm.Manager, dp fs
 	m := fsm.New(memory.NewStorage())
 	g.Use(m.WrapContext)
 
-	setupFn(m, dp)
+	setupFn(bot, m, dp)
 
 	bot.Start()
 }
@@ -0,0 +1,169 @@
+package sm
+
+import (
+	"slices"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+)
+
+// Achievement — достижение, полученное группой.
+type Achievement struct {
+	ID         string
+	UnlockedAt time.Time
+}
+
+func UnmarshallAchievementFromDB(id string, unlockedAt time.Time) (Achievement, error) {
+	if id == "" {
+		return Achievement{}, commonerrs.NewInvalidInputError("expected not empty achievement id")
+	}
+
+	if unlockedAt.IsZero() {
+		return Achievement{}, commonerrs.NewInvalidInputError("expected not zero unlock time")
+	}
+
+	return Achievement{
+		ID:         id,
+		UnlockedAt: unlockedAt,
+	}, nil
+}
+
+// AchievementFacts — состояние мероприятия, по которому проверяются условия достижений.
+type AchievementFacts struct {
+	Activities []*Activity
+	// Все группы мероприятия, включая проверяемую.
+	Characters []*Character
+	Rules      EventRules
+	Skills     SkillCatalogue
+}
+
+// AchievementRule — правило получения достижения.
+type AchievementRule struct {
+	ID    string
+	Title string
+	Check func(c *Character, facts AchievementFacts) bool
+}
+
+// AchievementRules — каталог достижений мероприятия.
+var AchievementRules = []AchievementRule{
+	{
+		ID:    "all_engineering",
+		Title: "Инженер до мозга костей: посещены все инженерные точки",
+		Check: visitedAllOfSkill(Engineering),
+	},
+	{
+		ID:    "first_to_finish",
+		Title: "Первые на финише: все посещения завершены раньше всех",
+		Check: firstToFinish,
+	},
+	{
+		ID:    "all_skills_5",
+		Title: "Разносторонняя личность: не меньше 5 баллов в каждом навыке",
+		Check: atLeastPointsInEverySkill(5),
+	},
+}
+
+// AchievementRuleByID ищет правило в каталоге AchievementRules.
+func AchievementRuleByID(id string) (AchievementRule, bool) {
+	for _, r := range AchievementRules {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return AchievementRule{}, false
+}
+
+// UnlockAchievements выдаёт группе достижения, условия которых выполнены, и
+// возвращает только что полученные. Полученные достижения не отзываются.
+func (c *Character) UnlockAchievements(rules []AchievementRule, facts AchievementFacts, now time.Time) []Achievement {
+	res := make([]Achievement, 0)
+	for _, rule := range rules {
+		if c.HasAchievement(rule.ID) || !rule.Check(c, facts) {
+			continue
+		}
+
+		a := Achievement{ID: rule.ID, UnlockedAt: now}
+		c.Achievements = append(c.Achievements, a)
+		res = append(res, a)
+	}
+	return res
+}
+
+func (c *Character) HasAchievement(id string) bool {
+	return slices.ContainsFunc(c.Achievements, func(a Achievement) bool {
+		return a.ID == id
+	})
+}
+
+// HasVisited сообщает, что группа побывала на точке: отметилась на ней или
+// получила от неё оценку.
+func (c *Character) HasVisited(activityName string) bool {
+	for _, slot := range c.Slots {
+		if b, ok := slot.Booking(activityName); ok && b.isVisited() {
+			return true
+		}
+	}
+
+	_, ok := c.LastGrade(activityName)
+	return ok
+}
+
+// CompletedSlots возвращает число завершённых посещений.
+func (c *Character) CompletedSlots() int {
+	i := 0
+	for _, slot := range c.Slots {
+		for _, b := range slot.Bookings {
+			if b.Status == SlotCompleted {
+				i++
+			}
+		}
+	}
+	return i
+}
+
+// visitedAllOfSkill проверяет, что группа побывала на всех точках с навыком
+// skill. Если навыка нет в каталоге мероприятия, достижение не выдаётся.
+func visitedAllOfSkill(skill SkillType) func(c *Character, facts AchievementFacts) bool {
+	return func(c *Character, facts AchievementFacts) bool {
+		if _, ok := facts.Skills.Skill(skill); !ok {
+			return false
+		}
+
+		found := false
+		for _, a := range facts.Activities {
+			if len(a.Slots) == 0 || !slices.Contains(a.Skills, skill) {
+				continue
+			}
+			if !c.HasVisited(a.Name) {
+				return false
+			}
+			found = true
+		}
+		return found
+	}
+}
+
+func firstToFinish(c *Character, facts AchievementFacts) bool {
+	if facts.Rules.MaxTakenSlots <= 0 || c.CompletedSlots() < facts.Rules.MaxTakenSlots {
+		return false
+	}
+
+	for _, other := range facts.Characters {
+		if other.GroupName != c.GroupName && other.HasAchievement("first_to_finish") {
+			return false
+		}
+	}
+	return true
+}
+
+func atLeastPointsInEverySkill(points int) func(c *Character, facts AchievementFacts) bool {
+	return func(c *Character, facts AchievementFacts) bool {
+		skills := c.Skills()
+		for _, st := range facts.Skills.Types() {
+			if skills[st] < points {
+				return false
+			}
+		}
+		return !facts.Skills.IsZero()
+	}
+}
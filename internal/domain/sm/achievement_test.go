@@ -0,0 +1,118 @@
+package sm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+func TestCharacter_UnlockAchievements(t *testing.T) {
+	rules := sm.DefaultEventRules()
+	rules.MaxTakenSlots = 2
+	start := time.Now().Add(time.Hour).Truncate(time.Minute)
+	now := time.Now()
+
+	slots := func() []*sm.Slot {
+		return []*sm.Slot{
+			sm.MustNewSlot(start, start.Add(rules.SlotDuration)),
+			sm.MustNewSlot(start.Add(rules.SlotDuration), start.Add(2*rules.SlotDuration)),
+		}
+	}
+
+	robotics, err := sm.NewActivity(
+		"ЦМР", "Центр молодёжной робототехники", nil, nil, nil, nil,
+		[]sm.SkillType{sm.Engineering}, 5, slots(),
+	)
+	require.NoError(t, err)
+	lab, err := sm.NewActivity(
+		"Лаборатория", "Инженерная лаборатория", nil, nil, nil, nil,
+		[]sm.SkillType{sm.Engineering, sm.Researching}, 5, slots(),
+	)
+	require.NoError(t, err)
+	activities := []*sm.Activity{robotics, lab}
+
+	visit := func(char *sm.Character, i int, activityName string) {
+		require.NoError(t, char.Slots[i].Take(activityName))
+		require.NoError(t, char.Slots[i].Complete(activityName))
+	}
+
+	t.Run("should unlock engineering achievement after visiting all engineering activities", func(t *testing.T) {
+		char := sm.MustNewCharacter("СМ1-11Б", "first", slots())
+		facts := sm.AchievementFacts{
+			Activities: activities,
+			Characters: []*sm.Character{char},
+			Rules:      rules,
+			Skills:     sm.DefaultSkillCatalogue(),
+		}
+
+		visit(char, 0, robotics.Name)
+		require.Empty(t, char.UnlockAchievements(sm.AchievementRules, facts, now))
+
+		visit(char, 1, lab.Name)
+		unlocked := char.UnlockAchievements(sm.AchievementRules, facts, now)
+		require.ElementsMatch(t, []string{"all_engineering", "first_to_finish"}, achievementIDs(unlocked))
+
+		require.Empty(t, char.UnlockAchievements(sm.AchievementRules, facts, now))
+		require.Len(t, char.Achievements, 2)
+	})
+
+	t.Run("should not unlock engineering achievement without engineering in catalogue", func(t *testing.T) {
+		char := sm.MustNewCharacter("СМ1-11Б", "first", slots())
+		skill, err := sm.NewSkill("Творческие", "🎨", sm.GeneralCategory, 1)
+		require.NoError(t, err)
+		facts := sm.AchievementFacts{
+			Activities: activities,
+			Characters: []*sm.Character{char},
+			Rules:      rules,
+			Skills:     sm.MustNewSkillCatalogue([]sm.Skill{skill}),
+		}
+
+		visit(char, 0, robotics.Name)
+		visit(char, 1, lab.Name)
+		require.Equal(t, []string{"first_to_finish"}, achievementIDs(char.UnlockAchievements(sm.AchievementRules, facts, now)))
+	})
+
+	t.Run("should give first to finish only to one group", func(t *testing.T) {
+		first := sm.MustNewCharacter("СМ1-11Б", "first", slots())
+		second := sm.MustNewCharacter("СМ1-12Б", "second", slots())
+		facts := sm.AchievementFacts{Activities: activities, Characters: []*sm.Character{first, second}, Rules: rules}
+
+		visit(first, 0, robotics.Name)
+		visit(first, 1, "Спорт")
+		visit(second, 0, robotics.Name)
+		visit(second, 1, "Спорт")
+
+		require.Equal(t, []string{"first_to_finish"}, achievementIDs(first.UnlockAchievements(sm.AchievementRules, facts, now)))
+		require.Empty(t, second.UnlockAchievements(sm.AchievementRules, facts, now))
+	})
+
+	t.Run("should unlock all skills achievement", func(t *testing.T) {
+		char := sm.MustNewCharacter("СМ1-11Б", "first", slots())
+		facts := sm.AchievementFacts{
+			Activities: activities,
+			Characters: []*sm.Character{char},
+			Rules:      rules,
+			Skills:     sm.DefaultSkillCatalogue(),
+		}
+		skills := facts.Skills.Types()
+
+		for _, skill := range skills[1:] {
+			require.NoError(t, char.GiveGrade(skill, 5, "Спорт"))
+		}
+		require.Empty(t, char.UnlockAchievements(sm.AchievementRules, facts, now))
+
+		require.NoError(t, char.GiveGrade(skills[0], 5, "Спорт"))
+		require.Equal(t, []string{"all_skills_5"}, achievementIDs(char.UnlockAchievements(sm.AchievementRules, facts, now)))
+	})
+}
+
+func achievementIDs(as []sm.Achievement) []string {
+	res := make([]string, len(as))
+	for i, a := range as {
+		res[i] = a.ID
+	}
+	return res
+}
@@ -14,6 +14,7 @@ type Activity struct {
 	FullName    string
 	Description *string
 	Location    *string
+	Zone        *string // Зона кампуса, по которой считается время на дорогу.
 	Admins      []User
 	Skills      []SkillType
 	MaxPoints   int
@@ -25,6 +26,7 @@ func NewActivity(
 	fullName string,
 	description *string,
 	location *string,
+	zone *string,
 	admins []User,
 	skills []SkillType,
 	maxPoints int,
@@ -46,6 +48,10 @@ func NewActivity(
 		return nil, commonerrs.NewInvalidInputError("expected not empty name or nil")
 	}
 
+	if zone != nil && *zone == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty zone or nil")
+	}
+
 	if admins == nil {
 		admins = make([]User, 0)
 	}
@@ -89,6 +95,7 @@ func NewActivity(
 		FullName:    fullName,
 		Description: description,
 		Location:    location,
+		Zone:        zone,
 		Admins:      admins,
 		Skills:      skills,
 		MaxPoints:   maxPoints,
@@ -101,6 +108,7 @@ func UnmarshallActivityFromDB(
 	fullName string,
 	description *string,
 	location *string,
+	zone *string,
 	admins []User,
 	skillsStr []string,
 	maxPoints int,
@@ -118,6 +126,10 @@ func UnmarshallActivityFromDB(
 		return nil, commonerrs.NewInvalidInputError("expected not empty name or nil")
 	}
 
+	if zone != nil && *zone == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty zone or nil")
+	}
+
 	if admins == nil {
 		admins = make([]User, 0)
 	}
@@ -136,6 +148,7 @@ func UnmarshallActivityFromDB(
 		FullName:    fullName,
 		Description: description,
 		Location:    location,
+		Zone:        zone,
 		Admins:      admins,
 		Skills:      skills,
 		MaxPoints:   maxPoints,
@@ -146,7 +159,60 @@ func UnmarshallActivityFromDB(
 var ErrCannotIncSkill = errors.New("cannot increment skill")
 var ErrMaxPointsExceeded = errors.New("max points exceeded")
 
-func (a *Activity) Award(char *Character, skill SkillType, points int) error {
+var ErrGroupHasNotVisited = errors.New("group has not taken slot at activity")
+var ErrAwardOutsideSlotTime = errors.New("award outside slot time")
+var ErrSkillAlreadyAwarded = errors.New("skill already awarded for visit")
+
+// Award начисляет баллы группе, которая сейчас на точке: у неё должен быть слот
+// на эту активность, идущий в данный момент или закончившийся не позднее
+// AwardGracePeriod назад. За одно посещение навык оценивается только один раз.
+// Активности без расписания оцениваются без проверки посещения.
+func (a *Activity) Award(char *Character, skill SkillType, points int, rules EventRules) error {
+	if err := a.checkAward(skill, points); err != nil {
+		return err
+	}
+
+	if len(a.Slots) > 0 {
+		visit, err := a.currentVisit(char.GroupName, time.Now(), rules)
+		if err != nil {
+			return err
+		}
+
+		if char.hasGradeBetween(a.Name, skill, visit.Start, visit.End.Add(rules.AwardGracePeriod)) {
+			return ErrSkillAlreadyAwarded
+		}
+
+		if err = visit.Complete(char.GroupName); err != nil {
+			return err
+		}
+
+		if err = char.completeSlot(visit.Start, a.Name); err != nil {
+			return err
+		}
+	}
+
+	return char.GiveGrade(skill, points, a.Name)
+}
+
+// AwardWithoutVisit начисляет баллы без проверки посещения точки. Так
+// исправлять начисления может только организатор.
+func (a *Activity) AwardWithoutVisit(user User, char *Character, skill SkillType, points int) error {
+	if err := CanUserManageEvent(user); err != nil {
+		return err
+	}
+
+	return a.awardWithoutVisit(char, skill, points)
+}
+
+func (a *Activity) awardWithoutVisit(char *Character, skill SkillType, points int) error {
+	if err := a.checkAward(skill, points); err != nil {
+		return err
+	}
+
+	return char.GiveGrade(skill, points, a.Name)
+}
+
+func (a *Activity) checkAward(skill SkillType, points int) error {
 	if points > a.MaxPoints {
 		return ErrMaxPointsExceeded
 	}
@@ -155,7 +221,62 @@ func (a *Activity) Award(char *Character, skill SkillType, points int) error {
 		return ErrCannotIncSkill
 	}
 
-	return char.GiveGrade(skill, points, a.Name)
+	return nil
+}
+
+func (a *Activity) currentVisit(groupName string, now time.Time, rules EventRules) (*Slot, error) {
+	visited := false
+	for _, slot := range a.Slots {
+		if !slot.IsTakenBy(groupName) {
+			continue
+		}
+		visited = true
+
+		if !now.Before(slot.Start) && !now.After(slot.End.Add(rules.AwardGracePeriod)) {
+			return slot, nil
+		}
+	}
+
+	if !visited {
+		return nil, ErrGroupHasNotVisited
+	}
+
+	return nil, ErrAwardOutsideSlotTime
+}
+
+var ErrGradeGivenByAnotherActivity = errors.New("grade given by another activity")
+
+func (a *Activity) RevokeGrade(char *Character, gradeID string, reason string, username string) error {
+	if err := a.checkGradeOwner(char, gradeID); err != nil {
+		return err
+	}
+
+	return char.RevokeGrade(gradeID, reason, username)
+}
+
+func (a *Activity) CorrectGrade(char *Character, gradeID string, points int, reason string, username string) error {
+	if points > a.MaxPoints {
+		return ErrMaxPointsExceeded
+	}
+
+	if err := a.checkGradeOwner(char, gradeID); err != nil {
+		return err
+	}
+
+	return char.CorrectGrade(gradeID, points, reason, username)
+}
+
+func (a *Activity) checkGradeOwner(char *Character, gradeID string) error {
+	grade, ok := char.Grade(gradeID)
+	if !ok {
+		return ErrGradeNotFound
+	}
+
+	if grade.ActivityName != a.Name {
+		return ErrGradeGivenByAnotherActivity
+	}
+
+	return nil
 }
 
 func (a *Activity) TakeSlot(start time.Time, groupName string) error {
@@ -167,13 +288,75 @@ func (a *Activity) TakeSlot(start time.Time, groupName string) error {
 	return slot.Take(groupName)
 }
 
-func (a *Activity) FreeSlot(start time.Time) error {
+func (a *Activity) CheckInSlot(start time.Time, groupName string) error {
+	slot, ok := a.slotByTime(start)
+	if !ok {
+		return ErrSlotNotFound
+	}
+
+	return slot.CheckIn(groupName)
+}
+
+func (a *Activity) MarkSlotNoShow(start time.Time, groupName string) error {
+	slot, ok := a.slotByTime(start)
+	if !ok {
+		return ErrSlotNotFound
+	}
+
+	return slot.MarkNoShow(groupName)
+}
+
+// OverdueSlots возвращает забронированные слоты, на которые группы не пришли вовремя.
+func (a *Activity) OverdueSlots(now time.Time, rules EventRules) []*Slot {
+	if rules.NoShowTimeout == 0 {
+		return nil
+	}
+
+	return funcs.Filter(a.Slots, func(slot *Slot) bool {
+		return len(slot.Overdue(now, rules.NoShowTimeout)) > 0
+	})
+}
+
+// RecentlyOverdueSlots возвращает просроченные слоты, срок отметки на которых
+// истёк не раньше чем за window до now. Более старые брони остаются на
+// усмотрение администраторов точек.
+func (a *Activity) RecentlyOverdueSlots(now time.Time, window time.Duration, rules EventRules) []*Slot {
+	return funcs.Filter(a.OverdueSlots(now, rules), func(slot *Slot) bool {
+		return !slot.Start.Add(rules.NoShowTimeout).Before(now.Add(-window))
+	})
+}
+
+var ErrSlotIsNotOverdue = errors.New("slot is not overdue")
+
+func (a *Activity) ReleaseSlot(start time.Time, groupName string, rules EventRules) error {
+	slot, ok := a.slotByTime(start)
+	if !ok {
+		return ErrSlotNotFound
+	}
+
+	if rules.NoShowTimeout == 0 || !slot.IsOverdue(groupName, time.Now(), rules.NoShowTimeout) {
+		return ErrSlotIsNotOverdue
+	}
+
+	return slot.Release(groupName)
+}
+
+func (a *Activity) FreeSlot(start time.Time, groupName string) error {
+	slot, ok := a.slotByTime(start)
+	if !ok {
+		return ErrSlotNotFound
+	}
+
+	return slot.Free(groupName)
+}
+
+func (a *Activity) CancelSlot(start time.Time, groupName string) error {
 	slot, ok := a.slotByTime(start)
 	if !ok {
 		return ErrSlotNotFound
 	}
 
-	return slot.Free()
+	return slot.Free(groupName)
 }
 
 func (a *Activity) AvailableSlots() []*Slot {
@@ -184,7 +367,7 @@ func (a *Activity) AvailableSlots() []*Slot {
 
 func (a *Activity) HasTaken(groupName string) bool {
 	for _, slot := range a.Slots {
-		if !slot.IsAvailable() && *slot.Whom == groupName {
+		if slot.IsTakenBy(groupName) {
 			return true
 		}
 	}
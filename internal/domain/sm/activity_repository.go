@@ -9,15 +9,16 @@ var ErrActivityAlreadyExists = errors.New("activity already exists")
 var ErrActivityNotFound = errors.New("activity not found")
 
 type ActivitiesRepository interface {
-	Save(ctx context.Context, activity *Activity) error
-	Activity(ctx context.Context, activityName string) (*Activity, error)
-	ActivityByAdmin(ctx context.Context, adminUsername string) (*Activity, error)
-	Activities(ctx context.Context) ([]*Activity, error)
-	AdditionalActivities(ctx context.Context) ([]*Activity, error)
-	AvailableActivities(ctx context.Context) ([]*Activity, error)
+	Save(ctx context.Context, eventID string, activity *Activity) error
+	Activity(ctx context.Context, eventID string, activityName string) (*Activity, error)
+	ActivitiesByAdmin(ctx context.Context, eventID string, adminUsername string) ([]*Activity, error)
+	Activities(ctx context.Context, eventID string) ([]*Activity, error)
+	AdditionalActivities(ctx context.Context, eventID string) ([]*Activity, error)
+	AvailableActivities(ctx context.Context, eventID string) ([]*Activity, error)
 	UpdateSlots(
 		ctx context.Context,
-		activityUUID string,
+		eventID string,
+		activityName string,
 		updateFn func(innerCtx context.Context, activity *Activity) error,
 	) error
 }
@@ -0,0 +1,99 @@
+package sm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+func TestActivity_Award(t *testing.T) {
+	rules := sm.DefaultEventRules()
+	now := time.Now().Truncate(time.Minute)
+
+	current := sm.MustNewSlot(now.Add(-10*time.Minute), now.Add(10*time.Minute))
+	finished := sm.MustNewSlot(now.Add(-time.Hour), now.Add(-40*time.Minute))
+	act, err := sm.NewActivity(
+		"ЦМР", "Центр молодёжной робототехники", nil, nil, nil, nil,
+		[]sm.SkillType{sm.Engineering, sm.Researching}, 5,
+		[]*sm.Slot{finished, current},
+	)
+	require.NoError(t, err)
+
+	t.Run("should reject group without taken slot", func(t *testing.T) {
+		char := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+		err := act.Award(char, sm.Engineering, 3, rules)
+		require.ErrorIs(t, err, sm.ErrGroupHasNotVisited)
+	})
+
+	t.Run("should reject award outside slot time", func(t *testing.T) {
+		require.NoError(t, finished.Take("СМ1-12Б"))
+		char := sm.MustNewCharacter("СМ1-12Б", "testname", nil)
+		err := act.Award(char, sm.Engineering, 3, rules)
+		require.ErrorIs(t, err, sm.ErrAwardOutsideSlotTime)
+
+		admin := sm.MustNewUser("admin", sm.Administrator)
+		err = act.AwardWithoutVisit(admin, char, sm.Engineering, 3)
+		require.ErrorIs(t, err, sm.ErrPermissionDenied)
+		require.Empty(t, char.Grades)
+
+		organizer := sm.MustNewUser("organizer", sm.Organizer)
+		require.NoError(t, act.AwardWithoutVisit(organizer, char, sm.Engineering, 3))
+		require.Len(t, char.Grades, 1)
+	})
+
+	t.Run("should award skill once per visit", func(t *testing.T) {
+		require.NoError(t, current.Take("СМ1-13Б"))
+		char := sm.MustNewCharacter("СМ1-13Б", "testname", nil)
+
+		require.NoError(t, act.Award(char, sm.Engineering, 3, rules))
+		require.Equal(t, sm.SlotCompleted, current.StatusOf(char.GroupName))
+		require.NoError(t, act.Award(char, sm.Researching, 2, rules))
+
+		err := act.Award(char, sm.Engineering, 1, rules)
+		require.ErrorIs(t, err, sm.ErrSkillAlreadyAwarded)
+
+		require.NoError(t, char.RevokeGrade(char.Grades[0].ID, "Ошиблись баллами", "admin"))
+		require.NoError(t, act.Award(char, sm.Engineering, 1, rules))
+	})
+}
+
+func TestActivity_ReleaseSlot(t *testing.T) {
+	rules := sm.DefaultEventRules()
+	now := time.Now().Truncate(time.Minute)
+
+	overdue := sm.MustNewSlot(now.Add(-rules.NoShowTimeout-time.Minute), now.Add(10*time.Minute))
+	upcoming := sm.MustNewSlot(now.Add(time.Hour), now.Add(time.Hour+20*time.Minute))
+	act, err := sm.NewActivity(
+		"ЦМР", "Центр молодёжной робототехники", nil, nil, nil, nil,
+		[]sm.SkillType{sm.Engineering}, 5,
+		[]*sm.Slot{overdue, upcoming},
+	)
+	require.NoError(t, err)
+
+	char := sm.MustNewCharacter("СМ1-11Б", "testname", []*sm.Slot{
+		sm.MustNewSlot(overdue.Start, overdue.End),
+		sm.MustNewSlot(upcoming.Start, upcoming.End),
+	})
+	for _, slot := range act.Slots {
+		require.NoError(t, slot.Take(char.GroupName))
+	}
+	for _, slot := range char.Slots {
+		require.NoError(t, slot.Take(act.Name))
+	}
+
+	require.Equal(t, []*sm.Slot{overdue}, act.OverdueSlots(time.Now(), rules))
+	require.Equal(t, []*sm.Slot{overdue}, act.RecentlyOverdueSlots(now, 2*time.Minute, rules))
+	require.Empty(t, act.RecentlyOverdueSlots(now, 30*time.Second, rules))
+
+	err = act.ReleaseSlot(upcoming.Start, char.GroupName, rules)
+	require.ErrorIs(t, err, sm.ErrSlotIsNotOverdue)
+
+	require.NoError(t, act.ReleaseSlot(overdue.Start, char.GroupName, rules))
+	require.NoError(t, char.ReleaseSlot(overdue.Start, act.Name))
+	require.True(t, overdue.IsAvailable())
+	require.Equal(t, 1, char.TakenSlots())
+	require.Empty(t, act.OverdueSlots(time.Now(), rules))
+}
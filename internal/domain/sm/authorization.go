@@ -0,0 +1,40 @@
+package sm
+
+import (
+	"errors"
+)
+
+var ErrPermissionDenied = errors.New("permission denied")
+
+// CanUserManageActivity проверяет, что пользователь может начислять баллы и
+// отмечать группы на точке: это её администраторы и организаторы.
+func CanUserManageActivity(user User, activity *Activity) error {
+	if user.Role == Organizer {
+		return nil
+	}
+
+	if user.Role == Administrator && activity.IsAdmin(user.Username) {
+		return nil
+	}
+
+	return ErrPermissionDenied
+}
+
+// CanUserManageEvent проверяет, что пользователь может действовать в обход
+// правил мероприятия, например бронировать слоты за группы.
+func CanUserManageEvent(user User) error {
+	if user.Role == Organizer {
+		return nil
+	}
+
+	return ErrPermissionDenied
+}
+
+func (a *Activity) IsAdmin(username string) bool {
+	for _, admin := range a.Admins {
+		if admin.Username == username {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package sm_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+func TestCanUserManageActivity(t *testing.T) {
+	admin := sm.MustNewUser("admin", sm.Administrator)
+	act, err := sm.NewActivity(
+		"ЦМР", "Центр молодёжной робототехники", nil, nil, nil, []sm.User{admin},
+		[]sm.SkillType{sm.Engineering}, 5, nil,
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, sm.CanUserManageActivity(admin, act))
+	require.NoError(t, sm.CanUserManageActivity(sm.MustNewUser("org", sm.Organizer), act))
+	require.ErrorIs(t, sm.CanUserManageActivity(sm.MustNewUser("other", sm.Administrator), act), sm.ErrPermissionDenied)
+	require.ErrorIs(t, sm.CanUserManageActivity(sm.MustNewUser("admin", sm.Participant), act), sm.ErrPermissionDenied)
+
+	require.NoError(t, sm.CanUserManageEvent(sm.MustNewUser("org", sm.Organizer)))
+	require.ErrorIs(t, sm.CanUserManageEvent(admin), sm.ErrPermissionDenied)
+}
+
+func TestCanUserManageActivity_SeveralActivities(t *testing.T) {
+	admin := sm.MustNewUser("admin", sm.Administrator)
+	other := sm.MustNewUser("other", sm.Administrator)
+
+	newActivity := func(name string, admins ...sm.User) *sm.Activity {
+		act, err := sm.NewActivity(name, name, nil, nil, nil, admins, []sm.SkillType{sm.Engineering}, 5, nil)
+		require.NoError(t, err)
+		return act
+	}
+
+	first := newActivity("ЦМР", admin)
+	second := newActivity("НОЦ", other, admin)
+	foreign := newActivity("ЦКП", other)
+
+	t.Run("should allow admin to manage every activity they are listed on", func(t *testing.T) {
+		require.NoError(t, sm.CanUserManageActivity(admin, first))
+		require.NoError(t, sm.CanUserManageActivity(admin, second))
+	})
+
+	t.Run("should deny admin picking activity they are not listed on", func(t *testing.T) {
+		require.ErrorIs(t, sm.CanUserManageActivity(admin, foreign), sm.ErrPermissionDenied)
+		require.ErrorIs(t, sm.CanUserManageActivity(other, first), sm.ErrPermissionDenied)
+	})
+}
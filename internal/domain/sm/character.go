@@ -5,25 +5,54 @@ import (
 	"fmt"
 	"regexp"
 	"slices"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
 
 	"github.com/zhikh23/sm-instruction/pkg/funcs"
 )
 
-const InstructionDuration = 4 * time.Hour
-const MaxTakenSlots = 4 + 1 + 2
-const MinDurationBefore = 5 * time.Minute
-
 var ErrSlotAlreadyExists = errors.New("slot already exists")
+var ErrGroupNameTooLong = errors.New("group name is too long")
+var ErrSameGroupName = errors.New("group already has this name")
+
+// MaxGroupNameLength — наибольшая длина названия группы в символах.
+const MaxGroupNameLength = 64
+
+// DefaultGroupNamePattern — формат названий учебных групп СМ.
+const DefaultGroupNamePattern = `^СМ\d{1,2}\-\d{2,3}[Б]?$`
 
 type Character struct {
+	// ID не меняется при переименовании группы.
+	ID        string
 	GroupName string
-	Username  string
-	StartedAt *time.Time
-	Slots     []*Slot
-	Grades    []Grade
+	// Username — капитан, записанный при импорте. С ним связываются организаторы.
+	Username string
+	Members  []Member
+	// InviteCode — код ссылки-приглашения в группу.
+	InviteCode string
+	StartedAt  *time.Time
+	// ScheduledStart — время старта волны, назначенное организатором. Раньше
+	// него Инструкция группы не начинается.
+	ScheduledStart *time.Time
+	// Extension — продление окна Инструкции организатором.
+	Extension time.Duration
+	// PausedAt — начало текущей паузы; nil, если отсчёт окна идёт.
+	PausedAt *time.Time
+	// Paused — суммарная длительность снятых пауз.
+	Paused time.Duration
+	Slots  []*Slot
+	Grades []Grade
+	// Полученные достижения в порядке получения.
+	Achievements []Achievement
+	Penalties    []Penalty
+	// Журнал изменений окна Инструкции в порядке изменения.
+	WindowAdjustments []WindowAdjustment
+	// Время недавних неверных секретных кодов.
+	CodeAttempts []time.Time
 }
 
 func NewCharacter(
@@ -39,13 +68,8 @@ func NewCharacter(
 		return nil, commonerrs.NewInvalidInputError("expected not empty username")
 	}
 
-	if err := ValidateGroupName(groupName); err != nil {
-		return nil, err
-	}
-
-	skills := make(map[SkillType]int)
-	for _, s := range AllSkills {
-		skills[s] = 0
+	if len([]rune(groupName)) > MaxGroupNameLength {
+		return nil, ErrGroupNameTooLong
 	}
 
 	times := make(map[time.Time]bool)
@@ -57,11 +81,18 @@ func NewCharacter(
 	}
 
 	return &Character{
-		GroupName: groupName,
-		Username:  username,
-		StartedAt: nil,
-		Slots:     slots,
-		Grades:    make([]Grade, 0),
+		ID:                uuid.New().String(),
+		GroupName:         groupName,
+		Username:          username,
+		Members:           []Member{{Username: username, Role: Captain}},
+		InviteCode:        newInviteCode(),
+		StartedAt:         nil,
+		Slots:             slots,
+		Grades:            make([]Grade, 0),
+		Achievements:      make([]Achievement, 0),
+		Penalties:         make([]Penalty, 0),
+		WindowAdjustments: make([]WindowAdjustment, 0),
+		CodeAttempts:      make([]time.Time, 0),
 	}, nil
 }
 
@@ -78,12 +109,27 @@ func MustNewCharacter(
 }
 
 func UnmarshallCharacterFromDB(
+	id string,
 	groupName string,
 	username string,
+	members []Member,
+	inviteCode string,
 	startedAt *time.Time,
+	scheduledStart *time.Time,
+	extension time.Duration,
+	pausedAt *time.Time,
+	paused time.Duration,
 	slots []*Slot,
 	grades []Grade,
+	achievements []Achievement,
+	penalties []Penalty,
+	windowAdjustments []WindowAdjustment,
+	codeAttempts []time.Time,
 ) (*Character, error) {
+	if id == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty id")
+	}
+
 	if groupName == "" {
 		return nil, commonerrs.NewInvalidInputError("expected not empty group")
 	}
@@ -92,8 +138,25 @@ func UnmarshallCharacterFromDB(
 		return nil, commonerrs.NewInvalidInputError("expected not empty username")
 	}
 
-	if err := ValidateGroupName(groupName); err != nil {
-		return nil, err
+	if inviteCode == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty invite code")
+	}
+
+	if extension < 0 {
+		return nil, commonerrs.NewInvalidInputError("expected non-negative instruction extension")
+	}
+
+	if paused < 0 {
+		return nil, commonerrs.NewInvalidInputError("expected non-negative instruction pause")
+	}
+
+	if pausedAt != nil && startedAt == nil {
+		return nil, commonerrs.NewInvalidInputError("expected not paused instruction before start")
+	}
+
+	// Капитан из импорта всегда состоит в группе.
+	if !slices.ContainsFunc(members, func(m Member) bool { return m.Username == username }) {
+		members = append(members, Member{Username: username, Role: Captain})
 	}
 
 	if slots == nil {
@@ -104,18 +167,58 @@ func UnmarshallCharacterFromDB(
 		grades = make([]Grade, 0)
 	}
 
+	if achievements == nil {
+		achievements = make([]Achievement, 0)
+	}
+
+	if penalties == nil {
+		penalties = make([]Penalty, 0)
+	}
+
+	if windowAdjustments == nil {
+		windowAdjustments = make([]WindowAdjustment, 0)
+	}
+
+	if codeAttempts == nil {
+		codeAttempts = make([]time.Time, 0)
+	}
+
 	return &Character{
-		Username:  username,
-		GroupName: groupName,
-		StartedAt: startedAt,
-		Slots:     slots,
-		Grades:    grades,
+		ID:                id,
+		Username:          username,
+		GroupName:         groupName,
+		Members:           members,
+		InviteCode:        inviteCode,
+		StartedAt:         startedAt,
+		ScheduledStart:    scheduledStart,
+		Extension:         extension,
+		PausedAt:          pausedAt,
+		Paused:            paused,
+		Slots:             slots,
+		Grades:            grades,
+		Achievements:      achievements,
+		Penalties:         penalties,
+		WindowAdjustments: windowAdjustments,
+		CodeAttempts:      codeAttempts,
 	}, nil
 }
 
-func ValidateGroupName(groupName string) error {
-	pattern := `^СМ\d{1,2}\-\d{2,3}[Б]?$`
-	if !regexp.MustCompile(pattern).MatchString(groupName) {
+// ValidateGroupName проверяет название группы по регулярному выражению pattern.
+func ValidateGroupName(groupName string, pattern string) error {
+	if groupName == "" {
+		return commonerrs.NewInvalidInputError("expected not empty group")
+	}
+
+	if len([]rune(groupName)) > MaxGroupNameLength {
+		return ErrGroupNameTooLong
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return commonerrs.NewInvalidInputErrorf("invalid group name pattern %s: %s", pattern, err.Error())
+	}
+
+	if !re.MatchString(groupName) {
 		return commonerrs.NewInvalidInputError(
 			fmt.Sprintf(
 				"invalid group name %s; expected match regular expression %s",
@@ -125,12 +228,45 @@ func ValidateGroupName(groupName string) error {
 	return nil
 }
 
-func (c *Character) Rating() float64 {
-	return c.ratingFactor() * float64(c.ratingBase())
+// SplitGroupNames разбирает список групп, перечисленных через запятую, точку
+// с запятой или с новой строки. Пробелы внутри названия сохраняются, повторы
+// отбрасываются.
+func SplitGroupNames(s string) []string {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ';' || r == '\n'
+	})
+
+	res := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if f = strings.TrimSpace(f); f != "" && !slices.Contains(res, f) {
+			res = append(res, f)
+		}
+	}
+	return res
+}
+
+// Rename меняет отображаемое название группы. Брони, оценки и остальные
+// записи группы при этом сохраняются.
+func (c *Character) Rename(groupName string, pattern string) error {
+	if err := ValidateGroupName(groupName, pattern); err != nil {
+		return err
+	}
+
+	if groupName == c.GroupName {
+		return ErrSameGroupName
+	}
+
+	c.GroupName = groupName
+
+	return nil
+}
+
+func (c *Character) Rating(policy RatingPolicy) float64 {
+	return policy.Rate(c).Total
 }
 
 func (c *Character) GiveGrade(skillType SkillType, points int, activityName string) error {
-	grade, err := NewGrade(skillType, points, activityName, time.Now())
+	grade, err := NewGrade(uuid.New().String(), skillType, points, activityName, time.Now())
 	if err != nil {
 		return err
 	}
@@ -140,6 +276,67 @@ func (c *Character) GiveGrade(skillType SkillType, points int, activityName stri
 	return nil
 }
 
+func (c *Character) RevokeGrade(gradeID string, reason string, username string) error {
+	grade, ok := c.gradeByID(gradeID)
+	if !ok {
+		return ErrGradeNotFound
+	}
+
+	if grade.IsRevoked() {
+		return ErrGradeAlreadyRevoked
+	}
+
+	revocation, err := NewGradeRevocation(reason, username, time.Now())
+	if err != nil {
+		return err
+	}
+
+	grade.Revocation = &revocation
+
+	return nil
+}
+
+// CorrectGrade отзывает оценку и выставляет вместо неё новую с тем же навыком.
+func (c *Character) CorrectGrade(gradeID string, points int, reason string, username string) error {
+	grade, ok := c.gradeByID(gradeID)
+	if !ok {
+		return ErrGradeNotFound
+	}
+	skillType, activityName := grade.SkillType, grade.ActivityName
+
+	if points <= 0 {
+		return commonerrs.NewInvalidInputError("expected positive number of points")
+	}
+
+	if err := c.RevokeGrade(gradeID, reason, username); err != nil {
+		return err
+	}
+
+	return c.GiveGrade(skillType, points, activityName)
+}
+
+// LastGrade возвращает последнюю не отозванную оценку, выставленную активностью.
+func (c *Character) LastGrade(activityName string) (Grade, bool) {
+	var last Grade
+	for _, g := range c.Grades {
+		if g.ActivityName != activityName || g.IsRevoked() {
+			continue
+		}
+		if last.IsZero() || !g.Time.Before(last.Time) {
+			last = g
+		}
+	}
+	return last, !last.IsZero()
+}
+
+func (c *Character) Grade(gradeID string) (Grade, bool) {
+	grade, ok := c.gradeByID(gradeID)
+	if !ok {
+		return Grade{}, false
+	}
+	return *grade, true
+}
+
 func (c *Character) AvailableSlots() []*Slot {
 	slotIsAvailable := func(slot *Slot) bool {
 		return slot.IsAvailable()
@@ -148,28 +345,110 @@ func (c *Character) AvailableSlots() []*Slot {
 	return funcs.Filter(c.Slots, slotIsAvailable)
 }
 
+var ErrInstructionAlreadyStarted = errors.New("instruction already started")
+var ErrInstructionNotStarted = errors.New("instruction not started")
+
+// Start начинает Инструкцию группы. Повторный старт ничего не меняет. Если
+// организатор назначил группе волну, Инструкция начинается не раньше неё.
 func (c *Character) Start() error {
+	if c.IsStarted() {
+		return nil
+	}
+
 	t := time.Now()
+	if c.ScheduledStart != nil && c.ScheduledStart.After(t) {
+		t = *c.ScheduledStart
+	}
 	c.StartedAt = &t
 
-	// Обрезаем слоты до нужного промежутка времени.
-	c.Slots = funcs.Filter(c.Slots, func(slot *Slot) bool {
-		return slot.Start.Before(c.StartedAt.Add(InstructionDuration))
-	})
+	return nil
+}
+
+// ScheduleStart назначает группе время старта волны.
+func (c *Character) ScheduleStart(at time.Time) error {
+	if at.IsZero() {
+		return commonerrs.NewInvalidInputError("expected not zero start time")
+	}
+
+	if c.IsStarted() {
+		return ErrInstructionAlreadyStarted
+	}
+
+	c.ScheduledStart = &at
+
+	return nil
+}
+
+// Restart начинает окно Инструкции заново с текущего момента и сбрасывает
+// продление и паузы. Слоты, отрезанные прежним окном, возвращаются из eventSlots.
+func (c *Character) Restart(username string, eventSlots []*Slot) error {
+	if username == "" {
+		return commonerrs.NewInvalidInputError("expected not empty username")
+	}
+
+	if !c.IsStarted() {
+		return ErrInstructionNotStarted
+	}
+
+	t := time.Now()
+	c.StartedAt = &t
+	c.Extension = 0
+	c.PausedAt = nil
+	c.Paused = 0
+	c.restoreSlots(eventSlots)
+	c.logWindowAdjustment(WindowRestarted, 0, username, t)
+
+	return nil
+}
+
+// Extend продлевает окно Инструкции на d.
+func (c *Character) Extend(d time.Duration, username string, eventSlots []*Slot) error {
+	if d <= 0 {
+		return commonerrs.NewInvalidInputError("expected positive instruction extension")
+	}
+
+	// Продление хранится в минутах.
+	if d.Truncate(time.Minute) != d {
+		return commonerrs.NewInvalidInputError("instruction extension must be multiply of minute")
+	}
+
+	if username == "" {
+		return commonerrs.NewInvalidInputError("expected not empty username")
+	}
+
+	if !c.IsStarted() {
+		return ErrInstructionNotStarted
+	}
+
+	c.Extension += d
+	c.restoreSlots(eventSlots)
+	c.logWindowAdjustment(WindowExtended, d, username, time.Now())
 
 	return nil
 }
 
+// restoreSlots добавляет группе недостающие пустые слоты мероприятия.
+func (c *Character) restoreSlots(eventSlots []*Slot) {
+	for _, slot := range eventSlots {
+		if _, ok := c.slotByTime(slot.Start); !ok {
+			c.Slots = append(c.Slots, MustNewSlot(slot.Start, slot.End))
+		}
+	}
+	slices.SortFunc(c.Slots, func(a, b *Slot) int {
+		return a.Start.Compare(b.Start)
+	})
+}
+
 func (c *Character) IsStarted() bool {
 	return c.StartedAt != nil
 }
 
-func (c *Character) EndTime() *time.Time {
+func (c *Character) EndTime(rules EventRules) *time.Time {
 	if !c.IsStarted() {
 		return nil
 	}
 
-	v := c.StartedAt.Add(InstructionDuration)
+	v := c.StartedAt.Add(rules.InstructionDuration + c.Extension + c.PausedFor(time.Now()))
 
 	return &v
 }
@@ -177,18 +456,34 @@ func (c *Character) EndTime() *time.Time {
 var ErrSlotsMaxNumberExceeded = errors.New("slot max number exceeded")
 var ErrSlotNotFound = errors.New("slot not found")
 var ErrSlotIsTooLate = errors.New("slot is too late")
+var ErrSlotIsTooEarly = errors.New("slot is before instruction start")
 var ErrSlotIsTooClose = errors.New("slot is too close")
 
-func (c *Character) TakeSlot(start time.Time, activityName string) error {
-	if c.TakenSlots() >= MaxTakenSlots {
+func (c *Character) TakeSlot(start time.Time, activityName string, rules EventRules) error {
+	if err := c.CanTakeSlotAt(start, rules); err != nil {
+		return err
+	}
+
+	slot, _ := c.slotByTime(start)
+
+	return slot.Take(activityName)
+}
+
+// CanTakeSlotAt проверяет, может ли группа забронировать слот, ничего не меняя.
+func (c *Character) CanTakeSlotAt(start time.Time, rules EventRules) error {
+	if c.TakenSlots() >= rules.MaxTakenSlots {
 		return ErrSlotsMaxNumberExceeded
 	}
 
-	if c.IsStarted() && start.After(*c.EndTime()) {
+	if c.IsStarted() && start.After(*c.EndTime(rules)) {
 		return ErrSlotIsTooLate
 	}
 
-	if start.Sub(time.Now()) < MinDurationBefore {
+	if c.IsStarted() && start.Before(*c.StartedAt) {
+		return ErrSlotIsTooEarly
+	}
+
+	if start.Sub(time.Now()) < rules.MinDurationBefore {
 		return ErrSlotIsTooClose
 	}
 
@@ -197,15 +492,165 @@ func (c *Character) TakeSlot(start time.Time, activityName string) error {
 		return ErrSlotNotFound
 	}
 
+	if !slot.IsAvailable() {
+		return ErrSlotHasAlreadyTaken
+	}
+
+	return nil
+}
+
+// CanFitInstructionWindow проверяет, что бронь на start не выводит группу, ещё
+// не начавшую Инструкцию, за окно InstructionDuration: от назначенной волны, а
+// без волны — между первой и последней бронью. Начавшие группы проверяет
+// CanTakeSlotAt.
+func (c *Character) CanFitInstructionWindow(start time.Time, rules EventRules) error {
+	if c.IsStarted() {
+		return nil
+	}
+
+	if c.ScheduledStart != nil {
+		if start.Before(*c.ScheduledStart) {
+			return ErrSlotIsTooEarly
+		}
+		if start.After(c.ScheduledStart.Add(rules.InstructionDuration)) {
+			return ErrSlotIsTooLate
+		}
+		return nil
+	}
+
+	first, last := start, start
+	for _, slot := range c.Slots {
+		if slot.IsAvailable() {
+			continue
+		}
+		if slot.Start.Before(first) {
+			first = slot.Start
+		}
+		if slot.Start.After(last) {
+			last = slot.Start
+		}
+	}
+
+	if last.After(first.Add(rules.InstructionDuration)) {
+		return ErrSlotIsTooLate
+	}
+
+	return nil
+}
+
+// CanReachSlotAt проверяет, успеет ли группа добраться до точки activityName
+// с соседних броней и вернуться с неё к следующей. Зоны точек берутся из zones.
+func (c *Character) CanReachSlotAt(
+	start time.Time,
+	activityName string,
+	zones map[string]string,
+	travel TravelTimes,
+) error {
+	slot, ok := c.slotByTime(start)
+	if !ok {
+		return ErrSlotNotFound
+	}
+	zone := zones[activityName]
+
+	for _, other := range c.Slots {
+		if other == slot || len(other.Bookings) == 0 {
+			continue
+		}
+		otherZone := zones[other.Bookings[0].Whom]
+
+		if !other.End.After(slot.Start) && slot.Start.Sub(other.End) < travel.Between(otherZone, zone) {
+			return ErrSlotIsUnreachable
+		}
+
+		if !other.Start.Before(slot.End) && other.Start.Sub(slot.End) < travel.Between(zone, otherZone) {
+			return ErrSlotIsUnreachable
+		}
+	}
+
+	return nil
+}
+
+var ErrSlotIsTooLateToCancel = errors.New("slot is too late to cancel")
+
+func (c *Character) CancelSlot(start time.Time, activityName string, rules EventRules) error {
+	if start.Sub(time.Now()) < rules.MinDurationBeforeCancel {
+		return ErrSlotIsTooLateToCancel
+	}
+
+	slot, ok := c.slotByTime(start)
+	if !ok {
+		return ErrSlotNotFound
+	}
+
+	return slot.Free(activityName)
+}
+
+// ForceTakeSlot бронирует слот в обход правил мероприятия: без ограничений
+// на число броней и время до начала слота.
+func (c *Character) ForceTakeSlot(start time.Time, activityName string) error {
+	slot, ok := c.slotByTime(start)
+	if !ok {
+		return ErrSlotNotFound
+	}
+
 	return slot.Take(activityName)
 }
 
+// ForceCancelSlot снимает бронь без ограничения на время до начала слота.
+func (c *Character) ForceCancelSlot(start time.Time, activityName string) error {
+	slot, ok := c.slotByTime(start)
+	if !ok {
+		return ErrSlotNotFound
+	}
+
+	return slot.Free(activityName)
+}
+
+func (c *Character) CheckInSlot(start time.Time, activityName string) error {
+	slot, ok := c.slotByTime(start)
+	if !ok {
+		return ErrSlotNotFound
+	}
+
+	return slot.CheckIn(activityName)
+}
+
+func (c *Character) MarkSlotNoShow(start time.Time, activityName string) error {
+	slot, ok := c.slotByTime(start)
+	if !ok {
+		return ErrSlotNotFound
+	}
+
+	return slot.MarkNoShow(activityName)
+}
+
+func (c *Character) ReleaseSlot(start time.Time, activityName string) error {
+	slot, ok := c.slotByTime(start)
+	if !ok {
+		return ErrSlotNotFound
+	}
+
+	return slot.Release(activityName)
+}
+
+// completeSlot завершает посещение. Слота может не быть, если он был отрезан
+// при старте Инструкции.
+func (c *Character) completeSlot(start time.Time, activityName string) error {
+	slot, ok := c.slotByTime(start)
+	if !ok {
+		return nil
+	}
+
+	return slot.Complete(activityName)
+}
+
+// Skills возвращает сумму баллов по навыкам. Навыков без оценок в результате нет.
 func (c *Character) Skills() map[SkillType]int {
 	skills := make(map[SkillType]int)
-	for _, skill := range AllSkills {
-		skills[skill] = c.sumPoints(func(st SkillType) bool {
-			return st == skill
-		})
+	for _, g := range c.Grades {
+		if !g.IsRevoked() {
+			skills[g.SkillType] += g.Points
+		}
 	}
 	return skills
 }
@@ -220,45 +665,40 @@ func (c *Character) TakenSlots() int {
 	return i
 }
 
-func (c *Character) CanTakeSlot() error {
-	if c.TakenSlots() >= MaxTakenSlots {
+func (c *Character) CanTakeSlot(rules EventRules) error {
+	if c.TakenSlots() >= rules.MaxTakenSlots {
 		return ErrSlotsMaxNumberExceeded
 	}
 
 	return nil
 }
 
-func (c *Character) slotByTime(start time.Time) (*Slot, bool) {
-	for _, slot := range c.Slots {
-		if slot.Start.Equal(start) {
-			return slot, true
+func (c *Character) hasGradeBetween(activityName string, skill SkillType, from time.Time, to time.Time) bool {
+	for _, g := range c.Grades {
+		if g.ActivityName != activityName || g.SkillType != skill || g.IsRevoked() {
+			continue
+		}
+		if !g.Time.Before(from) && !g.Time.After(to) {
+			return true
 		}
 	}
-	return nil, false
-}
-
-func (c *Character) ratingBase() int {
-	isGeneral := func(st SkillType) bool {
-		return slices.Contains(GeneralSkill, st)
-	}
-	return c.sumPoints(isGeneral)
+	return false
 }
 
-const lambda = 1.0 / 72 // Не спрашивайте, почему.
-
-func (c *Character) ratingFactor() float64 {
-	isAdditional := func(st SkillType) bool {
-		return slices.Contains(AdditionalSkill, st)
+func (c *Character) gradeByID(gradeID string) (*Grade, bool) {
+	for i := range c.Grades {
+		if c.Grades[i].ID == gradeID {
+			return &c.Grades[i], true
+		}
 	}
-	return 1.0 + lambda*float64(c.sumPoints(isAdditional))
+	return nil, false
 }
 
-func (c *Character) sumPoints(predicate func(st SkillType) bool) int {
-	r := 0
-	for _, g := range c.Grades {
-		if predicate(g.SkillType) {
-			r += g.Points
+func (c *Character) slotByTime(start time.Time) (*Slot, bool) {
+	for _, slot := range c.Slots {
+		if slot.Start.Equal(start) {
+			return slot, true
 		}
 	}
-	return r
+	return nil, false
 }
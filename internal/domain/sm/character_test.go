@@ -0,0 +1,192 @@
+package sm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+func TestCharacter_CancelSlot(t *testing.T) {
+	rules := sm.DefaultEventRules()
+	soon := time.Now().Add(rules.MinDurationBeforeCancel / 2).Truncate(time.Minute)
+	later := time.Now().Add(2 * rules.MinDurationBeforeCancel).Truncate(time.Minute)
+
+	char := sm.MustNewCharacter("СМ1-11Б", "testname", []*sm.Slot{
+		sm.MustNewSlot(soon, soon.Add(rules.SlotDuration)),
+		sm.MustNewSlot(later, later.Add(rules.SlotDuration)),
+	})
+
+	err := char.TakeSlot(later, "ЦМР", rules)
+	require.NoError(t, err)
+	require.Equal(t, 1, char.TakenSlots())
+
+	err = char.CancelSlot(later, "ССФСМ", rules)
+	require.ErrorIs(t, err, sm.ErrSlotTakenByAnother)
+
+	err = char.CancelSlot(later, "ЦМР", rules)
+	require.NoError(t, err)
+	require.Zero(t, char.TakenSlots())
+
+	err = char.CancelSlot(later, "ЦМР", rules)
+	require.ErrorIs(t, err, sm.ErrSlotHasNotTaken)
+
+	err = char.CancelSlot(soon, "ЦМР", rules)
+	require.ErrorIs(t, err, sm.ErrSlotIsTooLateToCancel)
+}
+
+func TestCharacter_CanReachSlotAt(t *testing.T) {
+	rules := sm.DefaultEventRules()
+	start := time.Now().Add(time.Hour).Truncate(time.Minute)
+
+	char := sm.MustNewCharacter("СМ1-11Б", "testname", []*sm.Slot{
+		sm.MustNewSlot(start, start.Add(rules.SlotDuration)),
+		sm.MustNewSlot(start.Add(rules.SlotDuration), start.Add(2*rules.SlotDuration)),
+		sm.MustNewSlot(start.Add(2*rules.SlotDuration), start.Add(3*rules.SlotDuration)),
+	})
+	require.NoError(t, char.TakeSlot(start, "ЦМР", rules))
+
+	zones := map[string]string{"ЦМР": "ГЗ", "ССФСМ": "ГЗ", "Спорткомплекс": "СК"}
+	travel := sm.MustNewTravelTimes([]sm.TravelTime{
+		{From: "СК", To: "ГЗ", Duration: 15 * time.Minute},
+	})
+
+	t.Run("should allow neighbouring slot in the same zone", func(t *testing.T) {
+		err := char.CanReachSlotAt(start.Add(rules.SlotDuration), "ССФСМ", zones, travel)
+		require.NoError(t, err)
+	})
+
+	t.Run("should reject neighbouring slot in a distant zone", func(t *testing.T) {
+		err := char.CanReachSlotAt(start.Add(rules.SlotDuration), "Спорткомплекс", zones, travel)
+		require.ErrorIs(t, err, sm.ErrSlotIsUnreachable)
+	})
+
+	t.Run("should allow distant zone with enough time to travel", func(t *testing.T) {
+		err := char.CanReachSlotAt(start.Add(2*rules.SlotDuration), "Спорткомплекс", zones, travel)
+		require.NoError(t, err)
+	})
+
+	t.Run("should ignore activities without zone", func(t *testing.T) {
+		err := char.CanReachSlotAt(start.Add(rules.SlotDuration), "Лекция", zones, travel)
+		require.NoError(t, err)
+	})
+}
+
+func TestCharacter_Rename(t *testing.T) {
+	t.Run("should keep id and records", func(t *testing.T) {
+		char := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+		require.NoError(t, char.GiveGrade(sm.Engineering, 3, "Лекция"))
+		id := char.ID
+
+		require.NoError(t, char.Rename("Гости МГУ", `^.+$`))
+		require.Equal(t, "Гости МГУ", char.GroupName)
+		require.Equal(t, id, char.ID)
+		require.Equal(t, 3, char.Skills()[sm.Engineering])
+	})
+
+	t.Run("should validate new name by pattern", func(t *testing.T) {
+		char := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+
+		require.ErrorAs(t, char.Rename("Гости МГУ", sm.DefaultGroupNamePattern), &commonerrs.InvalidInputError{})
+		require.ErrorIs(t, char.Rename("СМ1-11Б", sm.DefaultGroupNamePattern), sm.ErrSameGroupName)
+		require.Equal(t, "СМ1-11Б", char.GroupName)
+	})
+}
+
+func TestSplitGroupNames(t *testing.T) {
+	require.Equal(t,
+		[]string{"СМ1-11Б", "Гости МГУ", "СМ1-12Б"},
+		sm.SplitGroupNames("СМ1-11Б, Гости МГУ;\nСМ1-12Б,, СМ1-11Б"),
+	)
+	require.Empty(t, sm.SplitGroupNames(" , \n"))
+}
+
+func TestCharacter_Start(t *testing.T) {
+	rules := sm.DefaultEventRules()
+
+	t.Run("should not restart on repeated start", func(t *testing.T) {
+		char := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+		require.NoError(t, char.Start())
+		startedAt := *char.StartedAt
+
+		require.NoError(t, char.Start())
+		require.Equal(t, startedAt, *char.StartedAt)
+	})
+
+	t.Run("should start not before scheduled wave", func(t *testing.T) {
+		wave := time.Now().Add(time.Hour).Truncate(time.Minute)
+		char := sm.MustNewCharacter("СМ1-11Б", "testname", []*sm.Slot{
+			sm.MustNewSlot(wave.Add(-rules.SlotDuration), wave),
+			sm.MustNewSlot(wave, wave.Add(rules.SlotDuration)),
+		})
+		require.NoError(t, char.ScheduleStart(wave))
+
+		require.NoError(t, char.Start())
+		require.Equal(t, wave, *char.StartedAt)
+		require.ErrorIs(t, char.CanTakeSlotAt(wave.Add(-rules.SlotDuration), rules), sm.ErrSlotIsTooEarly)
+		require.NoError(t, char.CanTakeSlotAt(wave, rules))
+		require.ErrorIs(t, char.ScheduleStart(wave), sm.ErrInstructionAlreadyStarted)
+	})
+
+	t.Run("should extend and restart window", func(t *testing.T) {
+		char := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+		require.ErrorIs(t, char.Extend(time.Hour, "organizer", nil), sm.ErrInstructionNotStarted)
+		require.NoError(t, char.Start())
+		end := *char.EndTime(rules)
+
+		require.ErrorAs(t, char.Extend(30*time.Second, "organizer", nil), &commonerrs.InvalidInputError{})
+		require.NoError(t, char.Extend(30*time.Minute, "organizer", nil))
+		require.Equal(t, end.Add(30*time.Minute), *char.EndTime(rules))
+
+		require.NoError(t, char.Restart("organizer", nil))
+		require.Zero(t, char.Extension)
+		require.False(t, char.EndTime(rules).Before(end))
+		require.Len(t, char.WindowAdjustments, 2)
+		require.Equal(t, sm.WindowExtended, char.WindowAdjustments[0].Kind)
+		require.Equal(t, sm.WindowRestarted, char.WindowAdjustments[1].Kind)
+	})
+}
+
+func TestCharacter_Pause(t *testing.T) {
+	rules := sm.DefaultEventRules()
+
+	t.Run("should not pause before start", func(t *testing.T) {
+		char := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+
+		require.ErrorIs(t, char.Pause("organizer", time.Now()), sm.ErrInstructionNotStarted)
+		require.ErrorIs(t, char.Resume("organizer", time.Now()), sm.ErrInstructionNotPaused)
+	})
+
+	t.Run("should move end time while paused", func(t *testing.T) {
+		char := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+		require.NoError(t, char.Start())
+		end := *char.EndTime(rules)
+
+		now := time.Now()
+		require.NoError(t, char.Pause("organizer", now))
+		require.ErrorIs(t, char.Pause("organizer", now), sm.ErrInstructionAlreadyPaused)
+		require.Equal(t, 10*time.Minute, char.PausedFor(now.Add(10*time.Minute)))
+
+		require.NoError(t, char.Resume("organizer", now.Add(10*time.Minute)))
+		require.False(t, char.IsPaused())
+		require.Equal(t, 10*time.Minute, char.Paused)
+		require.Equal(t, end.Add(10*time.Minute), *char.EndTime(rules))
+
+		require.Len(t, char.WindowAdjustments, 2)
+		require.Equal(t, sm.WindowPaused, char.WindowAdjustments[0].Kind)
+		require.Equal(t, sm.WindowResumed, char.WindowAdjustments[1].Kind)
+		require.Equal(t, char.Paused, char.WindowAdjustments[1].Duration)
+	})
+
+	t.Run("should reject slots after paused window", func(t *testing.T) {
+		char := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+		require.NoError(t, char.Start())
+		require.NoError(t, char.Pause("organizer", time.Now()))
+
+		end := *char.EndTime(rules)
+		require.ErrorIs(t, char.CanTakeSlotAt(end.Add(time.Hour), rules), sm.ErrSlotIsTooLate)
+	})
+}
@@ -9,12 +9,15 @@ var ErrCharacterAlreadyExists = errors.New("character already exists")
 var ErrCharacterNotFound = errors.New("character not found")
 
 type CharactersRepository interface {
-	Save(ctx context.Context, character *Character) error
-	Character(ctx context.Context, groupName string) (*Character, error)
-	Characters(ctx context.Context) ([]*Character, error)
-	CharacterByUsername(ctx context.Context, username string) (*Character, error)
+	Save(ctx context.Context, eventID string, character *Character) error
+	Character(ctx context.Context, eventID string, groupName string) (*Character, error)
+	Characters(ctx context.Context, eventID string) ([]*Character, error)
+	CharacterByUsername(ctx context.Context, eventID string, username string) (*Character, error)
+	GroupNameByID(ctx context.Context, eventID string, id string) (string, error)
+	CharacterByInviteCode(ctx context.Context, eventID string, inviteCode string) (*Character, error)
 	Update(
 		ctx context.Context,
+		eventID string,
 		groupName string,
 		updateFn func(innerCtx context.Context, char *Character) error,
 	) error
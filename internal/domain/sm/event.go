@@ -0,0 +1,167 @@
+package sm
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+)
+
+const DateFormat = "2006-01-02"
+
+type Event struct {
+	ID       string
+	Name     string
+	Date     time.Time
+	Timezone *time.Location
+	// Регулярное выражение, которому должны соответствовать названия групп.
+	GroupNamePattern string
+	Rules            EventRules
+	// Время на дорогу между зонами кампуса.
+	TravelTimes  TravelTimes
+	Skills       SkillCatalogue
+	RatingPolicy RatingPolicy
+}
+
+func NewEvent(
+	id string,
+	name string,
+	date time.Time,
+	timezone string,
+	groupNamePattern string,
+	rules EventRules,
+	travelTimes TravelTimes,
+	skills SkillCatalogue,
+	ratingPolicy RatingPolicy,
+) (*Event, error) {
+	if id == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty id")
+	}
+
+	if name == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty name")
+	}
+
+	if date.IsZero() {
+		return nil, commonerrs.NewInvalidInputError("expected not zero date")
+	}
+
+	if timezone == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty timezone")
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, commonerrs.NewInvalidInputErrorf("invalid timezone %s: %s", timezone, err.Error())
+	}
+
+	if groupNamePattern == "" {
+		groupNamePattern = DefaultGroupNamePattern
+	}
+
+	if _, err = regexp.Compile(groupNamePattern); err != nil {
+		return nil, commonerrs.NewInvalidInputErrorf("invalid group name pattern %s: %s", groupNamePattern, err.Error())
+	}
+
+	if rules.IsZero() {
+		return nil, commonerrs.NewInvalidInputError("expected not empty rules")
+	}
+
+	if skills.IsZero() {
+		skills = DefaultSkillCatalogue()
+	}
+
+	if ratingPolicy == nil {
+		ratingPolicy, err = NewSkillsRatingPolicy(skills, rules.RatingLambda, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Event{
+		ID:               id,
+		Name:             name,
+		Date:             time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, loc),
+		Timezone:         loc,
+		GroupNamePattern: groupNamePattern,
+		Rules:            rules,
+		TravelTimes:      travelTimes,
+		Skills:           skills,
+		RatingPolicy:     ratingPolicy,
+	}, nil
+}
+
+func MustNewEvent(
+	id string,
+	name string,
+	date time.Time,
+	timezone string,
+	groupNamePattern string,
+	rules EventRules,
+	travelTimes TravelTimes,
+	skills SkillCatalogue,
+	ratingPolicy RatingPolicy,
+) *Event {
+	e, err := NewEvent(id, name, date, timezone, groupNamePattern, rules, travelTimes, skills, ratingPolicy)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+func UnmarshallEventFromDB(
+	id string,
+	name string,
+	date time.Time,
+	timezone string,
+	groupNamePattern string,
+	rules EventRules,
+	travelTimes TravelTimes,
+	skills []Skill,
+	skillRatings []SkillRating,
+	balanceBonus float64,
+) (*Event, error) {
+	catalogue, err := NewSkillCatalogue(skills)
+	if err != nil {
+		return nil, err
+	}
+	ratingPolicy, err := NewSkillsRatingPolicy(catalogue, rules.RatingLambda, skillRatings, balanceBonus)
+	if err != nil {
+		return nil, err
+	}
+	return NewEvent(id, name, date, timezone, groupNamePattern, rules, travelTimes, catalogue, ratingPolicy)
+}
+
+// ValidateGroupName проверяет название группы по формату, принятому на мероприятии.
+func (e *Event) ValidateGroupName(groupName string) error {
+	return ValidateGroupName(groupName, e.GroupNamePattern)
+}
+
+// TimeAt возвращает момент времени в день проведения события.
+func (e *Event) TimeAt(hours int, minutes int) time.Time {
+	return time.Date(e.Date.Year(), e.Date.Month(), e.Date.Day(), hours, minutes, 0, 0, e.Timezone)
+}
+
+// ParseTime разбирает время в формате TimeFormat в день проведения события.
+func (e *Event) ParseTime(s string) (time.Time, error) {
+	return ParseTimeAt(e.Date, s)
+}
+
+// ParseTimeAt разбирает время в формате TimeFormat в день date. Часовой пояс
+// берётся из date, поэтому от настроек сервера результат не зависит.
+func ParseTimeAt(date time.Time, s string) (time.Time, error) {
+	t, err := time.Parse(TimeFormat, strings.TrimSpace(s))
+	if err != nil {
+		return time.Time{}, commonerrs.NewInvalidInputErrorf("invalid time %q; expected format %s", s, TimeFormat)
+	}
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), 0, 0, date.Location()), nil
+}
+
+func (e *Event) SlotTimes() []time.Time {
+	return e.Rules.SlotTimes(e.Date)
+}
+
+func (e *Event) EmptySlots() []*Slot {
+	return e.Rules.EmptySlots(e.Date)
+}
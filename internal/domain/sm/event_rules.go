@@ -0,0 +1,166 @@
+package sm
+
+import (
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+)
+
+type EventRules struct {
+	InstructionDuration     time.Duration
+	MaxTakenSlots           int
+	MinDurationBefore       time.Duration
+	MinDurationBeforeCancel time.Duration
+	RatingLambda            float64
+	SlotDuration            time.Duration
+	// Время начала первого и последнего слота отсчитывается от начала дня.
+	FirstSlotStart time.Duration
+	LastSlotStart  time.Duration
+	// Сколько ещё можно начислять баллы после окончания слота.
+	AwardGracePeriod time.Duration
+	// Через сколько после начала слота без отметки о прибытии бронь снимается.
+	// Нулевое значение отключает автоматическое снятие.
+	NoShowTimeout time.Duration
+	// Сколько группа из листа ожидания может думать над предложенным слотом.
+	WaitlistOfferTimeout time.Duration
+	// Наибольший штраф за одно нарушение и наибольшая сумма штрафов группы.
+	// Нулевая сумма снимает ограничение на сумму.
+	MaxPenaltyPoints      int
+	MaxTotalPenaltyPoints int
+	// Сколько неверных секретных кодов группа может ввести за CodeLockout,
+	// прежде чем ввод кодов будет заблокирован.
+	MaxCodeAttempts int
+	CodeLockout     time.Duration
+}
+
+func DefaultEventRules() EventRules {
+	return EventRules{
+		InstructionDuration:     4 * time.Hour,
+		MaxTakenSlots:           4 + 1 + 2,
+		MinDurationBefore:       5 * time.Minute,
+		MinDurationBeforeCancel: 15 * time.Minute,
+		RatingLambda:            1.0 / 72, // Не спрашивайте, почему.
+		SlotDuration:            20 * time.Minute,
+		FirstSlotStart:          11*time.Hour + 20*time.Minute,
+		LastSlotStart:           17*time.Hour + 20*time.Minute,
+		AwardGracePeriod:        10 * time.Minute,
+		NoShowTimeout:           10 * time.Minute,
+		WaitlistOfferTimeout:    5 * time.Minute,
+		MaxPenaltyPoints:        5,
+		MaxTotalPenaltyPoints:   15,
+		MaxCodeAttempts:         5,
+		CodeLockout:             15 * time.Minute,
+	}
+}
+
+// NewEventRules проверяет правила мероприятия и возвращает их без изменений.
+func NewEventRules(r EventRules) (EventRules, error) {
+	if r.InstructionDuration <= 0 {
+		return EventRules{}, commonerrs.NewInvalidInputError("expected positive instruction duration")
+	}
+
+	if r.MaxTakenSlots <= 0 {
+		return EventRules{}, commonerrs.NewInvalidInputError("expected positive max number of taken slots")
+	}
+
+	if r.MinDurationBefore < 0 {
+		return EventRules{}, commonerrs.NewInvalidInputError("expected non-negative min duration before slot")
+	}
+
+	if r.MinDurationBeforeCancel < 0 {
+		return EventRules{}, commonerrs.NewInvalidInputError("expected non-negative min duration before cancel")
+	}
+
+	if r.RatingLambda < 0 {
+		return EventRules{}, commonerrs.NewInvalidInputError("expected non-negative rating lambda")
+	}
+
+	if r.SlotDuration <= 0 {
+		return EventRules{}, commonerrs.NewInvalidInputError("expected positive slot duration")
+	}
+
+	if r.FirstSlotStart < 0 || r.FirstSlotStart >= 24*time.Hour {
+		return EventRules{}, commonerrs.NewInvalidInputError("expected first slot start within a day")
+	}
+
+	if r.LastSlotStart < r.FirstSlotStart || r.LastSlotStart >= 24*time.Hour {
+		return EventRules{}, commonerrs.NewInvalidInputError("expected last slot start within a day after first slot start")
+	}
+
+	if r.AwardGracePeriod < 0 {
+		return EventRules{}, commonerrs.NewInvalidInputError("expected non-negative award grace period")
+	}
+
+	if r.NoShowTimeout < 0 {
+		return EventRules{}, commonerrs.NewInvalidInputError("expected non-negative no-show timeout")
+	}
+
+	if r.WaitlistOfferTimeout <= 0 {
+		return EventRules{}, commonerrs.NewInvalidInputError("expected positive waitlist offer timeout")
+	}
+
+	if r.MaxPenaltyPoints <= 0 {
+		return EventRules{}, commonerrs.NewInvalidInputError("expected positive max penalty points")
+	}
+
+	if r.MaxTotalPenaltyPoints < 0 {
+		return EventRules{}, commonerrs.NewInvalidInputError("expected non-negative max total penalty points")
+	}
+
+	if r.MaxCodeAttempts <= 0 {
+		return EventRules{}, commonerrs.NewInvalidInputError("expected positive max code attempts")
+	}
+
+	if r.CodeLockout <= 0 {
+		return EventRules{}, commonerrs.NewInvalidInputError("expected positive code lockout")
+	}
+
+	for _, d := range []time.Duration{
+		r.InstructionDuration, r.MinDurationBefore, r.MinDurationBeforeCancel, r.SlotDuration, r.FirstSlotStart,
+		r.LastSlotStart, r.AwardGracePeriod, r.NoShowTimeout, r.WaitlistOfferTimeout, r.CodeLockout,
+	} {
+		if d.Truncate(time.Minute) != d {
+			return EventRules{}, commonerrs.NewInvalidInputError("durations must be multiply of minute")
+		}
+	}
+
+	return r, nil
+}
+
+func MustNewEventRules(r EventRules) EventRules {
+	r, err := NewEventRules(r)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func UnmarshallEventRulesFromDB(r EventRules) (EventRules, error) {
+	return NewEventRules(r)
+}
+
+func (r EventRules) IsZero() bool {
+	return r == EventRules{}
+}
+
+// SlotTimes возвращает время начала всех слотов в указанный день.
+func (r EventRules) SlotTimes(day time.Time) []time.Time {
+	midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+
+	times := make([]time.Time, 0)
+	first := midnight.Add(r.FirstSlotStart)
+	last := midnight.Add(r.LastSlotStart)
+	for it := first; !it.After(last); it = it.Add(r.SlotDuration) {
+		times = append(times, it)
+	}
+	return times
+}
+
+func (r EventRules) EmptySlots(day time.Time) []*Slot {
+	times := r.SlotTimes(day)
+	slots := make([]*Slot, len(times))
+	for i, t := range times {
+		slots[i] = MustNewSlot(t, t.Add(r.SlotDuration))
+	}
+	return slots
+}
@@ -0,0 +1,50 @@
+package sm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+func TestEventRules_SlotTimes(t *testing.T) {
+	rules := sm.DefaultEventRules()
+	day := time.Date(2024, time.October, 5, 0, 0, 0, 0, time.UTC)
+
+	times := rules.SlotTimes(day)
+	require.Len(t, times, 19)
+	require.Equal(t, time.Date(2024, time.October, 5, 11, 20, 0, 0, time.UTC), times[0])
+	require.Equal(t, time.Date(2024, time.October, 5, 17, 20, 0, 0, time.UTC), times[len(times)-1])
+}
+
+func TestNewEventRules(t *testing.T) {
+	t.Run("should accept default rules", func(t *testing.T) {
+		rules, err := sm.NewEventRules(sm.DefaultEventRules())
+		require.NoError(t, err)
+		require.Equal(t, sm.DefaultEventRules(), rules)
+	})
+
+	t.Run("should return an error on invalid max taken slots", func(t *testing.T) {
+		rules := sm.DefaultEventRules()
+		rules.MaxTakenSlots = 0
+		_, err := sm.NewEventRules(rules)
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should return an error on last slot before first slot", func(t *testing.T) {
+		rules := sm.DefaultEventRules()
+		rules.FirstSlotStart, rules.LastSlotStart = 17*time.Hour, 11*time.Hour
+		_, err := sm.NewEventRules(rules)
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should return an error on duration that is not whole minutes", func(t *testing.T) {
+		rules := sm.DefaultEventRules()
+		rules.SlotDuration = 20*time.Minute + 30*time.Second
+		_, err := sm.NewEventRules(rules)
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+}
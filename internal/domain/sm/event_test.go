@@ -0,0 +1,89 @@
+package sm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+func TestNewEvent(t *testing.T) {
+	date := time.Date(2024, time.October, 5, 23, 30, 0, 0, time.UTC)
+
+	t.Run("should normalize date to midnight in event timezone", func(t *testing.T) {
+		event, err := sm.NewEvent("2024-10-05", "Инструктаж", date, "Europe/Moscow", "", sm.DefaultEventRules(), sm.TravelTimes{}, sm.SkillCatalogue{}, nil)
+		require.NoError(t, err)
+		require.Equal(t, "Europe/Moscow", event.Timezone.String())
+		require.Equal(t, "2024-10-05", event.Date.Format(sm.DateFormat))
+		require.Equal(t, 0, event.Date.Hour())
+	})
+
+	t.Run("should return an error on unknown timezone", func(t *testing.T) {
+		_, err := sm.NewEvent("2024-10-05", "Инструктаж", date, "Mars/Olympus", "", sm.DefaultEventRules(), sm.TravelTimes{}, sm.SkillCatalogue{}, nil)
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should return an error on empty rules", func(t *testing.T) {
+		_, err := sm.NewEvent("2024-10-05", "Инструктаж", date, "UTC", "", sm.EventRules{}, sm.TravelTimes{}, sm.SkillCatalogue{}, nil)
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should return an error on invalid group name pattern", func(t *testing.T) {
+		_, err := sm.NewEvent("2024-10-05", "Инструктаж", date, "UTC", "^(", sm.DefaultEventRules(), sm.TravelTimes{}, sm.SkillCatalogue{}, nil)
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+}
+
+func TestEvent_ValidateGroupName(t *testing.T) {
+	date := time.Date(2024, time.October, 5, 0, 0, 0, 0, time.UTC)
+
+	t.Run("should use default pattern", func(t *testing.T) {
+		event := sm.MustNewEvent("2024-10-05", "Инструктаж", date, "UTC", "", sm.DefaultEventRules(), sm.TravelTimes{}, sm.SkillCatalogue{}, nil)
+		require.NoError(t, event.ValidateGroupName("СМ1-11Б"))
+		require.ErrorAs(t, event.ValidateGroupName("ИУ7-11Б"), &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should use event pattern", func(t *testing.T) {
+		event := sm.MustNewEvent("2024-10-05", "Инструктаж", date, "UTC", `^(СМ|ИУ)\d{1,2}-\d{2,3}Б?$|^Гости .+$`, sm.DefaultEventRules(), sm.TravelTimes{}, sm.SkillCatalogue{}, nil)
+		require.NoError(t, event.ValidateGroupName("ИУ7-11Б"))
+		require.NoError(t, event.ValidateGroupName("Гости МГУ"))
+		require.ErrorAs(t, event.ValidateGroupName("РК6-11Б"), &commonerrs.InvalidInputError{})
+	})
+}
+
+func TestEvent_EmptySlots(t *testing.T) {
+	event := sm.MustNewEvent(
+		"2024-10-05", "Инструктаж",
+		time.Date(2024, time.October, 5, 0, 0, 0, 0, time.UTC),
+		"Europe/Moscow", "", sm.DefaultEventRules(), sm.TravelTimes{}, sm.SkillCatalogue{}, nil,
+	)
+
+	slots := event.EmptySlots()
+	require.Len(t, slots, 19)
+	require.Equal(t, event.TimeAt(11, 20), slots[0].Start)
+	require.Equal(t, event.Timezone, slots[0].Start.Location())
+}
+
+func TestEvent_ParseTime(t *testing.T) {
+	event := sm.MustNewEvent(
+		"2024-10-05", "Инструктаж",
+		time.Date(2024, time.October, 5, 0, 0, 0, 0, time.UTC),
+		"Asia/Vladivostok", "", sm.DefaultEventRules(), sm.TravelTimes{}, sm.SkillCatalogue{}, nil,
+	)
+
+	t.Run("should parse time in event day and timezone", func(t *testing.T) {
+		start, err := event.ParseTime("11:20")
+		require.NoError(t, err)
+		require.Equal(t, event.TimeAt(11, 20), start)
+		require.Equal(t, "Asia/Vladivostok", start.Location().String())
+		require.Equal(t, time.Date(2024, time.October, 5, 1, 20, 0, 0, time.UTC), start.UTC())
+	})
+
+	t.Run("should return an error on invalid time", func(t *testing.T) {
+		_, err := event.ParseTime("25:99")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+}
@@ -0,0 +1,30 @@
+package sm
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrEventAlreadyExists = errors.New("event already exists")
+var ErrEventNotFound = errors.New("event not found")
+var ErrEventRulesNotFound = errors.New("event rules not found")
+
+type EventsRepository interface {
+	Save(ctx context.Context, event *Event) error
+	Event(ctx context.Context, eventID string) (*Event, error)
+	// CurrentEvent возвращает событие с самой поздней датой проведения.
+	CurrentEvent(ctx context.Context) (*Event, error)
+	Events(ctx context.Context) ([]*Event, error)
+}
+
+type EventProvider interface {
+	Event(ctx context.Context) (*Event, error)
+}
+
+type EventRulesProvider interface {
+	Rules(ctx context.Context) (EventRules, error)
+}
+
+type SkillsProvider interface {
+	Skills(ctx context.Context) (SkillCatalogue, error)
+}
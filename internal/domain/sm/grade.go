@@ -1,23 +1,42 @@
 package sm
 
 import (
-	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+	"errors"
 	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
 )
 
+var ErrGradeNotFound = errors.New("grade not found")
+var ErrGradeAlreadyRevoked = errors.New("grade already revoked")
+
 type Grade struct {
+	ID           string
 	SkillType    SkillType
 	Points       int
 	ActivityName string
 	Time         time.Time
+	// Отозванная оценка остаётся в истории, но не учитывается в рейтинге.
+	Revocation *GradeRevocation
+}
+
+type GradeRevocation struct {
+	Reason   string
+	Username string
+	Time     time.Time
 }
 
 func NewGrade(
+	id string,
 	skillType SkillType,
 	points int,
 	activityName string,
 	time time.Time,
 ) (Grade, error) {
+	if id == "" {
+		return Grade{}, commonerrs.NewInvalidInputError("expected not empty grade id")
+	}
+
 	if skillType.IsZero() {
 		return Grade{}, commonerrs.NewInvalidInputError("expected not empty skill type")
 	}
@@ -35,6 +54,7 @@ func NewGrade(
 	}
 
 	return Grade{
+		ID:           id,
 		SkillType:    skillType,
 		Points:       points,
 		ActivityName: activityName,
@@ -42,11 +62,33 @@ func NewGrade(
 	}, nil
 }
 
+func NewGradeRevocation(reason string, username string, time time.Time) (GradeRevocation, error) {
+	if reason == "" {
+		return GradeRevocation{}, commonerrs.NewInvalidInputError("expected not empty revocation reason")
+	}
+
+	if username == "" {
+		return GradeRevocation{}, commonerrs.NewInvalidInputError("expected not empty username")
+	}
+
+	if time.IsZero() {
+		return GradeRevocation{}, commonerrs.NewInvalidInputError("expected non empty time")
+	}
+
+	return GradeRevocation{
+		Reason:   reason,
+		Username: username,
+		Time:     time,
+	}, nil
+}
+
 func UnmarshallGradeFromDB(
+	id string,
 	skillTypeStr string,
 	points int,
 	activityName string,
 	time time.Time,
+	revocation *GradeRevocation,
 ) (Grade, error) {
 	if skillTypeStr == "" {
 		return Grade{}, commonerrs.NewInvalidInputError("expected not empty skill type")
@@ -57,26 +99,20 @@ func UnmarshallGradeFromDB(
 		return Grade{}, err
 	}
 
-	if points <= 0 {
-		return Grade{}, commonerrs.NewInvalidInputError("expected positive number of points")
-	}
-
-	if activityName == "" {
-		return Grade{}, commonerrs.NewInvalidInputError("expected non empty activity name")
+	grade, err := NewGrade(id, skillType, points, activityName, time)
+	if err != nil {
+		return Grade{}, err
 	}
 
-	if time.IsZero() {
-		return Grade{}, commonerrs.NewInvalidInputError("expected non empty time")
-	}
+	grade.Revocation = revocation
 
-	return Grade{
-		SkillType:    skillType,
-		Points:       points,
-		ActivityName: activityName,
-		Time:         time,
-	}, nil
+	return grade, nil
 }
 
 func (g Grade) IsZero() bool {
 	return g == Grade{}
 }
+
+func (g Grade) IsRevoked() bool {
+	return g.Revocation != nil
+}
@@ -5,23 +5,74 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
 	"github.com/zhikh23/sm-instruction/internal/domain/sm"
 )
 
 func TestGrades(t *testing.T) {
+	rules := sm.DefaultEventRules()
 	char := sm.MustNewCharacter("СМ1-11Б", "testname", []*sm.Slot{})
-	require.Zero(t, char.Rating())
+	require.Zero(t, char.Rating(sm.DefaultRatingPolicy(rules)))
 
 	var err error
 	err = char.GiveGrade(sm.Engineering, 3, "ЦМР")
 	require.NoError(t, err)
-	require.Equal(t, 3.0, char.Rating())
+	require.Equal(t, 3.0, char.Rating(sm.DefaultRatingPolicy(rules)))
 
 	err = char.GiveGrade(sm.Researching, 2, "ЦМР")
 	require.NoError(t, err)
-	require.Equal(t, 5.0, char.Rating())
+	require.Equal(t, 5.0, char.Rating(sm.DefaultRatingPolicy(rules)))
 
 	err = char.GiveGrade(sm.Creative, 2, "ССФСМ")
 	require.NoError(t, err)
-	require.InDelta(t, 5.13889, char.Rating(), 1e-5)
+	require.InDelta(t, 5.13889, char.Rating(sm.DefaultRatingPolicy(rules)), 1e-5)
+}
+
+func TestCharacter_RevokeGrade(t *testing.T) {
+	rules := sm.DefaultEventRules()
+	char := sm.MustNewCharacter("СМ1-11Б", "testname", []*sm.Slot{})
+
+	require.NoError(t, char.GiveGrade(sm.Engineering, 3, "ЦМР"))
+	require.NoError(t, char.GiveGrade(sm.Researching, 2, "ЦМР"))
+
+	last, ok := char.LastGrade("ЦМР")
+	require.True(t, ok)
+	require.Equal(t, sm.Researching, last.SkillType)
+
+	t.Run("should require reason", func(t *testing.T) {
+		err := char.RevokeGrade(last.ID, "", "admin")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	err := char.RevokeGrade(last.ID, "Ошиблись группой", "admin")
+	require.NoError(t, err)
+	require.Len(t, char.Grades, 2)
+	require.True(t, char.Grades[1].IsRevoked())
+	require.Equal(t, 3.0, char.Rating(sm.DefaultRatingPolicy(rules)))
+
+	err = char.RevokeGrade(last.ID, "Ещё раз", "admin")
+	require.ErrorIs(t, err, sm.ErrGradeAlreadyRevoked)
+
+	err = char.RevokeGrade("unknown", "Ошиблись группой", "admin")
+	require.ErrorIs(t, err, sm.ErrGradeNotFound)
+
+	last, ok = char.LastGrade("ЦМР")
+	require.True(t, ok)
+	require.Equal(t, sm.Engineering, last.SkillType)
+}
+
+func TestCharacter_CorrectGrade(t *testing.T) {
+	rules := sm.DefaultEventRules()
+	char := sm.MustNewCharacter("СМ1-11Б", "testname", []*sm.Slot{})
+
+	require.NoError(t, char.GiveGrade(sm.Engineering, 3, "ЦМР"))
+	grade := char.Grades[0]
+
+	err := char.CorrectGrade(grade.ID, 1, "Опечатка", "admin")
+	require.NoError(t, err)
+	require.Len(t, char.Grades, 2)
+	require.True(t, char.Grades[0].IsRevoked())
+	require.Equal(t, "Опечатка", char.Grades[0].Revocation.Reason)
+	require.Equal(t, sm.Engineering, char.Grades[1].SkillType)
+	require.Equal(t, 1.0, char.Rating(sm.DefaultRatingPolicy(rules)))
 }
@@ -0,0 +1,110 @@
+package sm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+)
+
+var ErrPenaltyTooLarge = errors.New("penalty exceeds max penalty points")
+var ErrPenaltyLimitExceeded = errors.New("total penalty limit exceeded")
+
+// Penalty — штраф за нарушение правил. Штраф не привязан к навыку и вычитается
+// из итогового рейтинга группы.
+type Penalty struct {
+	ID     string
+	Points int
+	Reason string
+	// Точка, администратор которой выписал штраф. Пусто, если штраф выписан
+	// организатором вне точки.
+	ActivityName string
+	Username     string
+	Time         time.Time
+}
+
+func NewPenalty(
+	id string,
+	points int,
+	reason string,
+	activityName string,
+	username string,
+	time time.Time,
+) (Penalty, error) {
+	if id == "" {
+		return Penalty{}, commonerrs.NewInvalidInputError("expected not empty penalty id")
+	}
+
+	if points <= 0 {
+		return Penalty{}, commonerrs.NewInvalidInputError("expected positive number of penalty points")
+	}
+
+	if reason == "" {
+		return Penalty{}, commonerrs.NewInvalidInputError("expected not empty penalty reason")
+	}
+
+	if username == "" {
+		return Penalty{}, commonerrs.NewInvalidInputError("expected not empty username")
+	}
+
+	if time.IsZero() {
+		return Penalty{}, commonerrs.NewInvalidInputError("expected non empty time")
+	}
+
+	return Penalty{
+		ID:           id,
+		Points:       points,
+		Reason:       reason,
+		ActivityName: activityName,
+		Username:     username,
+		Time:         time,
+	}, nil
+}
+
+func UnmarshallPenaltyFromDB(
+	id string,
+	points int,
+	reason string,
+	activityName string,
+	username string,
+	time time.Time,
+) (Penalty, error) {
+	return NewPenalty(id, points, reason, activityName, username, time)
+}
+
+// Penalize выписывает группе штраф в пределах ограничений мероприятия.
+func (c *Character) Penalize(
+	points int,
+	reason string,
+	activityName string,
+	username string,
+	rules EventRules,
+) error {
+	penalty, err := NewPenalty(uuid.New().String(), points, reason, activityName, username, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if points > rules.MaxPenaltyPoints {
+		return ErrPenaltyTooLarge
+	}
+
+	if rules.MaxTotalPenaltyPoints > 0 && c.PenaltyPoints()+points > rules.MaxTotalPenaltyPoints {
+		return ErrPenaltyLimitExceeded
+	}
+
+	c.Penalties = append(c.Penalties, penalty)
+
+	return nil
+}
+
+// PenaltyPoints возвращает сумму всех штрафов группы.
+func (c *Character) PenaltyPoints() int {
+	r := 0
+	for _, p := range c.Penalties {
+		r += p.Points
+	}
+	return r
+}
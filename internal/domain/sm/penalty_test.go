@@ -0,0 +1,44 @@
+package sm_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+func TestCharacter_Penalize(t *testing.T) {
+	rules := sm.DefaultEventRules()
+	rules.MaxPenaltyPoints = 5
+	rules.MaxTotalPenaltyPoints = 8
+
+	t.Run("should subtract penalties from rating", func(t *testing.T) {
+		char := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+		require.NoError(t, char.GiveGrade(sm.Engineering, 10, "ЦМР"))
+		require.NoError(t, char.Penalize(3, "Опоздание", "ЦМР", "admin", rules))
+
+		policy := sm.DefaultRatingPolicy(rules)
+		b := policy.Rate(char)
+		require.Equal(t, 3.0, b.Penalty)
+		require.InDelta(t, 7.0, b.Total, 1e-9)
+		require.Equal(t, 3, char.PenaltyPoints())
+	})
+
+	t.Run("should require reason", func(t *testing.T) {
+		char := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+		err := char.Penalize(3, "", "ЦМР", "admin", rules)
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+		require.Empty(t, char.Penalties)
+	})
+
+	t.Run("should respect event limits", func(t *testing.T) {
+		char := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+		require.ErrorIs(t, char.Penalize(6, "Списывание", "", "organizer", rules), sm.ErrPenaltyTooLarge)
+
+		require.NoError(t, char.Penalize(5, "Списывание", "", "organizer", rules))
+		require.ErrorIs(t, char.Penalize(4, "Опоздание", "ЦМР", "admin", rules), sm.ErrPenaltyLimitExceeded)
+		require.NoError(t, char.Penalize(3, "Опоздание", "ЦМР", "admin", rules))
+	})
+}
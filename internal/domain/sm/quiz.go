@@ -0,0 +1,385 @@
+package sm
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+)
+
+var ErrQuizNotFound = errors.New("quiz not found")
+var ErrQuizAlreadyExists = errors.New("quiz already exists")
+var ErrQuizAlreadyStarted = errors.New("quiz already started by group")
+var ErrQuizNotStarted = errors.New("quiz not started by group")
+var ErrQuizAlreadyFinished = errors.New("quiz already finished")
+var ErrQuizNotFinished = errors.New("quiz not finished")
+var ErrQuizAlreadyAwarded = errors.New("quiz already awarded")
+
+// DefaultQuizQuestionTime — время на ответ, если для вопроса оно не указано.
+const DefaultQuizQuestionTime = time.Minute
+
+// Quiz — викторина точки. Группа отвечает на вопросы по очереди, на каждый
+// вопрос отводится своё время.
+type Quiz struct {
+	ActivityName string
+	Questions    []QuizQuestion
+}
+
+type QuizQuestion struct {
+	Text string
+	// Варианты ответа. Если их нет, ответ вводится текстом.
+	Options []string
+	// Ответы, которые засчитываются как верные.
+	Answers []string
+	// Навык, в который идут баллы за вопрос. Пустой навык при создании
+	// викторины заменяется первым навыком активности.
+	Skill SkillType
+	Time  time.Duration
+}
+
+func NewQuizQuestion(
+	text string,
+	options []string,
+	answers []string,
+	skill SkillType,
+	timeLimit time.Duration,
+) (QuizQuestion, error) {
+	if text == "" {
+		return QuizQuestion{}, commonerrs.NewInvalidInputError("expected not empty question text")
+	}
+
+	if options == nil {
+		options = make([]string, 0)
+	}
+
+	if len(answers) == 0 {
+		return QuizQuestion{}, commonerrs.NewInvalidInputError("expected at least one answer")
+	}
+
+	if len(options) > 0 {
+		for _, a := range answers {
+			if !containsQuizAnswer(options, a) {
+				return QuizQuestion{}, commonerrs.NewInvalidInputErrorf("expected answer %q among options", a)
+			}
+		}
+	}
+
+	if timeLimit <= 0 {
+		return QuizQuestion{}, commonerrs.NewInvalidInputError("expected positive question time")
+	}
+
+	return QuizQuestion{
+		Text:    text,
+		Options: options,
+		Answers: answers,
+		Skill:   skill,
+		Time:    timeLimit,
+	}, nil
+}
+
+// IsCorrect сравнивает ответ без учёта регистра и лишних пробелов.
+func (q QuizQuestion) IsCorrect(answer string) bool {
+	return containsQuizAnswer(q.Answers, answer)
+}
+
+func containsQuizAnswer(answers []string, answer string) bool {
+	answer = normalizeQuizAnswer(answer)
+	for _, a := range answers {
+		if normalizeQuizAnswer(a) == answer {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeQuizAnswer(s string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.Join(strings.Fields(s), " ")), "ё", "е")
+}
+
+func NewQuiz(activityName string, questions []QuizQuestion) (*Quiz, error) {
+	if activityName == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty activity name")
+	}
+
+	if len(questions) == 0 {
+		return nil, commonerrs.NewInvalidInputError("expected at least one question")
+	}
+
+	for _, q := range questions {
+		if q.Skill.IsZero() {
+			return nil, commonerrs.NewInvalidInputError("expected not empty skill of question")
+		}
+	}
+
+	return &Quiz{
+		ActivityName: activityName,
+		Questions:    questions,
+	}, nil
+}
+
+func UnmarshallQuizFromDB(activityName string, questions []QuizQuestion) (*Quiz, error) {
+	return NewQuiz(activityName, questions)
+}
+
+// NewQuiz создаёт викторину точки. Вопросы без навыка оцениваются в первый
+// навык активности.
+func (a *Activity) NewQuiz(questions []QuizQuestion) (*Quiz, error) {
+	if a.MaxPoints <= 0 {
+		return nil, commonerrs.NewInvalidInputErrorf("expected activity %q with positive max points", a.Name)
+	}
+
+	res := make([]QuizQuestion, len(questions))
+	for i, q := range questions {
+		if q.Skill.IsZero() && len(a.Skills) > 0 {
+			q.Skill = a.Skills[0]
+		}
+		if !slices.Contains(a.Skills, q.Skill) {
+			return nil, ErrCannotIncSkill
+		}
+		res[i] = q
+	}
+
+	return NewQuiz(a.Name, res)
+}
+
+// QuizAttempt — прохождение викторины группой. Отвечать может любой участник
+// группы.
+type QuizAttempt struct {
+	ActivityName string
+	GroupName    string
+	Username     string
+	StartedAt    time.Time
+	Answers      []QuizAnswer
+	// Баллы за викторину начислены.
+	Awarded bool
+}
+
+type QuizAnswer struct {
+	Text     string
+	Username string
+	Correct  bool
+	// Ответ пришёл после истечения времени на вопрос и не засчитан.
+	TimedOut bool
+	Time     time.Time
+}
+
+func NewQuizAttempt(activityName string, groupName string, username string, startedAt time.Time) (*QuizAttempt, error) {
+	if activityName == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty activity name")
+	}
+
+	if groupName == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty group name")
+	}
+
+	if username == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty username")
+	}
+
+	if startedAt.IsZero() {
+		return nil, commonerrs.NewInvalidInputError("expected not empty started at")
+	}
+
+	return &QuizAttempt{
+		ActivityName: activityName,
+		GroupName:    groupName,
+		Username:     username,
+		StartedAt:    startedAt,
+		Answers:      make([]QuizAnswer, 0),
+	}, nil
+}
+
+func UnmarshallQuizAttemptFromDB(
+	activityName string,
+	groupName string,
+	username string,
+	startedAt time.Time,
+	answers []QuizAnswer,
+	awarded bool,
+) (*QuizAttempt, error) {
+	at, err := NewQuizAttempt(activityName, groupName, username, startedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if answers != nil {
+		at.Answers = answers
+	}
+	at.Awarded = awarded
+
+	return at, nil
+}
+
+// StartQuiz начинает викторину. На точке с расписанием викторину можно
+// проходить только во время слота группы, как и получать баллы.
+func (a *Activity) StartQuiz(
+	quiz *Quiz,
+	groupName string,
+	username string,
+	now time.Time,
+	rules EventRules,
+) (*QuizAttempt, error) {
+	if quiz.ActivityName != a.Name {
+		return nil, ErrQuizNotFound
+	}
+
+	if len(a.Slots) > 0 {
+		if _, err := a.currentVisit(groupName, now, rules); err != nil {
+			return nil, err
+		}
+	}
+
+	return NewQuizAttempt(a.Name, groupName, username, now)
+}
+
+func (at *QuizAttempt) IsFinished(quiz *Quiz) bool {
+	return len(at.Answers) >= len(quiz.Questions)
+}
+
+// CurrentQuestion возвращает номер вопроса, на который группа отвечает сейчас.
+func (at *QuizAttempt) CurrentQuestion(quiz *Quiz) (int, bool) {
+	if at.IsFinished(quiz) {
+		return 0, false
+	}
+	return len(at.Answers), true
+}
+
+// Deadline возвращает, до какого времени принимается ответ на текущий вопрос.
+// Отсчёт идёт с предыдущего ответа или с начала викторины.
+func (at *QuizAttempt) Deadline(quiz *Quiz) (time.Time, bool) {
+	i, ok := at.CurrentQuestion(quiz)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	shownAt := at.StartedAt
+	if i > 0 {
+		shownAt = at.Answers[i-1].Time
+	}
+	return shownAt.Add(quiz.Questions[i].Time), true
+}
+
+// Answer засчитывает ответ на текущий вопрос. Опоздавший ответ не засчитывается,
+// и группа переходит к следующему вопросу.
+func (at *QuizAttempt) Answer(quiz *Quiz, text string, username string, now time.Time) (QuizAnswer, error) {
+	if username == "" {
+		return QuizAnswer{}, commonerrs.NewInvalidInputError("expected not empty username")
+	}
+
+	i, ok := at.CurrentQuestion(quiz)
+	if !ok {
+		return QuizAnswer{}, ErrQuizAlreadyFinished
+	}
+
+	deadline, _ := at.Deadline(quiz)
+	answer := QuizAnswer{
+		Text:     text,
+		Username: username,
+		Time:     now,
+	}
+	if now.After(deadline) {
+		answer.TimedOut = true
+	} else {
+		answer.Correct = quiz.Questions[i].IsCorrect(text)
+	}
+
+	at.Answers = append(at.Answers, answer)
+
+	return answer, nil
+}
+
+func (at *QuizAttempt) CorrectAnswers() int {
+	n := 0
+	for _, a := range at.Answers {
+		if a.Correct {
+			n++
+		}
+	}
+	return n
+}
+
+// QuizScore — результат викторины по одному навыку.
+type QuizScore struct {
+	Skill   SkillType
+	Correct int
+	Total   int
+	Points  int
+}
+
+// QuizScores переводит ответы группы в баллы: по каждому навыку доля верных
+// ответов от MaxPoints, с округлением вниз.
+func (a *Activity) QuizScores(quiz *Quiz, at *QuizAttempt) []QuizScore {
+	res := make([]QuizScore, 0, len(a.Skills))
+	for i, q := range quiz.Questions {
+		j := slices.IndexFunc(res, func(s QuizScore) bool { return s.Skill == q.Skill })
+		if j < 0 {
+			res = append(res, QuizScore{Skill: q.Skill})
+			j = len(res) - 1
+		}
+
+		res[j].Total++
+		if i < len(at.Answers) && at.Answers[i].Correct {
+			res[j].Correct++
+		}
+	}
+
+	for i := range res {
+		res[i].Points = a.MaxPoints * res[i].Correct / res[i].Total
+	}
+
+	return res
+}
+
+// AwardQuiz начисляет баллы за завершённую викторину. Посещение проверяется
+// на момент начала викторины: группа, начавшая её во время слота, получает
+// баллы и после его окончания. Навыки, за которые администратор уже начислил
+// баллы во время посещения, пропускаются.
+func (a *Activity) AwardQuiz(char *Character, quiz *Quiz, at *QuizAttempt, rules EventRules) error {
+	if at.ActivityName != a.Name || at.GroupName != char.GroupName {
+		return ErrQuizNotStarted
+	}
+
+	if !at.IsFinished(quiz) {
+		return ErrQuizNotFinished
+	}
+
+	if at.Awarded {
+		return ErrQuizAlreadyAwarded
+	}
+
+	var visit *Slot
+	if len(a.Slots) > 0 {
+		var err error
+		visit, err = a.currentVisit(char.GroupName, at.StartedAt, rules)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, s := range a.QuizScores(quiz, at) {
+		if s.Points == 0 {
+			continue
+		}
+		if visit != nil && char.hasGradeBetween(a.Name, s.Skill, visit.Start, visit.End.Add(rules.AwardGracePeriod)) {
+			continue
+		}
+		if err := a.awardWithoutVisit(char, s.Skill, s.Points); err != nil {
+			return err
+		}
+	}
+
+	if visit != nil {
+		if err := visit.Complete(char.GroupName); err != nil {
+			return err
+		}
+		if err := char.completeSlot(visit.Start, a.Name); err != nil {
+			return err
+		}
+	}
+
+	at.Awarded = true
+
+	return nil
+}
@@ -0,0 +1,232 @@
+package sm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+func TestActivity_NewQuiz(t *testing.T) {
+	act, err := sm.NewActivity(
+		"ЦМР", "Центр молодёжной робототехники", nil, nil, nil, nil,
+		[]sm.SkillType{sm.Engineering}, 5, nil,
+	)
+	require.NoError(t, err)
+
+	t.Run("should default question skill to first activity skill", func(t *testing.T) {
+		q, err := sm.NewQuizQuestion("Сколько колёс у робота?", nil, []string{"4"}, sm.SkillType{}, time.Minute)
+		require.NoError(t, err)
+
+		quiz, err := act.NewQuiz([]sm.QuizQuestion{q})
+		require.NoError(t, err)
+		require.Equal(t, sm.Engineering, quiz.Questions[0].Skill)
+	})
+
+	t.Run("should reject skill of another activity", func(t *testing.T) {
+		q, err := sm.NewQuizQuestion("Сколько колёс у робота?", nil, []string{"4"}, sm.Researching, time.Minute)
+		require.NoError(t, err)
+
+		_, err = act.NewQuiz([]sm.QuizQuestion{q})
+		require.ErrorIs(t, err, sm.ErrCannotIncSkill)
+	})
+
+	t.Run("should require answer among options", func(t *testing.T) {
+		_, err := sm.NewQuizQuestion("Сколько колёс у робота?", []string{"2", "3"}, []string{"4"}, sm.Engineering, time.Minute)
+		require.Error(t, err)
+	})
+}
+
+func TestQuizAttempt_Answer(t *testing.T) {
+	rules := sm.DefaultEventRules()
+	now := time.Now().Truncate(time.Minute)
+
+	current := sm.MustNewSlot(now.Add(-10*time.Minute), now.Add(10*time.Minute))
+	act, err := sm.NewActivity(
+		"ЦМР", "Центр молодёжной робототехники", nil, nil, nil, nil,
+		[]sm.SkillType{sm.Engineering, sm.Researching}, 4,
+		[]*sm.Slot{current},
+	)
+	require.NoError(t, err)
+
+	questions := []sm.QuizQuestion{
+		{Text: "Вопрос 1", Answers: []string{"Ёж"}, Skill: sm.Engineering, Time: time.Minute},
+		{Text: "Вопрос 2", Answers: []string{"да"}, Skill: sm.Engineering, Time: time.Minute},
+		{Text: "Вопрос 3", Answers: []string{"нет"}, Skill: sm.Researching, Time: time.Minute},
+	}
+	quiz, err := act.NewQuiz(questions)
+	require.NoError(t, err)
+
+	t.Run("should start quiz during slot only", func(t *testing.T) {
+		_, err := act.StartQuiz(quiz, "СМ1-11Б", "testname", now, rules)
+		require.ErrorIs(t, err, sm.ErrGroupHasNotVisited)
+	})
+
+	t.Run("should score answers and award once", func(t *testing.T) {
+		require.NoError(t, current.Take("СМ1-12Б"))
+		char := sm.MustNewCharacter("СМ1-12Б", "testname", nil)
+
+		at, err := act.StartQuiz(quiz, char.GroupName, "testname", now, rules)
+		require.NoError(t, err)
+
+		answer, err := at.Answer(quiz, "  ЕЖ ", "testname", now.Add(30*time.Second))
+		require.NoError(t, err)
+		require.True(t, answer.Correct)
+
+		// Время на второй вопрос отсчитывается с первого ответа.
+		answer, err = at.Answer(quiz, "да", "othername", now.Add(2*time.Minute))
+		require.NoError(t, err)
+		require.True(t, answer.TimedOut)
+		require.False(t, answer.Correct)
+
+		require.ErrorIs(t, act.AwardQuiz(char, quiz, at, rules), sm.ErrQuizNotFinished)
+
+		_, err = at.Answer(quiz, "нет", "testname", now.Add(2*time.Minute))
+		require.NoError(t, err)
+		require.True(t, at.IsFinished(quiz))
+
+		_, err = at.Answer(quiz, "нет", "testname", now.Add(2*time.Minute))
+		require.ErrorIs(t, err, sm.ErrQuizAlreadyFinished)
+
+		require.Equal(t, []sm.QuizScore{
+			{Skill: sm.Engineering, Correct: 1, Total: 2, Points: 2},
+			{Skill: sm.Researching, Correct: 1, Total: 1, Points: 4},
+		}, act.QuizScores(quiz, at))
+
+		require.NoError(t, act.AwardQuiz(char, quiz, at, rules))
+		require.Equal(t, 2, char.Skills()[sm.Engineering])
+		require.Equal(t, 4, char.Skills()[sm.Researching])
+
+		require.ErrorIs(t, act.AwardQuiz(char, quiz, at, rules), sm.ErrQuizAlreadyAwarded)
+	})
+}
+
+func TestActivity_QuizScores(t *testing.T) {
+	act, err := sm.NewActivity(
+		"ЦМР", "Центр молодёжной робототехники", nil, nil, nil, nil,
+		[]sm.SkillType{sm.Engineering, sm.Researching}, 5, nil,
+	)
+	require.NoError(t, err)
+
+	quiz, err := act.NewQuiz([]sm.QuizQuestion{
+		{Text: "Вопрос 1", Answers: []string{"1"}, Skill: sm.Engineering, Time: time.Minute},
+		{Text: "Вопрос 2", Answers: []string{"2"}, Skill: sm.Engineering, Time: time.Minute},
+		{Text: "Вопрос 3", Answers: []string{"3"}, Skill: sm.Engineering, Time: time.Minute},
+		{Text: "Вопрос 4", Answers: []string{"4"}, Skill: sm.Researching, Time: time.Minute},
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	at, err := act.StartQuiz(quiz, "СМ1-11Б", "testname", now, sm.DefaultEventRules())
+	require.NoError(t, err)
+
+	t.Run("should count unanswered questions as wrong", func(t *testing.T) {
+		require.Equal(t, []sm.QuizScore{
+			{Skill: sm.Engineering, Correct: 0, Total: 3, Points: 0},
+			{Skill: sm.Researching, Correct: 0, Total: 1, Points: 0},
+		}, act.QuizScores(quiz, at))
+	})
+
+	t.Run("should round points down", func(t *testing.T) {
+		for _, answer := range []string{"1", "2", "нет", "нет"} {
+			_, err := at.Answer(quiz, answer, "testname", now)
+			require.NoError(t, err)
+		}
+
+		require.Equal(t, []sm.QuizScore{
+			{Skill: sm.Engineering, Correct: 2, Total: 3, Points: 3},
+			{Skill: sm.Researching, Correct: 0, Total: 1, Points: 0},
+		}, act.QuizScores(quiz, at))
+	})
+}
+
+func TestActivity_AwardQuiz(t *testing.T) {
+	rules := sm.DefaultEventRules()
+	now := time.Now().Truncate(time.Minute)
+
+	questions := []sm.QuizQuestion{
+		{Text: "Вопрос 1", Answers: []string{"да"}, Skill: sm.Engineering, Time: time.Minute},
+		{Text: "Вопрос 2", Answers: []string{"нет"}, Skill: sm.Researching, Time: time.Minute},
+	}
+
+	newActivity := func(t *testing.T, slots []*sm.Slot) (*sm.Activity, *sm.Quiz) {
+		act, err := sm.NewActivity(
+			"ЦМР", "Центр молодёжной робототехники", nil, nil, nil, nil,
+			[]sm.SkillType{sm.Engineering, sm.Researching}, 4, slots,
+		)
+		require.NoError(t, err)
+
+		quiz, err := act.NewQuiz(questions)
+		require.NoError(t, err)
+
+		return act, quiz
+	}
+
+	finish := func(t *testing.T, quiz *sm.Quiz, at *sm.QuizAttempt, answeredAt time.Time) {
+		for _, answer := range []string{"да", "нет"} {
+			_, err := at.Answer(quiz, answer, "testname", answeredAt)
+			require.NoError(t, err)
+		}
+	}
+
+	t.Run("should award quiz finished after slot end", func(t *testing.T) {
+		past := sm.MustNewSlot(now.Add(-2*time.Hour), now.Add(-2*time.Hour+rules.SlotDuration))
+		act, quiz := newActivity(t, []*sm.Slot{past})
+		char := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+		require.NoError(t, past.Take(char.GroupName))
+
+		startedAt := past.End.Add(-time.Minute)
+		at, err := act.StartQuiz(quiz, char.GroupName, "testname", startedAt, rules)
+		require.NoError(t, err)
+		finish(t, quiz, at, startedAt.Add(30*time.Second))
+
+		require.NoError(t, act.AwardQuiz(char, quiz, at, rules))
+		require.True(t, at.Awarded)
+		require.Equal(t, 4, char.Skills()[sm.Engineering])
+		require.Equal(t, 4, char.Skills()[sm.Researching])
+	})
+
+	t.Run("should skip skill already awarded during visit", func(t *testing.T) {
+		current := sm.MustNewSlot(now.Add(-10*time.Minute), now.Add(10*time.Minute))
+		act, quiz := newActivity(t, []*sm.Slot{current})
+		char := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+		require.NoError(t, current.Take(char.GroupName))
+
+		require.NoError(t, act.Award(char, sm.Engineering, 2, rules))
+
+		at, err := act.StartQuiz(quiz, char.GroupName, "testname", now, rules)
+		require.NoError(t, err)
+		finish(t, quiz, at, now)
+
+		require.NoError(t, act.AwardQuiz(char, quiz, at, rules))
+		require.Equal(t, 2, char.Skills()[sm.Engineering])
+		require.Equal(t, 4, char.Skills()[sm.Researching])
+	})
+
+	t.Run("should award activity without slots", func(t *testing.T) {
+		act, quiz := newActivity(t, nil)
+		char := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+
+		at, err := act.StartQuiz(quiz, char.GroupName, "testname", now, rules)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, act.AwardQuiz(char, quiz, at, rules), sm.ErrQuizNotFinished)
+
+		finish(t, quiz, at, now)
+		require.NoError(t, act.AwardQuiz(char, quiz, at, rules))
+		require.ErrorIs(t, act.AwardQuiz(char, quiz, at, rules), sm.ErrQuizAlreadyAwarded)
+	})
+
+	t.Run("should reject attempt of another group", func(t *testing.T) {
+		act, quiz := newActivity(t, nil)
+		char := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+
+		at, err := act.StartQuiz(quiz, "СМ1-12Б", "othername", now, rules)
+		require.NoError(t, err)
+		finish(t, quiz, at, now)
+
+		require.ErrorIs(t, act.AwardQuiz(char, quiz, at, rules), sm.ErrQuizNotStarted)
+	})
+}
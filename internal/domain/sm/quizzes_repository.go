@@ -0,0 +1,25 @@
+package sm
+
+import (
+	"context"
+)
+
+type QuizzesRepository interface {
+	Save(ctx context.Context, eventID string, quiz *Quiz) error
+	Quiz(ctx context.Context, eventID string, activityName string) (*Quiz, error)
+	Quizzes(ctx context.Context, eventID string) ([]*Quiz, error)
+	SaveAttempt(ctx context.Context, eventID string, attempt *QuizAttempt) error
+	Attempt(ctx context.Context, eventID string, activityName string, groupName string) (*QuizAttempt, error)
+	Attempts(ctx context.Context, eventID string, activityName string) ([]*QuizAttempt, error)
+	UpdateAttempt(
+		ctx context.Context,
+		eventID string,
+		activityName string,
+		groupName string,
+		updateFn func(innerCtx context.Context, attempt *QuizAttempt) error,
+	) error
+}
+
+type QuizzesProvider interface {
+	Quizzes(ctx context.Context) ([]*Quiz, error)
+}
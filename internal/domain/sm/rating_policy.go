@@ -0,0 +1,233 @@
+package sm
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+)
+
+// RatingPolicy считает рейтинг группы и объясняет, из чего он сложился.
+type RatingPolicy interface {
+	Rate(c *Character) RatingBreakdown
+}
+
+// RatingBreakdown — расчёт рейтинга группы по шагам.
+type RatingBreakdown struct {
+	Skills []SkillScore
+	// Сумма основных навыков с учётом весов.
+	Base float64
+	// Множитель за дополнительные навыки.
+	Factor float64
+	// Надбавка за равномерное развитие основных навыков.
+	BalanceBonus float64
+	// Сумма штрафов, вычитаемая из рейтинга.
+	Penalty float64
+	Total   float64
+}
+
+type SkillScore struct {
+	Skill SkillType
+	// Набранные баллы и баллы, учтённые с учётом потолка.
+	Points  int
+	Counted int
+	Weight  float64
+}
+
+// SkillRating задаёт вес навыка в рейтинге и потолок учитываемых баллов.
+// Нулевой потолок означает, что баллы учитываются без ограничений.
+type SkillRating struct {
+	Skill  SkillType
+	Weight float64
+	Cap    int
+}
+
+// SkillsRatingPolicy считает рейтинг как сумму основных навыков, умноженную на
+// (1 + lambda × сумма дополнительных навыков), с надбавкой за баланс, равной
+// balanceBonus × наименьший из основных навыков, за вычетом штрафов.
+type SkillsRatingPolicy struct {
+	catalogue    SkillCatalogue
+	lambda       float64
+	skills       map[SkillType]SkillRating
+	balanceBonus float64
+}
+
+func NewSkillsRatingPolicy(
+	catalogue SkillCatalogue,
+	lambda float64,
+	skills []SkillRating,
+	balanceBonus float64,
+) (*SkillsRatingPolicy, error) {
+	if catalogue.IsZero() {
+		return nil, commonerrs.NewInvalidInputError("expected not empty skill catalogue")
+	}
+
+	if lambda < 0 {
+		return nil, commonerrs.NewInvalidInputError("expected non-negative rating lambda")
+	}
+
+	if balanceBonus < 0 {
+		return nil, commonerrs.NewInvalidInputError("expected non-negative balance bonus")
+	}
+
+	p := &SkillsRatingPolicy{
+		catalogue:    catalogue,
+		lambda:       lambda,
+		skills:       make(map[SkillType]SkillRating, len(catalogue.skills)),
+		balanceBonus: balanceBonus,
+	}
+	for _, skill := range catalogue.Types() {
+		p.skills[skill] = SkillRating{Skill: skill, Weight: 1}
+	}
+
+	for _, s := range skills {
+		if s.Skill.IsZero() {
+			return nil, commonerrs.NewInvalidInputError("expected not empty skill")
+		}
+
+		if _, ok := catalogue.Skill(s.Skill); !ok {
+			return nil, commonerrs.NewInvalidInputErrorf("skill %q is not in skill catalogue", s.Skill.String())
+		}
+
+		if s.Weight < 0 {
+			return nil, commonerrs.NewInvalidInputErrorf("expected non-negative weight of skill %q", s.Skill.String())
+		}
+
+		if s.Cap < 0 {
+			return nil, commonerrs.NewInvalidInputErrorf("expected non-negative cap of skill %q", s.Skill.String())
+		}
+
+		p.skills[s.Skill] = s
+	}
+
+	return p, nil
+}
+
+// DefaultRatingPolicy возвращает исходную формулу рейтинга для каталога навыков
+// по умолчанию: все веса равны единице, потолков и надбавки за баланс нет.
+func DefaultRatingPolicy(rules EventRules) *SkillsRatingPolicy {
+	p, err := NewSkillsRatingPolicy(DefaultSkillCatalogue(), rules.RatingLambda, nil, 0)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func (p *SkillsRatingPolicy) Rate(c *Character) RatingBreakdown {
+	points := c.Skills()
+
+	b := RatingBreakdown{
+		Skills: make([]SkillScore, 0, len(p.catalogue.skills)),
+		Factor: 1,
+	}
+
+	minGeneral := -1
+	for _, skill := range p.catalogue.Types() {
+		sr := p.skills[skill]
+		counted := points[skill]
+		if sr.Cap > 0 {
+			counted = min(counted, sr.Cap)
+		}
+		b.Skills = append(b.Skills, SkillScore{
+			Skill:   skill,
+			Points:  points[skill],
+			Counted: counted,
+			Weight:  sr.Weight,
+		})
+
+		if p.catalogue.IsGeneral(skill) {
+			b.Base += sr.Weight * float64(counted)
+			if minGeneral < 0 || counted < minGeneral {
+				minGeneral = counted
+			}
+		} else {
+			b.Factor += p.lambda * sr.Weight * float64(counted)
+		}
+	}
+
+	b.BalanceBonus = p.balanceBonus * float64(max(minGeneral, 0))
+	b.Penalty = float64(c.PenaltyPoints())
+	b.Total = b.Base*b.Factor + b.BalanceBonus - b.Penalty
+
+	return b
+}
+
+func (p *SkillsRatingPolicy) Lambda() float64 {
+	return p.lambda
+}
+
+func (p *SkillsRatingPolicy) BalanceBonus() float64 {
+	return p.balanceBonus
+}
+
+// SkillRatings возвращает настройки навыков в порядке каталога.
+func (p *SkillsRatingPolicy) SkillRatings() []SkillRating {
+	res := make([]SkillRating, 0, len(p.catalogue.skills))
+	for _, skill := range p.catalogue.Types() {
+		res = append(res, p.skills[skill])
+	}
+	return res
+}
+
+// CompareRating задаёт порядок групп в рейтинге: сначала по итоговому рейтингу,
+// затем по сумме основных навыков, затем выше та группа, которая раньше получила
+// последнюю оценку, и наконец по названию группы.
+func CompareRating(a *Character, b *Character, policy RatingPolicy) int {
+	ra, rb := policy.Rate(a), policy.Rate(b)
+
+	if c := compareFloats(rb.Total, ra.Total); c != 0 {
+		return c
+	}
+
+	if c := compareFloats(rb.Base, ra.Base); c != 0 {
+		return c
+	}
+
+	la, lb := a.lastGradeTime(), b.lastGradeTime()
+	switch {
+	case !la.IsZero() && !lb.IsZero() && !la.Equal(lb):
+		if la.Before(lb) {
+			return -1
+		}
+		return 1
+	case la.IsZero() != lb.IsZero():
+		// Группа с оценками выше группы без оценок.
+		if lb.IsZero() {
+			return -1
+		}
+		return 1
+	}
+
+	return strings.Compare(a.GroupName, b.GroupName)
+}
+
+// SortByRating упорядочивает группы по убыванию рейтинга.
+func SortByRating(chars []*Character, policy RatingPolicy) {
+	slices.SortStableFunc(chars, func(a, b *Character) int {
+		return CompareRating(a, b, policy)
+	})
+}
+
+// compareFloats сравнивает рейтинги с точностью, достаточной, чтобы ошибки
+// округления не влияли на порядок.
+func compareFloats(a float64, b float64) int {
+	const eps = 1e-9
+	switch {
+	case a < b-eps:
+		return -1
+	case a > b+eps:
+		return 1
+	}
+	return 0
+}
+
+func (c *Character) lastGradeTime() time.Time {
+	var last time.Time
+	for _, g := range c.Grades {
+		if !g.IsRevoked() && g.Time.After(last) {
+			last = g.Time
+		}
+	}
+	return last
+}
@@ -0,0 +1,60 @@
+package sm_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+func TestSkillsRatingPolicy(t *testing.T) {
+	char := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+	require.NoError(t, char.GiveGrade(sm.Engineering, 4, "ЦМР"))
+	require.NoError(t, char.GiveGrade(sm.Researching, 2, "НОЦ"))
+	require.NoError(t, char.GiveGrade(sm.Social, 3, "Студсовет"))
+	require.NoError(t, char.GiveGrade(sm.Sportive, 36, "Спорткомплекс"))
+
+	t.Run("should keep the original formula by default", func(t *testing.T) {
+		b := sm.DefaultRatingPolicy(sm.DefaultEventRules()).Rate(char)
+		require.Equal(t, 9.0, b.Base)
+		require.InDelta(t, 1.5, b.Factor, 1e-9)
+		require.InDelta(t, 13.5, b.Total, 1e-9)
+	})
+
+	t.Run("should apply weights, caps and balance bonus", func(t *testing.T) {
+		policy, err := sm.NewSkillsRatingPolicy(sm.DefaultSkillCatalogue(), 1.0/72, []sm.SkillRating{
+			{Skill: sm.Engineering, Weight: 2},
+			{Skill: sm.Sportive, Weight: 1, Cap: 18},
+		}, 0.5)
+		require.NoError(t, err)
+
+		b := policy.Rate(char)
+		require.Equal(t, 13.0, b.Base)
+		require.InDelta(t, 1.25, b.Factor, 1e-9)
+		require.Equal(t, 1.0, b.BalanceBonus)
+		require.InDelta(t, 17.25, b.Total, 1e-9)
+	})
+
+	t.Run("should reject negative weight", func(t *testing.T) {
+		_, err := sm.NewSkillsRatingPolicy(sm.DefaultSkillCatalogue(), 0, []sm.SkillRating{{Skill: sm.Social, Weight: -1}}, 0)
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+}
+
+func TestSortByRating(t *testing.T) {
+	policy := sm.DefaultRatingPolicy(sm.DefaultEventRules())
+
+	first := sm.MustNewCharacter("СМ1-13Б", "first", nil)
+	require.NoError(t, first.GiveGrade(sm.Engineering, 3, "ЦМР"))
+	second := sm.MustNewCharacter("СМ1-12Б", "second", nil)
+	require.NoError(t, second.GiveGrade(sm.Engineering, 3, "ЦМР"))
+	empty := sm.MustNewCharacter("СМ1-11Б", "empty", nil)
+	alsoEmpty := sm.MustNewCharacter("СМ1-10Б", "also-empty", nil)
+
+	chars := []*sm.Character{alsoEmpty, empty, second, first}
+	sm.SortByRating(chars, policy)
+
+	require.Equal(t, []*sm.Character{first, second, alsoEmpty, empty}, chars)
+}
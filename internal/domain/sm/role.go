@@ -9,6 +9,8 @@ type Role struct {
 var (
 	Participant   = Role{s: "participant"}
 	Administrator = Role{s: "administrator"}
+	// Organizer управляет всем мероприятием: всеми точками и группами.
+	Organizer = Role{s: "organizer"}
 )
 
 func NewRoleFromString(s string) (Role, error) {
@@ -17,9 +19,11 @@ func NewRoleFromString(s string) (Role, error) {
 		return Participant, nil
 	case "administrator":
 		return Administrator, nil
+	case "organizer":
+		return Organizer, nil
 	}
 	return Role{}, commonerrs.NewInvalidInputErrorf(
-		"invalid user role: %s; expected one of ['participant', 'administrator']", s,
+		"invalid user role: %s; expected one of ['participant', 'administrator', 'organizer']", s,
 	)
 }
 
@@ -0,0 +1,264 @@
+package sm
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+)
+
+var ErrSecretCodeNotFound = errors.New("secret code not found")
+var ErrSecretCodeAlreadyExists = errors.New("secret code already exists")
+var ErrSecretCodeExhausted = errors.New("secret code redemption limit reached")
+var ErrSecretCodeAlreadyRedeemed = errors.New("secret code already redeemed by group")
+var ErrTooManyCodeAttempts = errors.New("too many wrong secret codes")
+
+const (
+	MinSecretCodeLength = 4
+	MaxSecretCodeLength = 32
+
+	// Длина и алфавит сгенерированных кодов: без похожих друг на друга символов.
+	generatedSecretCodeLength   = 8
+	generatedSecretCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+// SecretCode — секретный код задания. Группа, которая ввела код в боте, получает
+// настроенные баллы за активность без участия администратора.
+type SecretCode struct {
+	Code         string
+	ActivityName string
+	Skill        SkillType
+	Points       int
+	// Сколько раз код можно ввести всего. Ноль снимает ограничение, у
+	// одноразового кода — единица.
+	MaxRedemptions int
+	// Сколько раз одна группа может ввести код.
+	MaxGroupRedemptions int
+	CreatedBy           string
+	Redemptions         []CodeRedemption
+}
+
+type CodeRedemption struct {
+	GroupName string
+	Username  string
+	Time      time.Time
+}
+
+func NewSecretCode(
+	code string,
+	activityName string,
+	skill SkillType,
+	points int,
+	maxRedemptions int,
+	maxGroupRedemptions int,
+	createdBy string,
+) (*SecretCode, error) {
+	code = NormalizeSecretCode(code)
+	if err := validateSecretCode(code); err != nil {
+		return nil, err
+	}
+
+	if activityName == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty activity name")
+	}
+
+	if skill.IsZero() {
+		return nil, commonerrs.NewInvalidInputError("expected not empty skill")
+	}
+
+	if points <= 0 {
+		return nil, commonerrs.NewInvalidInputError("expected positive number of points")
+	}
+
+	if maxRedemptions < 0 {
+		return nil, commonerrs.NewInvalidInputError("expected non-negative max redemptions")
+	}
+
+	if maxGroupRedemptions <= 0 {
+		return nil, commonerrs.NewInvalidInputError("expected positive max group redemptions")
+	}
+
+	if createdBy == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty username")
+	}
+
+	return &SecretCode{
+		Code:                code,
+		ActivityName:        activityName,
+		Skill:               skill,
+		Points:              points,
+		MaxRedemptions:      maxRedemptions,
+		MaxGroupRedemptions: maxGroupRedemptions,
+		CreatedBy:           createdBy,
+		Redemptions:         make([]CodeRedemption, 0),
+	}, nil
+}
+
+func UnmarshallSecretCodeFromDB(
+	code string,
+	activityName string,
+	skillStr string,
+	points int,
+	maxRedemptions int,
+	maxGroupRedemptions int,
+	createdBy string,
+	redemptions []CodeRedemption,
+) (*SecretCode, error) {
+	skill, err := NewSkillTypeFromString(skillStr)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := NewSecretCode(code, activityName, skill, points, maxRedemptions, maxGroupRedemptions, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if redemptions != nil {
+		c.Redemptions = redemptions
+	}
+
+	return c, nil
+}
+
+// NormalizeSecretCode приводит введённый код к виду, в котором он хранится:
+// регистр и пробелы по краям не важны.
+func NormalizeSecretCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// GenerateSecretCode возвращает случайный код, который легко продиктовать.
+func GenerateSecretCode() string {
+	alphabetSize := big.NewInt(int64(len(generatedSecretCodeAlphabet)))
+
+	var sb strings.Builder
+	for i := 0; i < generatedSecretCodeLength; i++ {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			panic(err)
+		}
+		sb.WriteByte(generatedSecretCodeAlphabet[n.Int64()])
+	}
+	return sb.String()
+}
+
+func validateSecretCode(code string) error {
+	if n := len([]rune(code)); n < MinSecretCodeLength || n > MaxSecretCodeLength {
+		return commonerrs.NewInvalidInputErrorf(
+			"expected secret code of %d to %d characters", MinSecretCodeLength, MaxSecretCodeLength,
+		)
+	}
+
+	for _, r := range code {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' {
+			return commonerrs.NewInvalidInputError("expected secret code of letters, digits and hyphens")
+		}
+	}
+
+	return nil
+}
+
+// CanBeRedeemedBy проверяет ограничения кода на число вводов.
+func (c *SecretCode) CanBeRedeemedBy(groupName string) error {
+	if c.MaxRedemptions > 0 && len(c.Redemptions) >= c.MaxRedemptions {
+		return ErrSecretCodeExhausted
+	}
+
+	if c.GroupRedemptions(groupName) >= c.MaxGroupRedemptions {
+		return ErrSecretCodeAlreadyRedeemed
+	}
+
+	return nil
+}
+
+func (c *SecretCode) GroupRedemptions(groupName string) int {
+	i := 0
+	for _, r := range c.Redemptions {
+		if r.GroupName == groupName {
+			i++
+		}
+	}
+	return i
+}
+
+// IssueSecretCode создаёт код, за который активность начисляет points баллов в
+// навык skill. Код подчиняется тем же ограничениям, что и начисление вручную.
+func (a *Activity) IssueSecretCode(
+	code string,
+	skill SkillType,
+	points int,
+	maxRedemptions int,
+	maxGroupRedemptions int,
+	createdBy string,
+) (*SecretCode, error) {
+	if err := a.checkAward(skill, points); err != nil {
+		return nil, err
+	}
+
+	return NewSecretCode(code, a.Name, skill, points, maxRedemptions, maxGroupRedemptions, createdBy)
+}
+
+// RedeemCode начисляет группе баллы по секретному коду через Award и учитывает
+// ввод кода.
+func (a *Activity) RedeemCode(char *Character, code *SecretCode, username string, rules EventRules) error {
+	if code.ActivityName != a.Name {
+		return ErrSecretCodeNotFound
+	}
+
+	if username == "" {
+		return commonerrs.NewInvalidInputError("expected not empty username")
+	}
+
+	if err := code.CanBeRedeemedBy(char.GroupName); err != nil {
+		return err
+	}
+
+	if err := a.Award(char, code.Skill, code.Points, rules); err != nil {
+		return err
+	}
+
+	code.Redemptions = append(code.Redemptions, CodeRedemption{
+		GroupName: char.GroupName,
+		Username:  username,
+		Time:      time.Now(),
+	})
+
+	return nil
+}
+
+// CanEnterCode защищает от перебора кодов: после MaxCodeAttempts неверных кодов
+// за CodeLockout ввод блокируется до тех пор, пока старые попытки не истекут.
+func (c *Character) CanEnterCode(now time.Time, rules EventRules) error {
+	if len(c.recentCodeAttempts(now, rules)) >= rules.MaxCodeAttempts {
+		return ErrTooManyCodeAttempts
+	}
+	return nil
+}
+
+// CodeLockedUntil возвращает, до какого времени группа не может вводить коды.
+func (c *Character) CodeLockedUntil(now time.Time, rules EventRules) (time.Time, bool) {
+	recent := c.recentCodeAttempts(now, rules)
+	if len(recent) < rules.MaxCodeAttempts {
+		return time.Time{}, false
+	}
+	return recent[len(recent)-rules.MaxCodeAttempts].Add(rules.CodeLockout), true
+}
+
+// FailCodeAttempt учитывает неверный код. Попытки старше CodeLockout забываются.
+func (c *Character) FailCodeAttempt(now time.Time, rules EventRules) {
+	c.CodeAttempts = append(c.recentCodeAttempts(now, rules), now)
+}
+
+func (c *Character) recentCodeAttempts(now time.Time, rules EventRules) []time.Time {
+	res := make([]time.Time, 0, len(c.CodeAttempts))
+	for _, t := range c.CodeAttempts {
+		if now.Sub(t) < rules.CodeLockout {
+			res = append(res, t)
+		}
+	}
+	return res
+}
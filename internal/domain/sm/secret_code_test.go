@@ -0,0 +1,83 @@
+package sm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+func TestActivity_RedeemCode(t *testing.T) {
+	rules := sm.DefaultEventRules()
+
+	description := "Найди код в лаборатории"
+	act, err := sm.NewActivity(
+		"Квест", "Квест по кампусу", &description, nil, nil, nil,
+		[]sm.SkillType{sm.Researching}, 5, nil,
+	)
+	require.NoError(t, err)
+
+	t.Run("should check award limits on issue", func(t *testing.T) {
+		_, err := act.IssueSecretCode("LAB-42", sm.Researching, 6, 1, 1, "organizer")
+		require.ErrorIs(t, err, sm.ErrMaxPointsExceeded)
+
+		_, err = act.IssueSecretCode("LAB-42", sm.Engineering, 3, 1, 1, "organizer")
+		require.ErrorIs(t, err, sm.ErrCannotIncSkill)
+
+		_, err = act.IssueSecretCode("LAB 42", sm.Researching, 3, 1, 1, "organizer")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should redeem one-time code once", func(t *testing.T) {
+		code, err := act.IssueSecretCode(" lab-42 ", sm.Researching, 3, 1, 1, "organizer")
+		require.NoError(t, err)
+		require.Equal(t, "LAB-42", code.Code)
+
+		first := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+		require.NoError(t, act.RedeemCode(first, code, "testname", rules))
+		require.Equal(t, 3, first.Skills()[sm.Researching])
+
+		second := sm.MustNewCharacter("СМ1-12Б", "othername", nil)
+		require.ErrorIs(t, act.RedeemCode(second, code, "othername", rules), sm.ErrSecretCodeExhausted)
+		require.Empty(t, second.Grades)
+	})
+
+	t.Run("should limit redemptions per group", func(t *testing.T) {
+		code, err := act.IssueSecretCode(sm.GenerateSecretCode(), sm.Researching, 2, 0, 1, "organizer")
+		require.NoError(t, err)
+
+		first := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+		second := sm.MustNewCharacter("СМ1-12Б", "othername", nil)
+		require.NoError(t, act.RedeemCode(first, code, "testname", rules))
+		require.NoError(t, act.RedeemCode(second, code, "othername", rules))
+		require.ErrorIs(t, act.RedeemCode(first, code, "testname", rules), sm.ErrSecretCodeAlreadyRedeemed)
+		require.Len(t, code.Redemptions, 2)
+	})
+}
+
+func TestCharacter_CanEnterCode(t *testing.T) {
+	rules := sm.DefaultEventRules()
+	rules.MaxCodeAttempts = 3
+	rules.CodeLockout = 10 * time.Minute
+	now := time.Now()
+
+	char := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+	// Старая попытка не учитывается и забывается.
+	char.FailCodeAttempt(now.Add(-time.Hour), rules)
+	for i := 0; i < rules.MaxCodeAttempts; i++ {
+		require.NoError(t, char.CanEnterCode(now, rules))
+		char.FailCodeAttempt(now.Add(time.Duration(i)*time.Minute), rules)
+	}
+	require.Len(t, char.CodeAttempts, rules.MaxCodeAttempts)
+
+	later := now.Add(2 * time.Minute)
+	require.ErrorIs(t, char.CanEnterCode(later, rules), sm.ErrTooManyCodeAttempts)
+	until, locked := char.CodeLockedUntil(later, rules)
+	require.True(t, locked)
+	require.Equal(t, now.Add(rules.CodeLockout), until)
+
+	require.NoError(t, char.CanEnterCode(until, rules))
+}
@@ -0,0 +1,16 @@
+package sm
+
+import (
+	"context"
+)
+
+type SecretCodesRepository interface {
+	Save(ctx context.Context, eventID string, code *SecretCode) error
+	SecretCodesByActivity(ctx context.Context, eventID string, activityName string) ([]*SecretCode, error)
+	Update(
+		ctx context.Context,
+		eventID string,
+		code string,
+		updateFn func(innerCtx context.Context, code *SecretCode) error,
+	) error
+}
@@ -0,0 +1,181 @@
+package sm
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+)
+
+var ErrSkillCatalogueNotFound = errors.New("skill catalogue not found")
+
+// SkillCategory — основные навыки составляют базу рейтинга, дополнительные
+// дают к ней множитель.
+type SkillCategory struct {
+	s string
+}
+
+var (
+	GeneralCategory    = SkillCategory{s: "general"}
+	AdditionalCategory = SkillCategory{s: "additional"}
+)
+
+func NewSkillCategoryFromString(s string) (SkillCategory, error) {
+	switch s {
+	case "general":
+		return GeneralCategory, nil
+	case "additional":
+		return AdditionalCategory, nil
+	}
+	return SkillCategory{}, commonerrs.NewInvalidInputErrorf(
+		"invalid skill category: %s; expected one of ['general', 'additional']", s,
+	)
+}
+
+func (c SkillCategory) String() string {
+	return c.s
+}
+
+func (c SkillCategory) IsZero() bool {
+	return c == SkillCategory{}
+}
+
+type Skill struct {
+	Type     SkillType
+	Emoji    string
+	Category SkillCategory
+	// Порядок вывода навыка в профиле и списках.
+	Order int
+}
+
+func NewSkill(name string, emoji string, category SkillCategory, order int) (Skill, error) {
+	st, err := NewSkillTypeFromString(name)
+	if err != nil {
+		return Skill{}, err
+	}
+
+	if category.IsZero() {
+		return Skill{}, commonerrs.NewInvalidInputError("expected not empty skill category")
+	}
+
+	return Skill{
+		Type:     st,
+		Emoji:    emoji,
+		Category: category,
+		Order:    order,
+	}, nil
+}
+
+func UnmarshallSkillFromDB(name string, emoji string, category string, order int) (Skill, error) {
+	c, err := NewSkillCategoryFromString(category)
+	if err != nil {
+		return Skill{}, err
+	}
+	return NewSkill(name, emoji, c, order)
+}
+
+func (s Skill) IsGeneral() bool {
+	return s.Category == GeneralCategory
+}
+
+// SkillCatalogue — навыки мероприятия в порядке вывода.
+type SkillCatalogue struct {
+	skills []Skill
+}
+
+func NewSkillCatalogue(skills []Skill) (SkillCatalogue, error) {
+	if len(skills) == 0 {
+		return SkillCatalogue{}, commonerrs.NewInvalidInputError("expected at least one skill")
+	}
+
+	names := make(map[SkillType]bool, len(skills))
+	general := false
+	for _, s := range skills {
+		if s.Type.IsZero() {
+			return SkillCatalogue{}, commonerrs.NewInvalidInputError("expected not empty skill")
+		}
+		if names[s.Type] {
+			return SkillCatalogue{}, commonerrs.NewInvalidInputErrorf("duplicate skill %q", s.Type.String())
+		}
+		names[s.Type] = true
+		general = general || s.IsGeneral()
+	}
+
+	if !general {
+		return SkillCatalogue{}, commonerrs.NewInvalidInputError("expected at least one general skill")
+	}
+
+	sorted := slices.Clone(skills)
+	slices.SortStableFunc(sorted, func(a, b Skill) int {
+		return a.Order - b.Order
+	})
+
+	return SkillCatalogue{skills: sorted}, nil
+}
+
+func MustNewSkillCatalogue(skills []Skill) SkillCatalogue {
+	c, err := NewSkillCatalogue(skills)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// DefaultSkillCatalogue возвращает навыки, с которыми Инструкция проводилась изначально.
+func DefaultSkillCatalogue() SkillCatalogue {
+	return MustNewSkillCatalogue([]Skill{
+		{Type: Engineering, Emoji: "🛠", Category: GeneralCategory, Order: 1},
+		{Type: Researching, Emoji: "🔭", Category: GeneralCategory, Order: 2},
+		{Type: Social, Emoji: "🤝", Category: GeneralCategory, Order: 3},
+		{Type: Creative, Emoji: "🔮", Category: AdditionalCategory, Order: 4},
+		{Type: Sportive, Emoji: "⚽️", Category: AdditionalCategory, Order: 5},
+	})
+}
+
+func (c SkillCatalogue) IsZero() bool {
+	return len(c.skills) == 0
+}
+
+func (c SkillCatalogue) Skills() []Skill {
+	return slices.Clone(c.skills)
+}
+
+func (c SkillCatalogue) Types() []SkillType {
+	res := make([]SkillType, len(c.skills))
+	for i, s := range c.skills {
+		res[i] = s.Type
+	}
+	return res
+}
+
+func (c SkillCatalogue) Skill(st SkillType) (Skill, bool) {
+	for _, s := range c.skills {
+		if s.Type == st {
+			return s, true
+		}
+	}
+	return Skill{}, false
+}
+
+func (c SkillCatalogue) IsGeneral(st SkillType) bool {
+	s, ok := c.Skill(st)
+	return ok && s.IsGeneral()
+}
+
+// Parse возвращает навык каталога по названию.
+func (c SkillCatalogue) Parse(name string) (SkillType, error) {
+	for _, s := range c.skills {
+		if s.Type.String() == name {
+			return s.Type, nil
+		}
+	}
+
+	names := make([]string, len(c.skills))
+	for i, s := range c.skills {
+		names[i] = s.Type.String()
+	}
+	return SkillType{}, commonerrs.NewInvalidInputErrorf(
+		"invalid skill type %s, expected one of [%s]", name, strings.Join(names, ", "),
+	)
+}
@@ -0,0 +1,74 @@
+package sm_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+func TestNewSkillCatalogue(t *testing.T) {
+	t.Run("should order skills and parse names", func(t *testing.T) {
+		catalogue, err := sm.NewSkillCatalogue([]sm.Skill{
+			mustNewSkill(t, "Кулинарные", "🍳", sm.AdditionalCategory, 2),
+			mustNewSkill(t, "Инженерные", "🛠", sm.GeneralCategory, 1),
+		})
+		require.NoError(t, err)
+
+		types := catalogue.Types()
+		require.Equal(t, []string{"Инженерные", "Кулинарные"}, []string{types[0].String(), types[1].String()})
+		require.True(t, catalogue.IsGeneral(types[0]))
+		require.False(t, catalogue.IsGeneral(types[1]))
+
+		st, err := catalogue.Parse("Кулинарные")
+		require.NoError(t, err)
+		require.Equal(t, types[1], st)
+
+		_, err = catalogue.Parse("Спортивные")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should reject duplicates", func(t *testing.T) {
+		_, err := sm.NewSkillCatalogue([]sm.Skill{
+			mustNewSkill(t, "Инженерные", "🛠", sm.GeneralCategory, 1),
+			mustNewSkill(t, "Инженерные", "⚙️", sm.GeneralCategory, 2),
+		})
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should require general skill", func(t *testing.T) {
+		_, err := sm.NewSkillCatalogue([]sm.Skill{
+			mustNewSkill(t, "Кулинарные", "🍳", sm.AdditionalCategory, 1),
+		})
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+	})
+
+	t.Run("should rate by catalogue", func(t *testing.T) {
+		catalogue := sm.MustNewSkillCatalogue([]sm.Skill{
+			mustNewSkill(t, "Инженерные", "🛠", sm.GeneralCategory, 1),
+			mustNewSkill(t, "Кулинарные", "🍳", sm.AdditionalCategory, 2),
+		})
+		cooking, err := catalogue.Parse("Кулинарные")
+		require.NoError(t, err)
+
+		policy, err := sm.NewSkillsRatingPolicy(catalogue, 0.5, nil, 0)
+		require.NoError(t, err)
+
+		char := sm.MustNewCharacter("СМ1-11Б", "testname", nil)
+		require.NoError(t, char.GiveGrade(sm.Engineering, 4, "ЦМР"))
+		require.NoError(t, char.GiveGrade(cooking, 2, "Столовая"))
+
+		b := policy.Rate(char)
+		require.Len(t, b.Skills, 2)
+		require.Equal(t, 4.0, b.Base)
+		require.InDelta(t, 8.0, b.Total, 1e-9)
+	})
+}
+
+func mustNewSkill(t *testing.T, name string, emoji string, category sm.SkillCategory, order int) sm.Skill {
+	s, err := sm.NewSkill(name, emoji, category, order)
+	require.NoError(t, err)
+	return s
+}
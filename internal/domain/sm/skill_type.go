@@ -1,15 +1,16 @@
 package sm
 
 import (
-	"fmt"
-
 	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
 )
 
+// SkillType — навык из каталога мероприятия. Допустимые навыки определяет
+// SkillCatalogue события.
 type SkillType struct {
 	s string
 }
 
+// Навыки каталога по умолчанию.
 var (
 	Engineering = SkillType{s: "Инженерные"}
 	Researching = SkillType{s: "Исследовательские"}
@@ -18,16 +19,6 @@ var (
 	Sportive    = SkillType{s: "Спортивные"}
 )
 
-var GeneralSkill = []SkillType{
-	Engineering, Researching, Social,
-}
-
-var AdditionalSkill = []SkillType{
-	Creative, Sportive,
-}
-
-var AllSkills = append(GeneralSkill, AdditionalSkill...)
-
 func (s SkillType) String() string {
 	return s.s
 }
@@ -37,19 +28,8 @@ func (s SkillType) IsZero() bool {
 }
 
 func NewSkillTypeFromString(s string) (SkillType, error) {
-	switch s {
-	case "Инженерные":
-		return Engineering, nil
-	case "Исследовательские":
-		return Researching, nil
-	case "Социальные":
-		return Social, nil
-	case "Творческие":
-		return Creative, nil
-	case "Спортивные":
-		return Sportive, nil
+	if s == "" {
+		return SkillType{}, commonerrs.NewInvalidInputError("expected not empty skill type")
 	}
-	return SkillType{}, commonerrs.NewInvalidInputError(
-		fmt.Sprintf("invalid skill type %s, expected one of [%v]", s, AllSkills),
-	)
+	return SkillType{s: s}, nil
 }
@@ -2,6 +2,7 @@ package sm
 
 import (
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
@@ -12,12 +13,28 @@ const TimeFormat = "15:04"
 type Slot struct {
 	Start time.Time
 	End   time.Time
-	Whom  *string
+	// Capacity — сколько групп одновременно может занять слот.
+	Capacity int
+	Bookings []*Booking
+}
+
+// Booking — бронь слота одной группой (или точкой, если слот принадлежит группе).
+type Booking struct {
+	Whom   string
+	Status SlotStatus
 }
 
 func NewSlot(
 	start time.Time,
 	end time.Time,
+) (*Slot, error) {
+	return NewSlotWithCapacity(start, end, 1)
+}
+
+func NewSlotWithCapacity(
+	start time.Time,
+	end time.Time,
+	capacity int,
 ) (*Slot, error) {
 	if start.IsZero() {
 		return nil, commonerrs.NewInvalidInputError("expected not zero start time")
@@ -39,10 +56,15 @@ func NewSlot(
 		return nil, commonerrs.NewInvalidInputError("start time must be before end time")
 	}
 
+	if capacity <= 0 {
+		return nil, commonerrs.NewInvalidInputError("expected positive slot capacity")
+	}
+
 	return &Slot{
-		Start: start,
-		End:   end,
-		Whom:  nil,
+		Start:    start,
+		End:      end,
+		Capacity: capacity,
+		Bookings: make([]*Booking, 0),
 	}, nil
 }
 
@@ -60,7 +82,8 @@ func MustNewSlot(
 func UnmarshallSlotFromDB(
 	start time.Time,
 	end time.Time,
-	whom *string,
+	capacity int,
+	bookings []*Booking,
 ) (*Slot, error) {
 	if start.IsZero() {
 		return nil, commonerrs.NewInvalidInputError("expected not zero start time")
@@ -70,45 +93,201 @@ func UnmarshallSlotFromDB(
 		return nil, commonerrs.NewInvalidInputError("expected not zero end time")
 	}
 
-	if whom != nil && *whom == "" {
-		return nil, commonerrs.NewInvalidInputError("expected not zero whom or nil")
+	if capacity <= 0 {
+		return nil, commonerrs.NewInvalidInputError("expected positive slot capacity")
+	}
+
+	if bookings == nil {
+		bookings = make([]*Booking, 0)
+	}
+
+	if len(bookings) > capacity {
+		return nil, commonerrs.NewInvalidInputErrorf("slot has %d bookings, capacity is %d", len(bookings), capacity)
 	}
 
 	return &Slot{
-		Start: start,
-		End:   end,
-		Whom:  whom,
+		Start:    start,
+		End:      end,
+		Capacity: capacity,
+		Bookings: bookings,
 	}, nil
 }
 
+func UnmarshallBookingFromDB(whom string, statusStr string) (*Booking, error) {
+	if whom == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty whom")
+	}
+
+	status, err := NewSlotStatusFromString(statusStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == SlotFree {
+		return nil, commonerrs.NewInvalidInputErrorf("booking can not have status %q", status.String())
+	}
+
+	return &Booking{
+		Whom:   whom,
+		Status: status,
+	}, nil
+}
+
+// IsAvailable сообщает, что в слоте есть свободные места.
 func (s *Slot) IsAvailable() bool {
-	return s.Whom == nil
+	return len(s.Bookings) < s.Capacity
+}
+
+func (s *Slot) IsEmpty() bool {
+	return len(s.Bookings) == 0
+}
+
+func (s *Slot) IsTakenBy(whom string) bool {
+	_, ok := s.Booking(whom)
+	return ok
+}
+
+func (s *Slot) Booking(whom string) (*Booking, bool) {
+	for _, b := range s.Bookings {
+		if b.Whom == whom {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+// StatusOf возвращает статус брони whom или SlotFree, если брони нет.
+func (s *Slot) StatusOf(whom string) SlotStatus {
+	if b, ok := s.Booking(whom); ok {
+		return b.Status
+	}
+	return SlotFree
 }
 
 var ErrSlotHasAlreadyTaken = errors.New("slot has already taken")
 
 func (s *Slot) Take(whom string) error {
-	if !s.IsAvailable() {
+	if s.IsTakenBy(whom) || !s.IsAvailable() {
 		return ErrSlotHasAlreadyTaken
 	}
 
-	s.Whom = &whom
+	s.Bookings = append(s.Bookings, &Booking{
+		Whom:   whom,
+		Status: SlotBooked,
+	})
 
 	return nil
 }
 
 var ErrSlotHasNotTaken = errors.New("slot has not taken")
+var ErrSlotAlreadyVisited = errors.New("slot already visited")
+
+var ErrInvalidSlotTransition = errors.New("invalid slot status transition")
+
+// CheckIn отмечает прибытие группы на точку.
+func (s *Slot) CheckIn(whom string) error {
+	return s.transition(whom, SlotCheckedIn, SlotBooked, SlotNoShow)
+}
+
+// MarkNoShow отмечает, что группа не пришла на забронированный слот.
+func (s *Slot) MarkNoShow(whom string) error {
+	return s.transition(whom, SlotNoShow, SlotBooked)
+}
 
-func (s *Slot) Free() error {
-	if s.IsAvailable() {
-		return ErrSlotHasNotTaken
+// Complete отмечает завершённое посещение. Повторное завершение не является ошибкой.
+func (s *Slot) Complete(whom string) error {
+	return s.transition(whom, SlotCompleted, SlotBooked, SlotCheckedIn, SlotCompleted)
+}
+
+// Release снимает бронь группы, которая не пришла на слот.
+func (s *Slot) Release(whom string) error {
+	if err := s.transition(whom, SlotNoShow, SlotBooked, SlotNoShow); err != nil {
+		return err
+	}
+
+	return s.Free(whom)
+}
+
+// IsOverdue сообщает, что whom так и не отметился спустя timeout после начала слота.
+func (s *Slot) IsOverdue(whom string, now time.Time, timeout time.Duration) bool {
+	b, ok := s.Booking(whom)
+	if !ok {
+		return false
+	}
+	return b.isOverdue(s.Start, now, timeout)
+}
+
+// Overdue возвращает брони, владельцы которых так и не отметились спустя timeout.
+func (s *Slot) Overdue(now time.Time, timeout time.Duration) []*Booking {
+	res := make([]*Booking, 0)
+	for _, b := range s.Bookings {
+		if b.isOverdue(s.Start, now, timeout) {
+			res = append(res, b)
+		}
+	}
+	return res
+}
+
+func (s *Slot) transition(whom string, to SlotStatus, from ...SlotStatus) error {
+	b, err := s.bookingOf(whom)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(from, b.Status) {
+		return ErrInvalidSlotTransition
+	}
+
+	b.Status = to
+
+	return nil
+}
+
+var ErrSlotTakenByAnother = errors.New("slot taken by another")
+
+// Free снимает бронь whom, брони других групп остаются. Бронь группы, которая
+// уже пришла на точку, снять нельзя.
+func (s *Slot) Free(whom string) error {
+	b, err := s.bookingOf(whom)
+	if err != nil {
+		return err
+	}
+
+	if b.isVisited() {
+		return ErrSlotAlreadyVisited
 	}
 
-	s.Whom = nil
+	s.Bookings = slices.DeleteFunc(s.Bookings, func(b *Booking) bool {
+		return b.Whom == whom
+	})
 
 	return nil
 }
 
+func (s *Slot) bookingOf(whom string) (*Booking, error) {
+	if s.IsEmpty() {
+		return nil, ErrSlotHasNotTaken
+	}
+
+	b, ok := s.Booking(whom)
+	if !ok {
+		return nil, ErrSlotTakenByAnother
+	}
+
+	return b, nil
+}
+
+func (b *Booking) isVisited() bool {
+	return b.Status == SlotCheckedIn || b.Status == SlotCompleted
+}
+
+func (b *Booking) isOverdue(start time.Time, now time.Time, timeout time.Duration) bool {
+	if b.Status != SlotBooked && b.Status != SlotNoShow {
+		return false
+	}
+	return !now.Before(start.Add(timeout))
+}
+
 func SlotsIntersection(a, b []*Slot) []*Slot {
 	res := make([]*Slot, 0, max(len(a), len(b)))
 	for i := range a {
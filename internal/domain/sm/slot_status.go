@@ -0,0 +1,41 @@
+package sm
+
+import "github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+
+type SlotStatus struct {
+	s string
+}
+
+var (
+	SlotFree      = SlotStatus{s: "free"}
+	SlotBooked    = SlotStatus{s: "booked"}
+	SlotCheckedIn = SlotStatus{s: "checked_in"}
+	SlotNoShow    = SlotStatus{s: "no_show"}
+	SlotCompleted = SlotStatus{s: "completed"}
+)
+
+func NewSlotStatusFromString(s string) (SlotStatus, error) {
+	switch s {
+	case "free":
+		return SlotFree, nil
+	case "booked":
+		return SlotBooked, nil
+	case "checked_in":
+		return SlotCheckedIn, nil
+	case "no_show":
+		return SlotNoShow, nil
+	case "completed":
+		return SlotCompleted, nil
+	}
+	return SlotStatus{}, commonerrs.NewInvalidInputErrorf(
+		"invalid slot status: %s; expected one of ['free', 'booked', 'checked_in', 'no_show', 'completed']", s,
+	)
+}
+
+func (s SlotStatus) String() string {
+	return s.s
+}
+
+func (s SlotStatus) IsZero() bool {
+	return s.s == ""
+}
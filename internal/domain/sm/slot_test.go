@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
 	"github.com/zhikh23/sm-instruction/internal/domain/sm"
 )
 
@@ -23,15 +24,94 @@ func TestSlot_Take(t *testing.T) {
 	err = slot.Take("СМ2-12")
 	require.ErrorIs(t, err, sm.ErrSlotHasAlreadyTaken)
 
-	err = slot.Free()
+	err = slot.Free("СМ2-12")
+	require.ErrorIs(t, err, sm.ErrSlotTakenByAnother)
+
+	err = slot.Free("СМ1-11Б")
 	require.NoError(t, err)
 
 	require.True(t, slot.IsAvailable())
 
-	err = slot.Free()
+	err = slot.Free("СМ1-11Б")
 	require.ErrorIs(t, err, sm.ErrSlotHasNotTaken)
 }
 
 func todayTime(hours int, minutes int) time.Time {
 	return time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), hours, minutes, 0, 0, time.Local)
 }
+
+func TestSlot_Capacity(t *testing.T) {
+	slot, err := sm.NewSlotWithCapacity(todayTime(11, 0), todayTime(11, 20), 2)
+	require.NoError(t, err)
+
+	require.NoError(t, slot.Take("СМ1-11Б"))
+	require.True(t, slot.IsAvailable())
+
+	err = slot.Take("СМ1-11Б")
+	require.ErrorIs(t, err, sm.ErrSlotHasAlreadyTaken)
+
+	require.NoError(t, slot.Take("СМ1-12Б"))
+	require.False(t, slot.IsAvailable())
+
+	err = slot.Take("СМ1-13Б")
+	require.ErrorIs(t, err, sm.ErrSlotHasAlreadyTaken)
+
+	require.NoError(t, slot.CheckIn("СМ1-12Б"))
+	require.Equal(t, sm.SlotBooked, slot.StatusOf("СМ1-11Б"))
+
+	require.NoError(t, slot.Free("СМ1-11Б"))
+	require.True(t, slot.IsAvailable())
+	require.False(t, slot.IsTakenBy("СМ1-11Б"))
+	require.True(t, slot.IsTakenBy("СМ1-12Б"))
+
+	err = slot.Free("СМ1-12Б")
+	require.ErrorIs(t, err, sm.ErrSlotAlreadyVisited)
+
+	_, err = sm.NewSlotWithCapacity(todayTime(11, 0), todayTime(11, 20), 0)
+	require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+}
+
+func TestSlot_Status(t *testing.T) {
+	slot := sm.MustNewSlot(todayTime(11, 0), todayTime(11, 20))
+	require.Equal(t, sm.SlotFree, slot.StatusOf("СМ1-11Б"))
+
+	err := slot.CheckIn("СМ1-11Б")
+	require.ErrorIs(t, err, sm.ErrSlotHasNotTaken)
+
+	require.NoError(t, slot.Take("СМ1-11Б"))
+	require.Equal(t, sm.SlotBooked, slot.StatusOf("СМ1-11Б"))
+
+	err = slot.CheckIn("СМ2-12")
+	require.ErrorIs(t, err, sm.ErrSlotTakenByAnother)
+
+	require.NoError(t, slot.MarkNoShow("СМ1-11Б"))
+	require.Equal(t, sm.SlotNoShow, slot.StatusOf("СМ1-11Б"))
+
+	err = slot.Complete("СМ1-11Б")
+	require.ErrorIs(t, err, sm.ErrInvalidSlotTransition)
+
+	require.NoError(t, slot.CheckIn("СМ1-11Б"))
+	require.Equal(t, sm.SlotCheckedIn, slot.StatusOf("СМ1-11Б"))
+
+	err = slot.Free("СМ1-11Б")
+	require.ErrorIs(t, err, sm.ErrSlotAlreadyVisited)
+
+	require.NoError(t, slot.Complete("СМ1-11Б"))
+	require.Equal(t, sm.SlotCompleted, slot.StatusOf("СМ1-11Б"))
+}
+
+func TestUnmarshallSlotFromDB(t *testing.T) {
+	_, err := sm.UnmarshallBookingFromDB("СМ1-11Б", "free")
+	require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+
+	booking, err := sm.UnmarshallBookingFromDB("СМ1-11Б", "checked_in")
+	require.NoError(t, err)
+
+	_, err = sm.UnmarshallSlotFromDB(todayTime(11, 0), todayTime(11, 20), 0, []*sm.Booking{booking})
+	require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+
+	slot, err := sm.UnmarshallSlotFromDB(todayTime(11, 0), todayTime(11, 20), 1, []*sm.Booking{booking})
+	require.NoError(t, err)
+	require.Equal(t, sm.SlotCheckedIn, slot.StatusOf("СМ1-11Б"))
+	require.False(t, slot.IsAvailable())
+}
@@ -0,0 +1,270 @@
+package sm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+)
+
+var ErrSubmissionNotFound = errors.New("submission not found")
+var ErrSubmissionAlreadyReviewed = errors.New("submission already reviewed")
+var ErrSubmissionAlreadyPending = errors.New("group already has pending submission")
+var ErrSubmissionsNotAccepted = errors.New("activity does not accept submissions")
+
+type ProofKind struct {
+	s string
+}
+
+var (
+	ProofText  = ProofKind{s: "text"}
+	ProofPhoto = ProofKind{s: "photo"}
+	ProofVideo = ProofKind{s: "video"}
+)
+
+func NewProofKindFromString(s string) (ProofKind, error) {
+	switch s {
+	case "text":
+		return ProofText, nil
+	case "photo":
+		return ProofPhoto, nil
+	case "video":
+		return ProofVideo, nil
+	}
+	return ProofKind{}, commonerrs.NewInvalidInputErrorf(
+		"invalid proof kind: %s; expected one of ['text', 'photo', 'video']", s,
+	)
+}
+
+func (k ProofKind) String() string {
+	return k.s
+}
+
+func (k ProofKind) IsZero() bool {
+	return k.s == ""
+}
+
+type SubmissionStatus struct {
+	s string
+}
+
+var (
+	SubmissionPending  = SubmissionStatus{s: "pending"}
+	SubmissionApproved = SubmissionStatus{s: "approved"}
+	SubmissionRejected = SubmissionStatus{s: "rejected"}
+)
+
+func NewSubmissionStatusFromString(s string) (SubmissionStatus, error) {
+	switch s {
+	case "pending":
+		return SubmissionPending, nil
+	case "approved":
+		return SubmissionApproved, nil
+	case "rejected":
+		return SubmissionRejected, nil
+	}
+	return SubmissionStatus{}, commonerrs.NewInvalidInputErrorf(
+		"invalid submission status: %s; expected one of ['pending', 'approved', 'rejected']", s,
+	)
+}
+
+func (s SubmissionStatus) String() string {
+	return s.s
+}
+
+func (s SubmissionStatus) IsZero() bool {
+	return s.s == ""
+}
+
+// Submission — решение дополнительного задания, которое группа отправила на
+// проверку администраторам активности.
+type Submission struct {
+	ID           string
+	ActivityName string
+	GroupName    string
+	Username     string
+	Kind         ProofKind
+	// Текст решения или подпись к фото и видео.
+	Text string
+	// Идентификатор файла в Telegram для фото и видео.
+	FileID      string
+	Status      SubmissionStatus
+	SubmittedAt time.Time
+	Review      *SubmissionReview
+}
+
+// SubmissionReview — результат проверки решения. Skill и Points заполнены
+// только у принятых решений, Reason — только у отклонённых.
+type SubmissionReview struct {
+	Username string
+	Skill    SkillType
+	Points   int
+	Reason   string
+	Time     time.Time
+}
+
+// Submit принимает решение группы. Решения принимаются только по
+// дополнительным заданиям, то есть активностям без места проведения.
+func (a *Activity) Submit(
+	groupName string,
+	username string,
+	kind ProofKind,
+	text string,
+	fileID string,
+) (*Submission, error) {
+	if a.Location != nil {
+		return nil, ErrSubmissionsNotAccepted
+	}
+
+	return NewSubmission(uuid.New().String(), a.Name, groupName, username, kind, text, fileID, time.Now())
+}
+
+func NewSubmission(
+	id string,
+	activityName string,
+	groupName string,
+	username string,
+	kind ProofKind,
+	text string,
+	fileID string,
+	submittedAt time.Time,
+) (*Submission, error) {
+	if id == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty submission id")
+	}
+
+	if activityName == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty activity name")
+	}
+
+	if groupName == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty group name")
+	}
+
+	if username == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty username")
+	}
+
+	if kind.IsZero() {
+		return nil, commonerrs.NewInvalidInputError("expected not empty proof kind")
+	}
+
+	if kind == ProofText && text == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty text of text proof")
+	}
+
+	if kind != ProofText && fileID == "" {
+		return nil, commonerrs.NewInvalidInputErrorf("expected not empty file id of %s proof", kind.String())
+	}
+
+	if submittedAt.IsZero() {
+		return nil, commonerrs.NewInvalidInputError("expected not empty submitted at")
+	}
+
+	return &Submission{
+		ID:           id,
+		ActivityName: activityName,
+		GroupName:    groupName,
+		Username:     username,
+		Kind:         kind,
+		Text:         text,
+		FileID:       fileID,
+		Status:       SubmissionPending,
+		SubmittedAt:  submittedAt,
+	}, nil
+}
+
+func UnmarshallSubmissionFromDB(
+	id string,
+	activityName string,
+	groupName string,
+	username string,
+	kindStr string,
+	text string,
+	fileID string,
+	statusStr string,
+	submittedAt time.Time,
+	review *SubmissionReview,
+) (*Submission, error) {
+	kind, err := NewProofKindFromString(kindStr)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := NewSubmissionStatusFromString(statusStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if (status == SubmissionPending) != (review == nil) {
+		return nil, commonerrs.NewInvalidInputError("expected review of reviewed submission only")
+	}
+
+	s, err := NewSubmission(id, activityName, groupName, username, kind, text, fileID, submittedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Status = status
+	s.Review = review
+
+	return s, nil
+}
+
+func (s *Submission) IsPending() bool {
+	return s.Status == SubmissionPending
+}
+
+// Approve принимает решение. Баллы начисляются отдельно, через Activity.Award.
+func (s *Submission) Approve(skill SkillType, points int, username string) error {
+	if username == "" {
+		return commonerrs.NewInvalidInputError("expected not empty username")
+	}
+
+	if skill.IsZero() {
+		return commonerrs.NewInvalidInputError("expected not empty skill")
+	}
+
+	if points <= 0 {
+		return commonerrs.NewInvalidInputError("expected positive number of points")
+	}
+
+	if !s.IsPending() {
+		return ErrSubmissionAlreadyReviewed
+	}
+
+	s.Status = SubmissionApproved
+	s.Review = &SubmissionReview{
+		Username: username,
+		Skill:    skill,
+		Points:   points,
+		Time:     time.Now(),
+	}
+
+	return nil
+}
+
+func (s *Submission) Reject(reason string, username string) error {
+	if username == "" {
+		return commonerrs.NewInvalidInputError("expected not empty username")
+	}
+
+	if reason == "" {
+		return commonerrs.NewInvalidInputError("expected not empty reason")
+	}
+
+	if !s.IsPending() {
+		return ErrSubmissionAlreadyReviewed
+	}
+
+	s.Status = SubmissionRejected
+	s.Review = &SubmissionReview{
+		Username: username,
+		Reason:   reason,
+		Time:     time.Now(),
+	}
+
+	return nil
+}
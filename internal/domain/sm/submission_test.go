@@ -0,0 +1,78 @@
+package sm_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+func TestActivity_Submit(t *testing.T) {
+	description := "Сними видео с талисманом"
+	location := "Лаборатория"
+
+	t.Run("should accept proofs for additional activities only", func(t *testing.T) {
+		act, err := sm.NewActivity(
+			"Лаба", "Лаборатория", &description, &location, nil, nil,
+			[]sm.SkillType{sm.Researching}, 5, nil,
+		)
+		require.NoError(t, err)
+
+		_, err = act.Submit("СМ1-11Б", "testname", sm.ProofText, "Готово", "")
+		require.ErrorIs(t, err, sm.ErrSubmissionsNotAccepted)
+	})
+
+	t.Run("should require content of proof", func(t *testing.T) {
+		act, err := sm.NewActivity(
+			"Талисман", "Видео с талисманом", &description, nil, nil, nil,
+			[]sm.SkillType{sm.Researching}, 5, nil,
+		)
+		require.NoError(t, err)
+
+		_, err = act.Submit("СМ1-11Б", "testname", sm.ProofText, "", "")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+
+		_, err = act.Submit("СМ1-11Б", "testname", sm.ProofVideo, "Наше видео", "")
+		require.ErrorAs(t, err, &commonerrs.InvalidInputError{})
+
+		s, err := act.Submit("СМ1-11Б", "testname", sm.ProofVideo, "", "file-id")
+		require.NoError(t, err)
+		require.True(t, s.IsPending())
+		require.Equal(t, "Талисман", s.ActivityName)
+	})
+}
+
+func TestSubmission_Review(t *testing.T) {
+	description := "Сними видео с талисманом"
+	act, err := sm.NewActivity(
+		"Талисман", "Видео с талисманом", &description, nil, nil, nil,
+		[]sm.SkillType{sm.Researching}, 5, nil,
+	)
+	require.NoError(t, err)
+
+	t.Run("should approve once", func(t *testing.T) {
+		s, err := act.Submit("СМ1-11Б", "testname", sm.ProofPhoto, "", "file-id")
+		require.NoError(t, err)
+
+		require.NoError(t, s.Approve(sm.Researching, 3, "admin"))
+		require.Equal(t, sm.SubmissionApproved, s.Status)
+		require.Equal(t, 3, s.Review.Points)
+
+		require.ErrorIs(t, s.Approve(sm.Researching, 3, "admin"), sm.ErrSubmissionAlreadyReviewed)
+		require.ErrorIs(t, s.Reject("Не то", "admin"), sm.ErrSubmissionAlreadyReviewed)
+	})
+
+	t.Run("should reject with reason", func(t *testing.T) {
+		s, err := act.Submit("СМ1-11Б", "testname", sm.ProofText, "Готово", "")
+		require.NoError(t, err)
+
+		require.ErrorAs(t, s.Reject("", "admin"), &commonerrs.InvalidInputError{})
+		require.True(t, s.IsPending())
+
+		require.NoError(t, s.Reject("Нет талисмана в кадре", "admin"))
+		require.Equal(t, sm.SubmissionRejected, s.Status)
+		require.Equal(t, "Нет талисмана в кадре", s.Review.Reason)
+	})
+}
@@ -0,0 +1,18 @@
+package sm
+
+import (
+	"context"
+)
+
+type SubmissionsRepository interface {
+	Save(ctx context.Context, eventID string, submission *Submission) error
+	// PendingSubmissions возвращает непроверенные решения по активности, начиная
+	// с самых старых.
+	PendingSubmissions(ctx context.Context, eventID string, activityName string) ([]*Submission, error)
+	Update(
+		ctx context.Context,
+		eventID string,
+		id string,
+		updateFn func(innerCtx context.Context, submission *Submission) error,
+	) error
+}
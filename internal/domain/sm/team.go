@@ -0,0 +1,152 @@
+package sm
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+)
+
+var ErrMemberAlreadyExists = errors.New("member already exists")
+var ErrMemberNotFound = errors.New("member not found")
+var ErrLastCaptain = errors.New("team must have at least one captain")
+var ErrInvalidInviteCode = errors.New("invalid invite code")
+var ErrAlreadyInAnotherTeam = errors.New("user already in another team")
+var ErrNotCaptain = errors.New("only captain can do this")
+var ErrUserIsNotParticipant = errors.New("user is not participant")
+
+// TeamRole — роль участника внутри группы. Капитаны бронируют точки,
+// остальные участники могут только смотреть.
+type TeamRole struct {
+	s string
+}
+
+var (
+	Captain    = TeamRole{s: "captain"}
+	TeamMember = TeamRole{s: "member"}
+)
+
+func NewTeamRoleFromString(s string) (TeamRole, error) {
+	switch s {
+	case "captain":
+		return Captain, nil
+	case "member":
+		return TeamMember, nil
+	}
+	return TeamRole{}, commonerrs.NewInvalidInputErrorf(
+		"invalid team role: %s; expected one of ['captain', 'member']", s,
+	)
+}
+
+func (r TeamRole) String() string {
+	return r.s
+}
+
+func (r TeamRole) IsZero() bool {
+	return r.s == ""
+}
+
+type Member struct {
+	Username string
+	Role     TeamRole
+}
+
+func NewMember(username string, role TeamRole) (Member, error) {
+	if username == "" {
+		return Member{}, commonerrs.NewInvalidInputError("expected not empty username")
+	}
+
+	if role.IsZero() {
+		return Member{}, commonerrs.NewInvalidInputError("expected not empty team role")
+	}
+
+	return Member{
+		Username: username,
+		Role:     role,
+	}, nil
+}
+
+func UnmarshallMemberFromDB(username string, role string) (Member, error) {
+	r, err := NewTeamRoleFromString(role)
+	if err != nil {
+		return Member{}, err
+	}
+
+	return NewMember(username, r)
+}
+
+func (c *Character) AddMember(username string, role TeamRole) error {
+	m, err := NewMember(username, role)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := c.Member(username); ok {
+		return ErrMemberAlreadyExists
+	}
+
+	c.Members = append(c.Members, m)
+
+	return nil
+}
+
+func (c *Character) RemoveMember(username string) error {
+	m, ok := c.Member(username)
+	if !ok {
+		return ErrMemberNotFound
+	}
+
+	if m.Role == Captain && len(c.Captains()) == 1 {
+		return ErrLastCaptain
+	}
+
+	c.Members = slices.DeleteFunc(c.Members, func(m Member) bool {
+		return m.Username == username
+	})
+
+	return nil
+}
+
+// JoinByInvite добавляет участника по коду из ссылки-приглашения. Повторное
+// вступление не является ошибкой.
+func (c *Character) JoinByInvite(username string, inviteCode string) error {
+	if inviteCode == "" || inviteCode != c.InviteCode {
+		return ErrInvalidInviteCode
+	}
+
+	if _, ok := c.Member(username); ok {
+		return nil
+	}
+
+	return c.AddMember(username, TeamMember)
+}
+
+func (c *Character) Member(username string) (Member, bool) {
+	for _, m := range c.Members {
+		if m.Username == username {
+			return m, true
+		}
+	}
+	return Member{}, false
+}
+
+func (c *Character) IsCaptain(username string) bool {
+	m, ok := c.Member(username)
+	return ok && m.Role == Captain
+}
+
+func (c *Character) Captains() []Member {
+	res := make([]Member, 0, 1)
+	for _, m := range c.Members {
+		if m.Role == Captain {
+			res = append(res, m)
+		}
+	}
+	return res
+}
+
+func newInviteCode() string {
+	return uuid.New().String()
+}
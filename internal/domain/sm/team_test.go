@@ -0,0 +1,41 @@
+package sm_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+func TestCharacter_Members(t *testing.T) {
+	t.Run("should make imported user a captain", func(t *testing.T) {
+		char := sm.MustNewCharacter("СМ1-11Б", "captain", nil)
+
+		require.True(t, char.IsCaptain("captain"))
+		require.NotEmpty(t, char.InviteCode)
+	})
+
+	t.Run("should join by invite code as member", func(t *testing.T) {
+		char := sm.MustNewCharacter("СМ1-11Б", "captain", nil)
+
+		require.ErrorIs(t, char.JoinByInvite("member", "wrong"), sm.ErrInvalidInviteCode)
+		require.NoError(t, char.JoinByInvite("member", char.InviteCode))
+		require.NoError(t, char.JoinByInvite("member", char.InviteCode))
+
+		require.Len(t, char.Members, 2)
+		m, ok := char.Member("member")
+		require.True(t, ok)
+		require.Equal(t, sm.TeamMember, m.Role)
+		require.False(t, char.IsCaptain("member"))
+	})
+
+	t.Run("should not remove last captain", func(t *testing.T) {
+		char := sm.MustNewCharacter("СМ1-11Б", "captain", nil)
+		require.NoError(t, char.AddMember("member", sm.TeamMember))
+
+		require.ErrorIs(t, char.RemoveMember("captain"), sm.ErrLastCaptain)
+		require.NoError(t, char.RemoveMember("member"))
+		require.ErrorIs(t, char.RemoveMember("member"), sm.ErrMemberNotFound)
+	})
+}
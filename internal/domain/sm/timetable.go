@@ -0,0 +1,187 @@
+package sm
+
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
+// TimetableAssignment — бронь, которую генератор расписания предлагает сделать.
+type TimetableAssignment struct {
+	GroupName    string
+	ActivityName string
+	Start        time.Time
+}
+
+// GroupConstraints — пожелания группы к расписанию.
+type GroupConstraints struct {
+	// Необязательный промежуток, в который должны попасть все брони группы.
+	NotBefore *time.Time
+	NotAfter  *time.Time
+	// Точки, на которые группу записывать нельзя.
+	ExcludedActivities []string
+}
+
+func (gc GroupConstraints) allows(activityName string, slot *Slot) bool {
+	if gc.NotBefore != nil && slot.Start.Before(*gc.NotBefore) {
+		return false
+	}
+	if gc.NotAfter != nil && slot.End.After(*gc.NotAfter) {
+		return false
+	}
+	return !slices.Contains(gc.ExcludedActivities, activityName)
+}
+
+// PlanTimetable распределяет группы по свободным слотам точек так, чтобы каждая
+// группа по возможности набрала MaxTakenSlots броней, а навыки покрывались равномерно.
+// Брони делаются прямо в переданных персонажах и точках: для пробного прогона
+// достаточно их не сохранять.
+//
+// Распределение жадное: на каждом шаге группа с наименьшим числом броней получает
+// слот на точке, навыки которой у неё пока развиты меньше всего. При равенстве
+// выбирается менее загруженная точка, затем более ранний слот. Точки без навыков
+// выбираются, только когда на точки с навыками записаться уже нельзя.
+//
+// Брони группы, ещё не начавшей Инструкцию, укладываются в InstructionDuration
+// от назначенной волны, а без волны — в InstructionDuration между первой и
+// последней бронью.
+func PlanTimetable(
+	chars []*Character,
+	activities []*Activity,
+	rules EventRules,
+	travel TravelTimes,
+	constraints map[string]GroupConstraints,
+) []TimetableAssignment {
+	zones := ActivitiesZones(activities)
+
+	byName := make(map[string]*Activity, len(activities))
+	load := make(map[string]int, len(activities))
+	for _, a := range activities {
+		byName[a.Name] = a
+		for _, slot := range a.Slots {
+			load[a.Name] += len(slot.Bookings)
+		}
+	}
+
+	coverage := make(map[string]map[SkillType]int, len(chars))
+	for _, char := range chars {
+		coverage[char.GroupName] = make(map[SkillType]int)
+		for _, slot := range char.Slots {
+			for _, b := range slot.Bookings {
+				if a, ok := byName[b.Whom]; ok {
+					for _, skill := range a.Skills {
+						coverage[char.GroupName][skill]++
+					}
+				}
+			}
+		}
+	}
+
+	order := slices.Clone(chars)
+	res := make([]TimetableAssignment, 0)
+	for {
+		sort.SliceStable(order, func(i, j int) bool {
+			if order[i].TakenSlots() != order[j].TakenSlots() {
+				return order[i].TakenSlots() < order[j].TakenSlots()
+			}
+			return order[i].GroupName < order[j].GroupName
+		})
+
+		progress := false
+		for _, char := range order {
+			if char.TakenSlots() >= rules.MaxTakenSlots {
+				continue
+			}
+
+			activity, slot, ok := bestTimetableSlot(
+				char, activities, rules, travel, zones, constraints[char.GroupName], coverage[char.GroupName], load,
+			)
+			if !ok {
+				continue
+			}
+
+			if err := activity.TakeSlot(slot.Start, char.GroupName); err != nil {
+				continue
+			}
+			if err := char.TakeSlot(slot.Start, activity.Name, rules); err != nil {
+				_ = activity.CancelSlot(slot.Start, char.GroupName)
+				continue
+			}
+
+			for _, skill := range activity.Skills {
+				coverage[char.GroupName][skill]++
+			}
+			load[activity.Name]++
+			res = append(res, TimetableAssignment{
+				GroupName:    char.GroupName,
+				ActivityName: activity.Name,
+				Start:        slot.Start,
+			})
+			progress = true
+		}
+
+		if !progress {
+			return res
+		}
+	}
+}
+
+func bestTimetableSlot(
+	char *Character,
+	activities []*Activity,
+	rules EventRules,
+	travel TravelTimes,
+	zones map[string]string,
+	gc GroupConstraints,
+	coverage map[SkillType]int,
+	load map[string]int,
+) (*Activity, *Slot, bool) {
+	var bestActivity *Activity
+	var bestSlot *Slot
+	bestScore, bestLoad, bestSkilled := 0, 0, false
+
+	for _, a := range activities {
+		if a.HasTaken(char.GroupName) {
+			continue
+		}
+
+		// Точки без навыков не выравнивают навыки группы, поэтому выбираются
+		// только если не осталось точек с навыками.
+		skilled := len(a.Skills) > 0
+		if bestSlot != nil && bestSkilled && !skilled {
+			continue
+		}
+
+		score := 0
+		for _, skill := range a.Skills {
+			score += coverage[skill]
+		}
+
+		for _, slot := range a.AvailableSlots() {
+			if !gc.allows(a.Name, slot) {
+				continue
+			}
+			if char.CanTakeSlotAt(slot.Start, rules) != nil {
+				continue
+			}
+			if char.CanFitInstructionWindow(slot.Start, rules) != nil {
+				continue
+			}
+			if char.CanReachSlotAt(slot.Start, a.Name, zones, travel) != nil {
+				continue
+			}
+
+			better := bestSlot == nil ||
+				skilled && !bestSkilled ||
+				score < bestScore ||
+				score == bestScore && load[a.Name] < bestLoad ||
+				score == bestScore && load[a.Name] == bestLoad && slot.Start.Before(bestSlot.Start)
+			if better {
+				bestActivity, bestSlot = a, slot
+				bestScore, bestLoad, bestSkilled = score, load[a.Name], skilled
+			}
+		}
+	}
+
+	return bestActivity, bestSlot, bestSlot != nil
+}
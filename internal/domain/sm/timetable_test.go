@@ -0,0 +1,149 @@
+package sm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+func TestPlanTimetable(t *testing.T) {
+	rules := sm.DefaultEventRules()
+	rules.MaxTakenSlots = 2
+	start := time.Now().Add(time.Hour).Truncate(time.Minute)
+
+	slots := func() []*sm.Slot {
+		return []*sm.Slot{
+			sm.MustNewSlot(start, start.Add(rules.SlotDuration)),
+			sm.MustNewSlot(start.Add(rules.SlotDuration), start.Add(2*rules.SlotDuration)),
+			sm.MustNewSlot(start.Add(2*rules.SlotDuration), start.Add(3*rules.SlotDuration)),
+		}
+	}
+
+	engineering, err := sm.NewActivity(
+		"ЦМР", "Центр молодёжной робототехники", nil, nil, nil, nil,
+		[]sm.SkillType{sm.Engineering}, 5, slots(),
+	)
+	require.NoError(t, err)
+	researching, err := sm.NewActivity(
+		"НОЦ", "Научно-образовательный центр", nil, nil, nil, nil,
+		[]sm.SkillType{sm.Researching}, 5, slots(),
+	)
+	require.NoError(t, err)
+	activities := []*sm.Activity{engineering, researching}
+
+	first := sm.MustNewCharacter("СМ1-11Б", "first", slots())
+	second := sm.MustNewCharacter("СМ1-12Б", "second", slots())
+	chars := []*sm.Character{first, second}
+
+	t.Run("should fill every group up to max taken slots with different skills", func(t *testing.T) {
+		notAfter := start.Add(2 * rules.SlotDuration)
+		plan := sm.PlanTimetable(chars, activities, rules, sm.TravelTimes{}, map[string]sm.GroupConstraints{
+			second.GroupName: {NotAfter: &notAfter},
+		})
+
+		require.Len(t, plan, 4)
+		for _, char := range chars {
+			require.Equal(t, rules.MaxTakenSlots, char.TakenSlots())
+			for _, act := range activities {
+				require.True(t, act.HasTaken(char.GroupName))
+			}
+		}
+
+		for _, a := range plan {
+			if a.GroupName == second.GroupName {
+				require.True(t, a.Start.Before(notAfter))
+			}
+		}
+	})
+
+	t.Run("should not assign anything when all groups are full", func(t *testing.T) {
+		plan := sm.PlanTimetable(chars, activities, rules, sm.TravelTimes{}, nil)
+		require.Empty(t, plan)
+	})
+}
+
+func TestPlanTimetable_InstructionWindow(t *testing.T) {
+	rules := sm.DefaultEventRules()
+	rules.MaxTakenSlots = 3
+	rules.InstructionDuration = rules.SlotDuration
+	start := time.Now().Add(time.Hour).Truncate(time.Minute)
+
+	slots := func() []*sm.Slot {
+		return []*sm.Slot{
+			sm.MustNewSlot(start, start.Add(rules.SlotDuration)),
+			sm.MustNewSlot(start.Add(rules.SlotDuration), start.Add(2*rules.SlotDuration)),
+			sm.MustNewSlot(start.Add(2*rules.SlotDuration), start.Add(3*rules.SlotDuration)),
+		}
+	}
+
+	activities := make([]*sm.Activity, 0, 3)
+	for _, name := range []string{"ЦМР", "НОЦ", "ЦКП"} {
+		a, err := sm.NewActivity(name, name, nil, nil, nil, nil, []sm.SkillType{sm.Engineering}, 5, slots())
+		require.NoError(t, err)
+		activities = append(activities, a)
+	}
+
+	scheduled := sm.MustNewCharacter("СМ1-11Б", "scheduled", slots())
+	require.NoError(t, scheduled.ScheduleStart(start.Add(rules.SlotDuration)))
+	unscheduled := sm.MustNewCharacter("СМ1-12Б", "unscheduled", slots())
+
+	plan := sm.PlanTimetable(
+		[]*sm.Character{scheduled, unscheduled}, activities, rules, sm.TravelTimes{}, nil,
+	)
+
+	starts := make(map[string][]time.Time)
+	for _, a := range plan {
+		starts[a.GroupName] = append(starts[a.GroupName], a.Start)
+	}
+
+	require.Len(t, starts[scheduled.GroupName], 2)
+	for _, s := range starts[scheduled.GroupName] {
+		require.False(t, s.Before(*scheduled.ScheduledStart))
+	}
+
+	require.Len(t, starts[unscheduled.GroupName], 2)
+	first, last := starts[unscheduled.GroupName][0], starts[unscheduled.GroupName][1]
+	if last.Before(first) {
+		first, last = last, first
+	}
+	require.LessOrEqual(t, last.Sub(first), rules.InstructionDuration)
+}
+
+func TestPlanTimetable_ActivitiesWithoutSkills(t *testing.T) {
+	rules := sm.DefaultEventRules()
+	rules.MaxTakenSlots = 2
+	start := time.Now().Add(time.Hour).Truncate(time.Minute)
+
+	slots := func() []*sm.Slot {
+		return []*sm.Slot{
+			sm.MustNewSlot(start, start.Add(rules.SlotDuration)),
+			sm.MustNewSlot(start.Add(rules.SlotDuration), start.Add(2*rules.SlotDuration)),
+		}
+	}
+
+	lunch, err := sm.NewActivity("Обед", "Обед", nil, nil, nil, nil, nil, 5, slots())
+	require.NoError(t, err)
+	engineering, err := sm.NewActivity(
+		"ЦМР", "Центр молодёжной робототехники", nil, nil, nil, nil,
+		[]sm.SkillType{sm.Engineering}, 5, slots(),
+	)
+	require.NoError(t, err)
+	researching, err := sm.NewActivity(
+		"НОЦ", "Научно-образовательный центр", nil, nil, nil, nil,
+		[]sm.SkillType{sm.Researching}, 5, slots(),
+	)
+	require.NoError(t, err)
+
+	char := sm.MustNewCharacter("СМ1-11Б", "testname", slots())
+	plan := sm.PlanTimetable(
+		[]*sm.Character{char}, []*sm.Activity{lunch, engineering, researching}, rules, sm.TravelTimes{}, nil,
+	)
+
+	require.Len(t, plan, 2)
+	require.False(t, lunch.HasTaken(char.GroupName))
+	require.True(t, engineering.HasTaken(char.GroupName))
+	require.True(t, researching.HasTaken(char.GroupName))
+}
@@ -0,0 +1,112 @@
+package sm
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+)
+
+var ErrSlotIsUnreachable = errors.New("slot is unreachable from neighbouring slots")
+
+// TravelTime — время на дорогу между двумя зонами кампуса. Дорога в обе стороны
+// занимает одинаковое время.
+type TravelTime struct {
+	From     string
+	To       string
+	Duration time.Duration
+}
+
+// TravelTimes — матрица времени на дорогу между зонами. Для неизвестных пар зон,
+// а также внутри одной зоны время на дорогу считается нулевым.
+type TravelTimes struct {
+	routes map[travelRoute]time.Duration
+}
+
+type travelRoute struct {
+	from string
+	to   string
+}
+
+func NewTravelTimes(travelTimes []TravelTime) (TravelTimes, error) {
+	routes := make(map[travelRoute]time.Duration, len(travelTimes))
+	for _, t := range travelTimes {
+		if t.From == "" || t.To == "" {
+			return TravelTimes{}, commonerrs.NewInvalidInputError("expected not empty zones")
+		}
+
+		if t.From == t.To {
+			return TravelTimes{}, commonerrs.NewInvalidInputErrorf("expected different zones, got %q twice", t.From)
+		}
+
+		if t.Duration <= 0 {
+			return TravelTimes{}, commonerrs.NewInvalidInputError("expected positive travel time")
+		}
+
+		if t.Duration.Truncate(time.Minute) != t.Duration {
+			return TravelTimes{}, commonerrs.NewInvalidInputError("travel time must be multiply of minute")
+		}
+
+		route := newTravelRoute(t.From, t.To)
+		if _, ok := routes[route]; ok {
+			return TravelTimes{}, commonerrs.NewInvalidInputErrorf("duplicate travel time between %q and %q", t.From, t.To)
+		}
+		routes[route] = t.Duration
+	}
+
+	return TravelTimes{routes: routes}, nil
+}
+
+func MustNewTravelTimes(travelTimes []TravelTime) TravelTimes {
+	t, err := NewTravelTimes(travelTimes)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func (t TravelTimes) IsZero() bool {
+	return len(t.routes) == 0
+}
+
+// Between возвращает время на дорогу из зоны from в зону to.
+func (t TravelTimes) Between(from string, to string) time.Duration {
+	if from == "" || to == "" || from == to {
+		return 0
+	}
+	return t.routes[newTravelRoute(from, to)]
+}
+
+// List возвращает все известные пары зон в детерминированном порядке.
+func (t TravelTimes) List() []TravelTime {
+	res := make([]TravelTime, 0, len(t.routes))
+	for route, d := range t.routes {
+		res = append(res, TravelTime{From: route.from, To: route.to, Duration: d})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].From != res[j].From {
+			return res[i].From < res[j].From
+		}
+		return res[i].To < res[j].To
+	})
+	return res
+}
+
+func newTravelRoute(from string, to string) travelRoute {
+	if to < from {
+		from, to = to, from
+	}
+	return travelRoute{from: from, to: to}
+}
+
+// ActivitiesZones возвращает зоны точек по их названиям. Точки без зоны пропускаются.
+func ActivitiesZones(activities []*Activity) map[string]string {
+	zones := make(map[string]string, len(activities))
+	for _, a := range activities {
+		if a.Zone != nil {
+			zones[a.Name] = *a.Zone
+		}
+	}
+	return zones
+}
@@ -7,6 +7,8 @@ import (
 type User struct {
 	Username string
 	Role     Role
+	// ChatID — идентификатор чата с ботом для уведомлений, 0 если пользователь ещё не писал боту.
+	ChatID int64
 }
 
 func (u User) IsZero() bool {
@@ -45,6 +47,7 @@ func MustNewUser(
 func UnmarshallUserFromDB(
 	username string,
 	role string,
+	chatID int64,
 ) (User, error) {
 	if username == "" {
 		return User{}, commonerrs.NewInvalidInputError("expected not empty username")
@@ -62,5 +65,16 @@ func UnmarshallUserFromDB(
 	return User{
 		Username: username,
 		Role:     r,
+		ChatID:   chatID,
 	}, nil
 }
+
+func (u *User) RegisterChat(chatID int64) error {
+	if chatID == 0 {
+		return commonerrs.NewInvalidInputError("expected not zero chat id")
+	}
+
+	u.ChatID = chatID
+
+	return nil
+}
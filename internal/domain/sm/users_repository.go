@@ -9,6 +9,12 @@ var ErrUserAlreadyExists = errors.New("user already exists")
 var ErrUserNotFound = errors.New("user not found")
 
 type UsersRepository interface {
-	Save(ctx context.Context, user User) error
-	User(ctx context.Context, username string) (User, error)
+	Save(ctx context.Context, eventID string, user User) error
+	User(ctx context.Context, eventID string, username string) (User, error)
+	Update(
+		ctx context.Context,
+		eventID string,
+		username string,
+		updateFn func(innerCtx context.Context, user *User) error,
+	) error
 }
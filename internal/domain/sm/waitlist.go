@@ -0,0 +1,300 @@
+package sm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+)
+
+var ErrAlreadyInWaitlist = errors.New("group already in waitlist")
+var ErrNotInWaitlist = errors.New("group not in waitlist")
+var ErrWaitlistOfferNotFound = errors.New("waitlist offer not found")
+var ErrWaitlistOfferExpired = errors.New("waitlist offer expired")
+var ErrActivityAlreadyTaken = errors.New("activity already taken by group")
+var ErrSlotIsOffered = errors.New("slot is offered to another group")
+
+// Waitlist — очередь групп, ожидающих освобождения слота на точке.
+type Waitlist struct {
+	ActivityName string
+	Entries      []*WaitlistEntry
+}
+
+type WaitlistEntry struct {
+	GroupName string
+	// Необязательный промежуток, в который группе удобно прийти.
+	From     *time.Time
+	To       *time.Time
+	JoinedAt time.Time
+	Offer    *WaitlistOffer
+}
+
+type WaitlistOffer struct {
+	Start     time.Time
+	OfferedAt time.Time
+	ExpiresAt time.Time
+}
+
+func NewWaitlist(activityName string) (*Waitlist, error) {
+	if activityName == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty activity name")
+	}
+
+	return &Waitlist{
+		ActivityName: activityName,
+		Entries:      make([]*WaitlistEntry, 0),
+	}, nil
+}
+
+func UnmarshallWaitlistFromDB(activityName string, entries []*WaitlistEntry) (*Waitlist, error) {
+	w, err := NewWaitlist(activityName)
+	if err != nil {
+		return nil, err
+	}
+
+	if entries != nil {
+		w.Entries = entries
+	}
+
+	return w, nil
+}
+
+func NewWaitlistEntry(groupName string, from *time.Time, to *time.Time, joinedAt time.Time) (*WaitlistEntry, error) {
+	if groupName == "" {
+		return nil, commonerrs.NewInvalidInputError("expected not empty group name")
+	}
+
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, commonerrs.NewInvalidInputError("expected waitlist range start before end")
+	}
+
+	if joinedAt.IsZero() {
+		return nil, commonerrs.NewInvalidInputError("expected not empty joined at")
+	}
+
+	return &WaitlistEntry{
+		GroupName: groupName,
+		From:      from,
+		To:        to,
+		JoinedAt:  joinedAt,
+	}, nil
+}
+
+func UnmarshallWaitlistEntryFromDB(
+	groupName string,
+	from *time.Time,
+	to *time.Time,
+	joinedAt time.Time,
+	offerStart *time.Time,
+	offeredAt *time.Time,
+	offerExpiresAt *time.Time,
+) (*WaitlistEntry, error) {
+	e, err := NewWaitlistEntry(groupName, from, to, joinedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if offerStart != nil {
+		if offeredAt == nil || offerExpiresAt == nil {
+			return nil, commonerrs.NewInvalidInputError("expected offered at and expiration time of waitlist offer")
+		}
+		e.Offer = &WaitlistOffer{
+			Start:     *offerStart,
+			OfferedAt: *offeredAt,
+			ExpiresAt: *offerExpiresAt,
+		}
+	}
+
+	return e, nil
+}
+
+func (w *Waitlist) Join(groupName string, from *time.Time, to *time.Time) error {
+	if _, ok := w.entry(groupName); ok {
+		return ErrAlreadyInWaitlist
+	}
+
+	e, err := NewWaitlistEntry(groupName, from, to, time.Now())
+	if err != nil {
+		return err
+	}
+
+	w.Entries = append(w.Entries, e)
+
+	return nil
+}
+
+func (w *Waitlist) Leave(groupName string) error {
+	for i, e := range w.Entries {
+		if e.GroupName == groupName {
+			w.Entries = append(w.Entries[:i], w.Entries[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotInWaitlist
+}
+
+func (w *Waitlist) Has(groupName string) bool {
+	_, ok := w.entry(groupName)
+	return ok
+}
+
+// Position возвращает место группы в очереди, начиная с единицы.
+func (w *Waitlist) Position(groupName string) (int, bool) {
+	for i, e := range w.Entries {
+		if e.GroupName == groupName {
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
+
+// IsOffered сообщает, что слот уже предложен какой-то группе и предложение ещё действует.
+func (w *Waitlist) IsOffered(start time.Time, now time.Time) bool {
+	for _, e := range w.Entries {
+		if e.HasOffer(now) && e.Offer.Start.Equal(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsHeldFor сообщает, что свободные места в слоте придержаны действующими
+// предложениями другим группам.
+func (w *Waitlist) IsHeldFor(slot *Slot, groupName string, now time.Time) bool {
+	held := 0
+	for _, e := range w.Entries {
+		if e.GroupName != groupName && e.HasOffer(now) && e.Offer.Start.Equal(slot.Start) {
+			held++
+		}
+	}
+	return held > 0 && slot.Capacity-len(slot.Bookings) <= held
+}
+
+// CanTakeSlot проверяет, что слот точки не придержан предложением другой группе.
+func (w *Waitlist) CanTakeSlot(activity *Activity, start time.Time, groupName string, now time.Time) error {
+	slot, ok := activity.slotByTime(start)
+	if !ok {
+		return ErrSlotNotFound
+	}
+
+	if w.IsHeldFor(slot, groupName, now) {
+		return ErrSlotIsOffered
+	}
+
+	return nil
+}
+
+// OfferSlot предлагает освободившийся слот первой подходящей группе из очереди.
+// Группа подходит, если слот попадает в её промежуток и eligible её пропускает.
+func (w *Waitlist) OfferSlot(
+	start time.Time,
+	now time.Time,
+	rules EventRules,
+	eligible func(groupName string) bool,
+) (*WaitlistEntry, bool) {
+	if w.IsOffered(start, now) {
+		return nil, false
+	}
+
+	for _, e := range w.Entries {
+		if e.HasOffer(now) || !e.Wants(start) || !eligible(e.GroupName) {
+			continue
+		}
+
+		e.Offer = &WaitlistOffer{
+			Start:     start,
+			OfferedAt: now,
+			ExpiresAt: now.Add(rules.WaitlistOfferTimeout),
+		}
+		return e, true
+	}
+
+	return nil, false
+}
+
+// ExpireOffers убирает из очереди группы, не ответившие на предложение вовремя,
+// и возвращает их названия.
+func (w *Waitlist) ExpireOffers(now time.Time) []string {
+	expired := make([]string, 0)
+	kept := make([]*WaitlistEntry, 0, len(w.Entries))
+	for _, e := range w.Entries {
+		if e.Offer != nil && !now.Before(e.Offer.ExpiresAt) {
+			expired = append(expired, e.GroupName)
+			continue
+		}
+		kept = append(kept, e)
+	}
+	w.Entries = kept
+	return expired
+}
+
+// ActiveOffer возвращает действующее предложение группе.
+func (w *Waitlist) ActiveOffer(groupName string, now time.Time) (WaitlistOffer, error) {
+	e, ok := w.entry(groupName)
+	if !ok {
+		return WaitlistOffer{}, ErrNotInWaitlist
+	}
+
+	if e.Offer == nil {
+		return WaitlistOffer{}, ErrWaitlistOfferNotFound
+	}
+
+	if !e.HasOffer(now) {
+		return WaitlistOffer{}, ErrWaitlistOfferExpired
+	}
+
+	return *e.Offer, nil
+}
+
+// WithdrawOffer отзывает предложение, оставляя группу в очереди.
+func (w *Waitlist) WithdrawOffer(groupName string) error {
+	e, ok := w.entry(groupName)
+	if !ok {
+		return ErrNotInWaitlist
+	}
+
+	if e.Offer == nil {
+		return ErrWaitlistOfferNotFound
+	}
+
+	e.Offer = nil
+
+	return nil
+}
+
+func (w *Waitlist) DeclineOffer(groupName string) error {
+	e, ok := w.entry(groupName)
+	if !ok {
+		return ErrNotInWaitlist
+	}
+
+	if e.Offer == nil {
+		return ErrWaitlistOfferNotFound
+	}
+
+	return w.Leave(groupName)
+}
+
+func (w *Waitlist) entry(groupName string) (*WaitlistEntry, bool) {
+	for _, e := range w.Entries {
+		if e.GroupName == groupName {
+			return e, true
+		}
+	}
+	return nil, false
+}
+
+// Wants сообщает, подходит ли группе слот, начинающийся в start.
+func (e *WaitlistEntry) Wants(start time.Time) bool {
+	if e.From != nil && start.Before(*e.From) {
+		return false
+	}
+	if e.To != nil && !start.Before(*e.To) {
+		return false
+	}
+	return true
+}
+
+func (e *WaitlistEntry) HasOffer(now time.Time) bool {
+	return e.Offer != nil && now.Before(e.Offer.ExpiresAt)
+}
@@ -0,0 +1,126 @@
+package sm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+func TestWaitlist_OfferSlot(t *testing.T) {
+	rules := sm.DefaultEventRules()
+	now := time.Now().Truncate(time.Minute)
+	start := now.Add(time.Hour)
+	anyone := func(string) bool { return true }
+
+	t.Run("should offer slot to first group in range", func(t *testing.T) {
+		w, err := sm.NewWaitlist("ЦМР")
+		require.NoError(t, err)
+
+		later := start.Add(time.Hour)
+		require.NoError(t, w.Join("СМ1-11Б", &later, nil))
+		require.NoError(t, w.Join("СМ1-12Б", nil, nil))
+		require.NoError(t, w.Join("СМ1-13Б", nil, nil))
+		require.ErrorIs(t, w.Join("СМ1-12Б", nil, nil), sm.ErrAlreadyInWaitlist)
+
+		e, ok := w.OfferSlot(start, now, rules, anyone)
+		require.True(t, ok)
+		require.Equal(t, "СМ1-12Б", e.GroupName)
+		require.Equal(t, now.Add(rules.WaitlistOfferTimeout), e.Offer.ExpiresAt)
+
+		_, ok = w.OfferSlot(start, now, rules, anyone)
+		require.False(t, ok, "slot is already offered")
+	})
+
+	t.Run("should skip ineligible groups", func(t *testing.T) {
+		w, err := sm.NewWaitlist("ЦМР")
+		require.NoError(t, err)
+		require.NoError(t, w.Join("СМ1-11Б", nil, nil))
+		require.NoError(t, w.Join("СМ1-12Б", nil, nil))
+
+		e, ok := w.OfferSlot(start, now, rules, func(groupName string) bool {
+			return groupName != "СМ1-11Б"
+		})
+		require.True(t, ok)
+		require.Equal(t, "СМ1-12Б", e.GroupName)
+	})
+
+	t.Run("should drop group after offer expired", func(t *testing.T) {
+		w, err := sm.NewWaitlist("ЦМР")
+		require.NoError(t, err)
+		require.NoError(t, w.Join("СМ1-11Б", nil, nil))
+		require.NoError(t, w.Join("СМ1-12Б", nil, nil))
+
+		_, ok := w.OfferSlot(start, now, rules, anyone)
+		require.True(t, ok)
+
+		expiredAt := now.Add(rules.WaitlistOfferTimeout)
+		_, err = w.ActiveOffer("СМ1-11Б", expiredAt)
+		require.ErrorIs(t, err, sm.ErrWaitlistOfferExpired)
+
+		require.Equal(t, []string{"СМ1-11Б"}, w.ExpireOffers(expiredAt))
+		require.False(t, w.Has("СМ1-11Б"))
+
+		e, ok := w.OfferSlot(start, expiredAt, rules, anyone)
+		require.True(t, ok)
+		require.Equal(t, "СМ1-12Б", e.GroupName)
+	})
+
+	t.Run("should keep group in waitlist after withdrawn offer", func(t *testing.T) {
+		w, err := sm.NewWaitlist("ЦМР")
+		require.NoError(t, err)
+		require.NoError(t, w.Join("СМ1-11Б", nil, nil))
+
+		_, err = w.ActiveOffer("СМ1-11Б", now)
+		require.ErrorIs(t, err, sm.ErrWaitlistOfferNotFound)
+
+		_, ok := w.OfferSlot(start, now, rules, anyone)
+		require.True(t, ok)
+
+		offer, err := w.ActiveOffer("СМ1-11Б", now)
+		require.NoError(t, err)
+		require.Equal(t, start, offer.Start)
+
+		require.NoError(t, w.WithdrawOffer("СМ1-11Б"))
+		require.True(t, w.Has("СМ1-11Б"))
+		require.Empty(t, w.ExpireOffers(now.Add(time.Hour)))
+	})
+}
+
+func TestWaitlist_IsHeldFor(t *testing.T) {
+	rules := sm.DefaultEventRules()
+	now := time.Now().Truncate(time.Minute)
+	start := now.Add(time.Hour)
+	anyone := func(string) bool { return true }
+
+	t.Run("should hold last free place for offered group", func(t *testing.T) {
+		slot := sm.MustNewSlot(start, start.Add(20*time.Minute))
+		w, err := sm.NewWaitlist("ЦМР")
+		require.NoError(t, err)
+		require.NoError(t, w.Join("СМ1-11Б", nil, nil))
+
+		_, ok := w.OfferSlot(start, now, rules, anyone)
+		require.True(t, ok)
+
+		require.True(t, w.IsHeldFor(slot, "СМ1-12Б", now))
+		require.False(t, w.IsHeldFor(slot, "СМ1-11Б", now))
+		require.False(t, w.IsHeldFor(slot, "СМ1-12Б", now.Add(rules.WaitlistOfferTimeout)))
+	})
+
+	t.Run("should not hold other free places", func(t *testing.T) {
+		slot, err := sm.NewSlotWithCapacity(start, start.Add(20*time.Minute), 2)
+		require.NoError(t, err)
+		w, err := sm.NewWaitlist("ЦМР")
+		require.NoError(t, err)
+		require.NoError(t, w.Join("СМ1-11Б", nil, nil))
+
+		_, ok := w.OfferSlot(start, now, rules, anyone)
+		require.True(t, ok)
+		require.False(t, w.IsHeldFor(slot, "СМ1-12Б", now))
+
+		require.NoError(t, slot.Take("СМ1-13Б"))
+		require.True(t, w.IsHeldFor(slot, "СМ1-12Б", now))
+	})
+}
@@ -0,0 +1,17 @@
+package sm
+
+import (
+	"context"
+)
+
+type WaitlistsRepository interface {
+	// Waitlist возвращает пустую очередь, если в неё ещё никто не вставал.
+	Waitlist(ctx context.Context, eventID string, activityName string) (*Waitlist, error)
+	Waitlists(ctx context.Context, eventID string) ([]*Waitlist, error)
+	Update(
+		ctx context.Context,
+		eventID string,
+		activityName string,
+		updateFn func(innerCtx context.Context, waitlist *Waitlist) error,
+	) error
+}
@@ -0,0 +1,164 @@
+package sm
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+)
+
+var ErrInstructionAlreadyPaused = errors.New("instruction already paused")
+var ErrInstructionNotPaused = errors.New("instruction not paused")
+
+type WindowAdjustmentKind struct {
+	s string
+}
+
+var (
+	WindowPaused    = WindowAdjustmentKind{s: "pause"}
+	WindowResumed   = WindowAdjustmentKind{s: "resume"}
+	WindowExtended  = WindowAdjustmentKind{s: "extend"}
+	WindowRestarted = WindowAdjustmentKind{s: "restart"}
+)
+
+func NewWindowAdjustmentKindFromString(s string) (WindowAdjustmentKind, error) {
+	switch s {
+	case "pause":
+		return WindowPaused, nil
+	case "resume":
+		return WindowResumed, nil
+	case "extend":
+		return WindowExtended, nil
+	case "restart":
+		return WindowRestarted, nil
+	}
+	return WindowAdjustmentKind{}, commonerrs.NewInvalidInputErrorf(
+		"invalid window adjustment kind: %s; expected one of ['pause', 'resume', 'extend', 'restart']", s,
+	)
+}
+
+func (k WindowAdjustmentKind) String() string {
+	return k.s
+}
+
+func (k WindowAdjustmentKind) IsZero() bool {
+	return k.s == ""
+}
+
+// WindowAdjustment — запись журнала изменений окна Инструкции группы.
+type WindowAdjustment struct {
+	ID   string
+	Kind WindowAdjustmentKind
+	// Продление окна или длительность снятой паузы. Для остальных изменений ноль.
+	Duration time.Duration
+	Username string
+	Time     time.Time
+}
+
+func UnmarshallWindowAdjustmentFromDB(
+	id string,
+	kind string,
+	duration time.Duration,
+	username string,
+	time time.Time,
+) (WindowAdjustment, error) {
+	if id == "" {
+		return WindowAdjustment{}, commonerrs.NewInvalidInputError("expected not empty window adjustment id")
+	}
+
+	k, err := NewWindowAdjustmentKindFromString(kind)
+	if err != nil {
+		return WindowAdjustment{}, err
+	}
+
+	if duration < 0 {
+		return WindowAdjustment{}, commonerrs.NewInvalidInputError("expected non-negative window adjustment duration")
+	}
+
+	if username == "" {
+		return WindowAdjustment{}, commonerrs.NewInvalidInputError("expected not empty username")
+	}
+
+	if time.IsZero() {
+		return WindowAdjustment{}, commonerrs.NewInvalidInputError("expected non empty time")
+	}
+
+	return WindowAdjustment{
+		ID:       id,
+		Kind:     k,
+		Duration: duration,
+		Username: username,
+		Time:     time,
+	}, nil
+}
+
+// Pause останавливает отсчёт окна Инструкции. Пока группа на паузе, конец окна
+// отодвигается вместе с текущим временем.
+func (c *Character) Pause(username string, now time.Time) error {
+	if username == "" {
+		return commonerrs.NewInvalidInputError("expected not empty username")
+	}
+
+	if !c.IsStarted() {
+		return ErrInstructionNotStarted
+	}
+
+	if c.IsPaused() {
+		return ErrInstructionAlreadyPaused
+	}
+
+	c.PausedAt = &now
+	c.logWindowAdjustment(WindowPaused, 0, username, now)
+
+	return nil
+}
+
+// Resume возобновляет отсчёт окна Инструкции. Длительность паузы добавляется к
+// окну группы с точностью до секунды, как она и хранится.
+func (c *Character) Resume(username string, now time.Time) error {
+	if username == "" {
+		return commonerrs.NewInvalidInputError("expected not empty username")
+	}
+
+	if !c.IsPaused() {
+		return ErrInstructionNotPaused
+	}
+
+	d := max(now.Sub(*c.PausedAt), 0).Truncate(time.Second)
+	c.Paused += d
+	c.PausedAt = nil
+	c.logWindowAdjustment(WindowResumed, d, username, now)
+
+	return nil
+}
+
+func (c *Character) IsPaused() bool {
+	return c.PausedAt != nil
+}
+
+// PausedFor возвращает, сколько окно Инструкции простояло на паузе к моменту now,
+// включая текущую паузу.
+func (c *Character) PausedFor(now time.Time) time.Duration {
+	d := c.Paused
+	if c.IsPaused() && now.After(*c.PausedAt) {
+		d += now.Sub(*c.PausedAt)
+	}
+	return d
+}
+
+func (c *Character) logWindowAdjustment(
+	kind WindowAdjustmentKind,
+	d time.Duration,
+	username string,
+	t time.Time,
+) {
+	c.WindowAdjustments = append(c.WindowAdjustments, WindowAdjustment{
+		ID:       uuid.New().String(),
+		Kind:     kind,
+		Duration: d,
+		Username: username,
+		Time:     t,
+	})
+}
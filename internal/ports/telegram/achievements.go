@@ -0,0 +1,46 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+
+	"gopkg.in/telebot.v3"
+
+	"github.com/zhikh23/sm-instruction/internal/app/command"
+	"github.com/zhikh23/sm-instruction/internal/app/query"
+)
+
+// unlockAchievements проверяет достижения группы и поздравляет её с новыми.
+// Ошибки только логируются: они не должны мешать основному действию.
+func (p *Port) unlockAchievements(ctx context.Context, bot *telebot.Bot, eventID string, groupName string) {
+	err := p.app.Commands.UnlockAchievements.Handle(ctx, command.UnlockAchievements{
+		EventID:   eventID,
+		GroupName: groupName,
+		OnUnlocked: func(ctx context.Context, achievements []command.UnlockedAchievement) {
+			char, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{EventID: eventID, GroupName: groupName})
+			if err != nil {
+				p.log.Error("failed to get character", "group", groupName, "error", err)
+				return
+			}
+
+			for _, a := range achievements {
+				p.sendToCharacter(ctx, bot, eventID, char, fmt.Sprintf("🏆 Новое достижение! %s", a.Title))
+			}
+		},
+	})
+	if err != nil {
+		p.log.Error("failed to unlock achievements", "group", groupName, "error", err)
+	}
+}
+
+func formatAchievements(as []query.Achievement) string {
+	if len(as) == 0 {
+		return "<i>Пока нет, но всё впереди!</i>"
+	}
+
+	lines := make([]string, len(as))
+	for i, a := range as {
+		lines[i] = fmt.Sprintf("🏆 %s", a.Title)
+	}
+	return buildMessage("\n", lines...)
+}
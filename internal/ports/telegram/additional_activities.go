@@ -12,14 +12,28 @@ import (
 	"github.com/zhikh23/sm-instruction/internal/domain/sm"
 )
 
+const additionalActivityNameKey = "additionalActivityName"
+
+const (
+	additionalEnterCodeButton   = "Ввести код"
+	additionalSubmitProofButton = "Отправить решение"
+	additionalBackButton        = "Назад"
+)
+
 func (p *Port) sendParticipantAdditionalActivities(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
-	groupName, err := extractGroupName(ctx, s)
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	groupName, err := p.extractGroupName(ctx, s)
 	if err != nil {
 		return err
 	}
 	activities, err := p.app.Queries.AdditionalActivities.Handle(ctx, query.AdditionalActivities{
+		EventID:   eventID,
 		GroupName: groupName,
 	})
 	if err != nil {
@@ -54,9 +68,14 @@ func (p *Port) sendParticipantAdditionalActivities(c telebot.Context, s fsm.Cont
 func (p *Port) additionalHandleActivityName(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
 	activityName := c.Message().Text
 
-	activity, err := p.app.Queries.GetActivity.Handle(ctx, query.GetActivity{ActivityName: activityName})
+	activity, err := p.app.Queries.GetActivity.Handle(ctx, query.GetActivity{EventID: eventID, ActivityName: activityName})
 	if errors.Is(err, sm.ErrActivityNotFound) {
 		err = c.Send("🚫 Выбери одно из предложенных дополнительных заданий.")
 		if err != nil {
@@ -73,9 +92,43 @@ func (p *Port) additionalHandleActivityName(c telebot.Context, s fsm.Context) er
 		fmt.Sprintf("🔹 %s", *activity.Description),
 	)
 
-	if err = c.Send(msg, telebot.ModeHTML); err != nil {
+	if err = s.Update(ctx, additionalActivityNameKey, activity.Name); err != nil {
+		return err
+	}
+
+	if err = s.SetState(ctx, additionalHandleActionState); err != nil {
+		return err
+	}
+
+	return c.Send(
+		msg, telebot.ModeHTML,
+		createMarkupWithButtonsFromStrings([]string{
+			additionalEnterCodeButton,
+			additionalSubmitProofButton,
+			additionalBackButton,
+		}, 2),
+	)
+}
+
+func (p *Port) additionalHandleAction(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	switch c.Message().Text {
+	case additionalEnterCodeButton:
+	case additionalSubmitProofButton:
+		return p.additionalSendEnterProof(c, s)
+	case additionalBackButton:
+		return p.sendParticipantMenu(c, s)
+	default:
+		return c.Send("🚫 Выбери одно из предложенных действий.")
+	}
+
+	if err := s.SetState(ctx, additionalHandleCodeState); err != nil {
 		return err
 	}
 
-	return p.sendParticipantMenu(c, s)
+	return c.Send(
+		"Введи секретный код задания.",
+		createMarkupWithButtonsFromStrings([]string{additionalBackButton}, 1),
+	)
 }
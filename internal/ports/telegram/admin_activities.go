@@ -0,0 +1,78 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+
+	"github.com/vitaliy-ukiru/fsm-telebot/v2"
+	"gopkg.in/telebot.v3"
+
+	"github.com/zhikh23/sm-instruction/internal/app/query"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+func (p *Port) adminSwitchActivity(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	if isOrganizer(ctx, s) {
+		return p.organizerSendActivities(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	acts, err := p.app.Queries.AdminActivities.Handle(ctx, query.AdminActivities{
+		EventID:  eventID,
+		Username: c.Chat().Username,
+	})
+	if err != nil {
+		return err
+	}
+
+	return p.adminSendActivities(c, s, acts)
+}
+
+func (p *Port) adminSendActivities(c telebot.Context, s fsm.Context, acts []query.Activity) error {
+	buttons := make([]string, 0, len(acts))
+	for _, act := range acts {
+		buttons = append(buttons, act.Name)
+	}
+
+	if err := s.SetState(context.Background(), adminHandleActivityState); err != nil {
+		return err
+	}
+
+	return c.Send(
+		"Ты администрируешь несколько точек. Выбери, с какой будешь работать.",
+		createMarkupWithButtonsFromStrings(buttons, 2),
+	)
+}
+
+func (p *Port) adminHandleActivity(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	activityName := c.Message().Text
+	_, err = p.app.Queries.AdminActivity.Handle(ctx, query.AdminActivity{
+		EventID:      eventID,
+		Username:     c.Chat().Username,
+		ActivityName: activityName,
+	})
+	if errors.Is(err, sm.ErrActivityNotFound) || errors.Is(err, sm.ErrPermissionDenied) {
+		return c.Send("🚫 Выбери точку из списка.")
+	} else if err != nil {
+		return err
+	}
+
+	if err = s.Update(ctx, activityNameKey, activityName); err != nil {
+		return err
+	}
+
+	return p.sendAdminMenu(c, s)
+}
@@ -2,7 +2,9 @@ package telegram
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/vitaliy-ukiru/fsm-telebot/v2"
 	"gopkg.in/telebot.v3"
@@ -14,27 +16,43 @@ import (
 func (p *Port) sendAdminTimetable(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
-	activityName, err := extractActivityName(ctx, s)
+	eventID, err := p.extractEventID(ctx, s)
 	if err != nil {
 		return err
 	}
 
-	char, err := p.app.Queries.GetActivity.Handle(ctx, query.GetActivity{ActivityName: activityName})
+	activityName, err := extractActivityName(ctx, s)
 	if err != nil {
 		return err
 	}
 
+	char, err := p.app.Queries.AdminActivity.Handle(ctx, query.AdminActivity{
+		EventID:      eventID,
+		Username:     c.Chat().Username,
+		ActivityName: activityName,
+	})
+	if errors.Is(err, sm.ErrPermissionDenied) {
+		return c.Send(permissionDeniedText)
+	} else if err != nil {
+		return err
+	}
+
 	msg := buildMessage("\n",
 		"<b>РАСПИСАНИЕ</b>\n",
 		fmt.Sprintf("Для точки %s:", activityName),
 		"",
 	)
 	for _, slot := range char.Slots {
-		var text string
-		if slot.Whom == nil {
-			text = "-"
-		} else {
-			text = *slot.Whom
+		text := "-"
+		if len(slot.Bookings) > 0 {
+			groups := make([]string, len(slot.Bookings))
+			for i, b := range slot.Bookings {
+				groups[i] = fmt.Sprintf("%s %s", b.Whom, slotStatusEmoji(b.Status))
+			}
+			text = strings.Join(groups, ", ")
+		}
+		if slot.Capacity > 1 {
+			text = fmt.Sprintf("%d/%d | %s", len(slot.Bookings), slot.Capacity, text)
 		}
 		msg = buildMessage("\n",
 			msg,
@@ -45,6 +63,12 @@ func (p *Port) sendAdminTimetable(c telebot.Context, s fsm.Context) error {
 		)
 	}
 
+	msg = buildMessage("\n",
+		msg,
+		"",
+		"🕓 - забронировано, ✅ - пришли, ❌ - не пришли, 🏁 - баллы начислены",
+	)
+
 	err = c.Send(msg, &telebot.ReplyMarkup{RemoveKeyboard: true}, telebot.ModeHTML)
 	if err != nil {
 		return err
@@ -52,3 +76,17 @@ func (p *Port) sendAdminTimetable(c telebot.Context, s fsm.Context) error {
 
 	return p.sendAdminMenu(c, s)
 }
+
+func slotStatusEmoji(status string) string {
+	switch status {
+	case sm.SlotBooked.String():
+		return "🕓"
+	case sm.SlotCheckedIn.String():
+		return "✅"
+	case sm.SlotNoShow.String():
+		return "❌"
+	case sm.SlotCompleted.String():
+		return "🏁"
+	}
+	return ""
+}
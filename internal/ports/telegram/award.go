@@ -36,9 +36,14 @@ func (p *Port) awardSendEnterGroup(c telebot.Context, s fsm.Context) error {
 func (p *Port) awardHandleGroupName(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
 	groupName := c.Message().Text
 
-	_, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{GroupName: groupName})
+	_, err = p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{EventID: eventID, GroupName: groupName})
 	if errors.Is(err, sm.ErrCharacterNotFound) {
 		return p.awardSendCharacterNotFound(c, s)
 	} else if err != nil {
@@ -55,6 +60,11 @@ func (p *Port) awardHandleGroupName(c telebot.Context, s fsm.Context) error {
 func (p *Port) awardSendEnterSkill(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
 	if err := s.SetState(ctx, awardHandleSkillState); err != nil {
 		return err
 	}
@@ -64,14 +74,23 @@ func (p *Port) awardSendEnterSkill(c telebot.Context, s fsm.Context) error {
 		return err
 	}
 
-	act, err := p.app.Queries.GetActivity.Handle(ctx, query.GetActivity{ActivityName: activityName})
+	act, err := p.app.Queries.AdminActivity.Handle(ctx, query.AdminActivity{
+		EventID:      eventID,
+		Username:     c.Chat().Username,
+		ActivityName: activityName,
+	})
+	if err != nil {
+		return err
+	}
+
+	skills, err := p.eventSkills(ctx, eventID)
 	if err != nil {
 		return err
 	}
 
 	return c.Send(
 		"Выбери один из доступных навыков для начисления баллов.",
-		createMarkupWithButtonsFromStrings(act.Skills, 2),
+		createMarkupWithButtonsFromStrings(skillLabels(skills, act.Skills), 2),
 	)
 }
 
@@ -87,14 +106,31 @@ func (p *Port) awardSendCharacterNotFound(c telebot.Context, _ fsm.Context) erro
 func (p *Port) awardHandleSkill(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
-	skillType := c.Message().Text
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	skills, err := p.eventSkills(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	skillType, ok := skillByLabel(skills, c.Message().Text)
+	if !ok {
+		return p.awardSendInvalidSkill(c, s)
+	}
 
 	activityName, err := extractActivityName(ctx, s)
 	if err != nil {
 		return err
 	}
 
-	act, err := p.app.Queries.GetActivity.Handle(ctx, query.GetActivity{ActivityName: activityName})
+	act, err := p.app.Queries.AdminActivity.Handle(ctx, query.AdminActivity{
+		EventID:      eventID,
+		Username:     c.Chat().Username,
+		ActivityName: activityName,
+	})
 	if err != nil {
 		return err
 	}
@@ -117,12 +153,21 @@ func (p *Port) awardHandleSkill(c telebot.Context, s fsm.Context) error {
 func (p *Port) awardSendEnterPoints(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
 	activityName, err := extractActivityName(ctx, s)
 	if err != nil {
 		return err
 	}
 
-	act, err := p.app.Queries.GetActivity.Handle(ctx, query.GetActivity{ActivityName: activityName})
+	act, err := p.app.Queries.AdminActivity.Handle(ctx, query.AdminActivity{
+		EventID:      eventID,
+		Username:     c.Chat().Username,
+		ActivityName: activityName,
+	})
 	if err != nil {
 		return err
 	}
@@ -153,6 +198,11 @@ func (p *Port) awardSendInvalidSkill(c telebot.Context, s fsm.Context) error {
 func (p *Port) awardHandlePoints(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
 	pointsStr := c.Message().Text
 	points, err := strconv.Atoi(pointsStr)
 	if err != nil {
@@ -175,17 +225,40 @@ func (p *Port) awardHandlePoints(c telebot.Context, s fsm.Context) error {
 	}
 
 	err = p.app.Commands.AwardCharacter.Handle(ctx, command.AwardCharacter{
+		EventID:      eventID,
 		GroupName:    groupName,
 		ActivityName: activityName,
 		SkillType:    skill,
 		Points:       points,
+		Username:     c.Chat().Username,
+		// Организатор исправляет начисления задним числом, поэтому посещение не проверяется.
+		Override: isOrganizer(ctx, s),
 	})
 	if errors.Is(err, sm.ErrMaxPointsExceeded) {
 		return p.awardSendInvalidPoints(c, s)
+	} else if errors.Is(err, sm.ErrGroupHasNotVisited) {
+		return p.awardSendRejected(c, s, fmt.Sprintf("🚫 Группа %s не записана на твою точку.", groupName))
+	} else if errors.Is(err, sm.ErrAwardOutsideSlotTime) {
+		event, err := p.app.Queries.GetEvent.Handle(ctx, query.GetEvent{EventID: eventID})
+		if err != nil {
+			return err
+		}
+		return p.awardSendRejected(c, s, fmt.Sprintf(
+			"🚫 Начислять баллы можно только во время слота группы и в течение %d минут после его окончания.",
+			int(event.Rules.AwardGracePeriod.Minutes()),
+		))
+	} else if errors.Is(err, sm.ErrInvalidSlotTransition) {
+		return p.awardSendRejected(c, s, "🚫 Группа отмечена как не пришедшая. Сначала отметь её прибытие.")
+	} else if errors.Is(err, sm.ErrSkillAlreadyAwarded) {
+		return p.awardSendRejected(c, s, "🚫 За это посещение баллы в этот навык уже начислены.")
+	} else if errors.Is(err, sm.ErrPermissionDenied) {
+		return p.awardSendRejected(c, s, permissionDeniedText)
 	} else if err != nil {
 		return err
 	}
 
+	p.unlockAchievements(ctx, c.Bot(), eventID, groupName)
+
 	return p.awardSendSuccess(c, s, groupName, skill, points)
 }
 
@@ -199,6 +272,13 @@ func (p *Port) awardSendSuccess(c telebot.Context, s fsm.Context, groupName stri
 	return p.sendAdminMenu(c, s)
 }
 
+func (p *Port) awardSendRejected(c telebot.Context, s fsm.Context, msg string) error {
+	if err := c.Send(msg); err != nil {
+		return err
+	}
+	return p.sendAdminMenu(c, s)
+}
+
 func (p *Port) awardSendInvalidPoints(c telebot.Context, s fsm.Context) error {
 	err := c.Send("🚫 Кажется это некорректное количество баллов.")
 	if err != nil {
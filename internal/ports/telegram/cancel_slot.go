@@ -0,0 +1,223 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vitaliy-ukiru/fsm-telebot/v2"
+	"gopkg.in/telebot.v3"
+
+	"github.com/zhikh23/sm-instruction/internal/app/command"
+	"github.com/zhikh23/sm-instruction/internal/app/query"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+const cancelSlotActivityName = "cancelSlotActivityName"
+const cancelSlotStartTime = "cancelSlotStartTime"
+const cancelSlotApproveButton = "Да, отменить"
+const cancelSlotBackButton = "Назад"
+
+func (p *Port) cancelSlotSendBookedSlots(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	if ok, err := p.requireCaptain(c, s); !ok {
+		return err
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	groupName, err := p.extractGroupName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	char, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{EventID: eventID, GroupName: groupName})
+	if err != nil {
+		return err
+	}
+
+	buttons := make([]string, 0, len(char.Slots))
+	for _, slot := range char.Slots {
+		if len(slot.Bookings) == 0 {
+			continue
+		}
+		buttons = append(buttons, cancelSlotButtonText(slot))
+	}
+
+	if len(buttons) == 0 {
+		if err = c.Send("Здесь пока пусто. Забронируй точку, и она появится в этом списке."); err != nil {
+			return err
+		}
+		return p.sendParticipantMenu(c, s)
+	}
+	buttons = append(buttons, cancelSlotBackButton)
+
+	event, err := p.app.Queries.GetEvent.Handle(ctx, query.GetEvent{EventID: eventID})
+	if err != nil {
+		return err
+	}
+
+	msg := buildMessage("\n",
+		"<b>МОИ БРОНИ</b>",
+		"",
+		"Выбери бронь, которую хочешь отменить.",
+		fmt.Sprintf(
+			"❕ Отменить бронь можно не позднее, чем за %d минут до начала точки.",
+			int(event.Rules.MinDurationBeforeCancel.Minutes()),
+		),
+	)
+
+	if err = s.SetState(ctx, cancelSlotHandleSlotState); err != nil {
+		return err
+	}
+
+	return c.Send(
+		msg, telebot.ModeHTML,
+		createMarkupWithButtonsFromStrings(buttons, 1),
+	)
+}
+
+func (p *Port) cancelSlotHandleSlot(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	answer := c.Message().Text
+	if answer == cancelSlotBackButton {
+		return p.sendParticipantMenu(c, s)
+	}
+
+	groupName, err := p.extractGroupName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	char, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{EventID: eventID, GroupName: groupName})
+	if err != nil {
+		return err
+	}
+
+	var chosen *query.Slot
+	for _, slot := range char.Slots {
+		if len(slot.Bookings) > 0 && cancelSlotButtonText(slot) == answer {
+			chosen = &slot
+			break
+		}
+	}
+
+	if chosen == nil {
+		if err = c.Send("🚫 Выбери одну из своих броней."); err != nil {
+			return err
+		}
+		return p.cancelSlotSendBookedSlots(c, s)
+	}
+
+	if err = s.Update(ctx, cancelSlotActivityName, chosen.Bookings[0].Whom); err != nil {
+		return err
+	}
+
+	if err = s.Update(ctx, cancelSlotStartTime, chosen.Start); err != nil {
+		return err
+	}
+
+	if err = s.SetState(ctx, cancelSlotHandleApproveState); err != nil {
+		return err
+	}
+
+	return c.Send(
+		fmt.Sprintf(
+			"❓ Точно отменить бронь точки %q на время %s?",
+			chosen.Bookings[0].Whom, chosen.Start.Format(sm.TimeFormat),
+		),
+		createMarkupWithButtonsFromStrings([]string{cancelSlotApproveButton, cancelSlotBackButton}, 2),
+	)
+}
+
+func (p *Port) cancelSlotHandleApprove(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	answer := c.Message().Text
+	if answer != cancelSlotApproveButton {
+		return p.sendParticipantMenu(c, s)
+	}
+
+	groupName, err := p.extractGroupName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	activityName, err := cancelSlotExtractActivityName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	start, err := cancelSlotExtractStartTime(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	err = p.app.Commands.CancelSlot.Handle(ctx, command.CancelSlot{
+		EventID:      eventID,
+		GroupName:    groupName,
+		ActivityName: activityName,
+		Start:        start,
+		Username:     c.Chat().Username,
+	})
+	if errors.Is(err, sm.ErrNotCaptain) {
+		return p.sendNotCaptain(c, s)
+	} else if errors.Is(err, sm.ErrSlotIsTooLateToCancel) {
+		if err = c.Send("🚫 Ой, эту бронь уже слишком поздно отменять :("); err != nil {
+			return err
+		}
+		return p.sendParticipantMenu(c, s)
+	} else if errors.Is(err, sm.ErrSlotHasNotTaken) || errors.Is(err, sm.ErrSlotTakenByAnother) {
+		if err = c.Send("🚫 Кажется, эта бронь уже не действительна."); err != nil {
+			return err
+		}
+		return p.sendParticipantMenu(c, s)
+	} else if err != nil {
+		return err
+	}
+
+	err = c.Send(fmt.Sprintf("✅ Бронь точки %q на время %s отменена", activityName, start.Format(sm.TimeFormat)))
+	if err != nil {
+		return err
+	}
+
+	p.ProcessWaitlists(ctx, c.Bot())
+
+	return p.sendParticipantMenu(c, s)
+}
+
+func cancelSlotButtonText(slot query.Slot) string {
+	return fmt.Sprintf("%s | %s", slot.Start.Format(sm.TimeFormat), slot.Bookings[0].Whom)
+}
+
+func cancelSlotExtractActivityName(ctx context.Context, s fsm.Context) (string, error) {
+	var activityName string
+	if err := s.Data(ctx, cancelSlotActivityName, &activityName); err != nil {
+		return "", fmt.Errorf("failed extract activity name: %w", err)
+	}
+	return activityName, nil
+}
+
+func cancelSlotExtractStartTime(ctx context.Context, s fsm.Context) (time.Time, error) {
+	var startTime time.Time
+	if err := s.Data(ctx, cancelSlotStartTime, &startTime); err != nil {
+		return time.Time{}, fmt.Errorf("failed extract start time: %w", err)
+	}
+	return startTime, nil
+}
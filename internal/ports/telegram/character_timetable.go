@@ -14,12 +14,17 @@ import (
 func (p *Port) sendCharacterTimetable(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
-	groupName, err := extractGroupName(ctx, s)
+	eventID, err := p.extractEventID(ctx, s)
 	if err != nil {
 		return err
 	}
 
-	char, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{GroupName: groupName})
+	groupName, err := p.extractGroupName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	char, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{EventID: eventID, GroupName: groupName})
 	if err != nil {
 		return err
 	}
@@ -30,19 +35,21 @@ func (p *Port) sendCharacterTimetable(c telebot.Context, s fsm.Context) error {
 		"",
 	)
 	for _, slot := range char.Slots {
-		act, err := p.app.Queries.GetActivity.Handle(ctx, query.GetActivity{ActivityName: *slot.Whom})
+		if len(slot.Bookings) == 0 {
+			continue
+		}
+		activityName := slot.Bookings[0].Whom
+
+		act, err := p.app.Queries.GetActivity.Handle(ctx, query.GetActivity{EventID: eventID, ActivityName: activityName})
 		if err != nil {
 			return err
 		}
 
-		if slot.Whom == nil {
-			continue
-		}
 		msg = buildMessage("\n",
 			msg,
 			fmt.Sprintf(
 				"<code>%s-%s</code> | %s | %s",
-				slot.Start.Format(sm.TimeFormat), slot.End.Format(sm.TimeFormat), *slot.Whom, *act.Location,
+				slot.Start.Format(sm.TimeFormat), slot.End.Format(sm.TimeFormat), activityName, *act.Location,
 			),
 		)
 	}
@@ -0,0 +1,230 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/vitaliy-ukiru/fsm-telebot/v2"
+	"gopkg.in/telebot.v3"
+
+	"github.com/zhikh23/sm-instruction/internal/app/command"
+	"github.com/zhikh23/sm-instruction/internal/app/query"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+const checkInGroupNameKey = "checkInGroupName"
+const checkInStartTimeKey = "checkInStartTime"
+const checkInArrivedButton = "Пришли"
+const checkInNoShowButton = "Не пришли"
+const checkInBackButton = "Назад"
+
+func (p *Port) checkInSendSlots(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	activityName, err := extractActivityName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	act, err := p.app.Queries.AdminActivity.Handle(ctx, query.AdminActivity{
+		EventID:      eventID,
+		Username:     c.Chat().Username,
+		ActivityName: activityName,
+	})
+	if errors.Is(err, sm.ErrPermissionDenied) {
+		return c.Send(permissionDeniedText)
+	} else if err != nil {
+		return err
+	}
+
+	buttons := make([]string, 0, len(act.Slots))
+	for _, slot := range act.Slots {
+		for _, b := range slot.Bookings {
+			if b.Status == sm.SlotBooked.String() || b.Status == sm.SlotNoShow.String() {
+				buttons = append(buttons, checkInButtonText(slot, b))
+			}
+		}
+	}
+
+	if len(buttons) == 0 {
+		if err = c.Send("Нет групп, ожидающих отметки о прибытии."); err != nil {
+			return err
+		}
+		return p.sendAdminMenu(c, s)
+	}
+	buttons = append(buttons, checkInBackButton)
+
+	if err = s.SetState(ctx, checkInHandleSlotState); err != nil {
+		return err
+	}
+
+	return c.Send(
+		"Выбери группу, чтобы отметить её прибытие.",
+		createMarkupWithButtonsFromStrings(buttons, 1),
+	)
+}
+
+func (p *Port) checkInHandleSlot(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	answer := c.Message().Text
+	if answer == checkInBackButton {
+		return p.sendAdminMenu(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	activityName, err := extractActivityName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	act, err := p.app.Queries.AdminActivity.Handle(ctx, query.AdminActivity{
+		EventID:      eventID,
+		Username:     c.Chat().Username,
+		ActivityName: activityName,
+	})
+	if err != nil {
+		return err
+	}
+
+	var chosen *query.Slot
+	var chosenBooking query.Booking
+	for _, slot := range act.Slots {
+		for _, b := range slot.Bookings {
+			if checkInButtonText(slot, b) == answer {
+				chosen = &slot
+				chosenBooking = b
+				break
+			}
+		}
+		if chosen != nil {
+			break
+		}
+	}
+
+	if chosen == nil {
+		if err = c.Send("🚫 Выбери одну из предложенных групп."); err != nil {
+			return err
+		}
+		return p.checkInSendSlots(c, s)
+	}
+
+	if err = s.Update(ctx, checkInGroupNameKey, chosenBooking.Whom); err != nil {
+		return err
+	}
+
+	if err = s.Update(ctx, checkInStartTimeKey, chosen.Start); err != nil {
+		return err
+	}
+
+	if err = s.SetState(ctx, checkInHandleStatusState); err != nil {
+		return err
+	}
+
+	return c.Send(
+		fmt.Sprintf("Группа %s на время %s:", chosenBooking.Whom, chosen.Start.Format(sm.TimeFormat)),
+		createMarkupWithButtonsFromStrings([]string{checkInArrivedButton, checkInNoShowButton, checkInBackButton}, 2),
+	)
+}
+
+func (p *Port) checkInHandleStatus(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	answer := c.Message().Text
+	if answer != checkInArrivedButton && answer != checkInNoShowButton {
+		return p.sendAdminMenu(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	activityName, err := extractActivityName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	groupName, err := checkInExtractGroupName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	start, err := checkInExtractStartTime(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	var msg string
+	if answer == checkInArrivedButton {
+		err = p.app.Commands.CheckInSlot.Handle(ctx, command.CheckInSlot{
+			EventID:      eventID,
+			GroupName:    groupName,
+			ActivityName: activityName,
+			Start:        start,
+			Username:     c.Chat().Username,
+		})
+		msg = fmt.Sprintf("✅ Группа %s отмечена как прибывшая", groupName)
+	} else {
+		err = p.app.Commands.MarkSlotNoShow.Handle(ctx, command.MarkSlotNoShow{
+			EventID:      eventID,
+			GroupName:    groupName,
+			ActivityName: activityName,
+			Start:        start,
+			Username:     c.Chat().Username,
+		})
+		msg = fmt.Sprintf("❌ Группа %s отмечена как не пришедшая", groupName)
+	}
+	if errors.Is(err, sm.ErrInvalidSlotTransition) ||
+		errors.Is(err, sm.ErrSlotHasNotTaken) ||
+		errors.Is(err, sm.ErrSlotTakenByAnother) {
+		if err = c.Send("🚫 Статус этой брони уже изменился."); err != nil {
+			return err
+		}
+		return p.sendAdminMenu(c, s)
+	} else if errors.Is(err, sm.ErrPermissionDenied) {
+		if err = c.Send(permissionDeniedText); err != nil {
+			return err
+		}
+		return p.sendAdminMenu(c, s)
+	} else if err != nil {
+		return err
+	}
+
+	if err = c.Send(msg); err != nil {
+		return err
+	}
+
+	return p.sendAdminMenu(c, s)
+}
+
+func checkInButtonText(slot query.Slot, b query.Booking) string {
+	return fmt.Sprintf("%s | %s %s", slot.Start.Format(sm.TimeFormat), b.Whom, slotStatusEmoji(b.Status))
+}
+
+func checkInExtractGroupName(ctx context.Context, s fsm.Context) (string, error) {
+	var groupName string
+	if err := s.Data(ctx, checkInGroupNameKey, &groupName); err != nil {
+		return "", fmt.Errorf("failed extract group name: %w", err)
+	}
+	return groupName, nil
+}
+
+func checkInExtractStartTime(ctx context.Context, s fsm.Context) (time.Time, error) {
+	var startTime time.Time
+	if err := s.Data(ctx, checkInStartTimeKey, &startTime); err != nil {
+		return time.Time{}, fmt.Errorf("failed extract start time: %w", err)
+	}
+	return startTime, nil
+}
@@ -50,15 +50,28 @@ const (
 	participantMenuProfileButton    = "Профиль"
 	participantMenuTimetableButton  = "Расписание"
 	participantMenuTakeSlotButton   = "Забронировать точку"
+	participantMenuCancelSlotButton = "Мои брони"
 	participantMenuGradesButton     = "Успеваемость"
 	participantMenuRatingButton     = "Сессия"
 	participantMenuAdditionalButton = "Дополнительные задания"
+	participantMenuQuizButton       = "Викторины"
 	participantMenuLearnMore        = "Материалы"
+	participantMenuWaitlistButton   = "Лист ожидания"
 
 	adminMenuAwardCharacterButton = "Начислить баллы"
+	adminMenuRevokeGradeButton    = "Отменить последнее начисление"
 	adminMenuTimetableButton      = "Расписание"
+	adminMenuCheckInButton        = "Отметить прибытие"
+	adminMenuOrganizerButton      = "К панели организатора"
+	adminMenuSwitchActivityButton = "Сменить точку"
+	adminMenuPenaltyButton        = "Штраф"
+	adminMenuSecretCodesButton    = "Секретные коды"
+	adminMenuSubmissionsButton    = "Проверка решений"
+	adminMenuQuizResultsButton    = "Результаты викторины"
 )
 
+const permissionDeniedText = "🚫 У тебя нет прав на это действие."
+
 func (p *Port) sendParticipantMenu(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
@@ -66,51 +79,169 @@ func (p *Port) sendParticipantMenu(c telebot.Context, s fsm.Context) error {
 		return err
 	}
 
+	buttons := []string{
+		participantMenuProfileButton,
+		participantMenuTimetableButton,
+	}
+	if isCaptain(ctx, s) {
+		buttons = append(buttons, participantMenuTakeSlotButton, participantMenuCancelSlotButton)
+	}
+	buttons = append(buttons,
+		participantMenuGradesButton,
+		participantMenuRatingButton,
+		participantMenuAdditionalButton,
+		participantMenuQuizButton,
+		participantMenuLearnMore,
+	)
+	if isCaptain(ctx, s) {
+		buttons = append(buttons, participantMenuWaitlistButton)
+	}
+
 	return c.Send(
 		"Выбери действие.",
-		createMarkupWithButtonsFromStrings([]string{
-			participantMenuProfileButton,
-			participantMenuTimetableButton,
-			participantMenuTakeSlotButton,
-			participantMenuGradesButton,
-			participantMenuRatingButton,
-			participantMenuAdditionalButton,
-			participantMenuLearnMore,
-		}, 2),
+		createMarkupWithButtonsFromStrings(buttons, 2),
 	)
 }
 
 func (p *Port) sendAdminMenu(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
-	if err := s.SetState(ctx, adminMenuHandle); err != nil {
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
 		return err
 	}
 
-	act, err := p.app.Queries.AdminActivity.Handle(ctx, query.AdminActivity{Username: c.Chat().Username})
+	activityName, err := extractActivityName(ctx, s)
+	if err != nil {
+		activityName = ""
+	}
+
+	// Организатор работает с точкой, выбранной в своей панели.
+	if isOrganizer(ctx, s) {
+		if activityName == "" {
+			return p.sendOrganizerMenu(c, s)
+		}
+		act, err := p.app.Queries.AdminActivity.Handle(ctx, query.AdminActivity{
+			EventID:      eventID,
+			Username:     c.Chat().Username,
+			ActivityName: activityName,
+		})
+		if err != nil {
+			return err
+		}
+		return p.sendAdminMenuFor(c, s, act, true, true)
+	}
+
+	acts, err := p.app.Queries.AdminActivities.Handle(ctx, query.AdminActivities{
+		EventID:  eventID,
+		Username: c.Chat().Username,
+	})
 	if err != nil {
 		return err
 	}
 
-	if err = s.Update(ctx, activityNameKey, act.Name); err != nil {
+	if len(acts) == 0 {
+		return c.Send(permissionDeniedText)
+	}
+
+	// Точку, выбранную ранее, сохраняем, пока администратор на неё назначен.
+	for _, act := range acts {
+		if act.Name == activityName {
+			return p.sendAdminMenuFor(c, s, act, false, len(acts) > 1)
+		}
+	}
+
+	if len(acts) > 1 {
+		return p.adminSendActivities(c, s, acts)
+	}
+
+	if err = s.Update(ctx, activityNameKey, acts[0].Name); err != nil {
+		return err
+	}
+
+	return p.sendAdminMenuFor(c, s, acts[0], false, false)
+}
+
+func (p *Port) sendAdminMenuFor(
+	c telebot.Context,
+	s fsm.Context,
+	act query.Activity,
+	organizer bool,
+	switchable bool,
+) error {
+	if err := s.SetState(context.Background(), adminMenuHandle); err != nil {
 		return err
 	}
 
 	buttons := make([]string, 0)
 	buttons = append(buttons, adminMenuAwardCharacterButton)
+	buttons = append(buttons, adminMenuRevokeGradeButton)
+	buttons = append(buttons, adminMenuPenaltyButton)
 	if act.Location != nil {
 		buttons = append(buttons, adminMenuTimetableButton)
+		buttons = append(buttons, adminMenuCheckInButton)
+	} else {
+		buttons = append(buttons, adminMenuSecretCodesButton)
+		buttons = append(buttons, adminMenuSubmissionsButton)
+	}
+	if p.hasQuiz(c, s, act) {
+		buttons = append(buttons, adminMenuQuizResultsButton)
+	}
+	if switchable {
+		buttons = append(buttons, adminMenuSwitchActivityButton)
+	}
+	msg := "Панель управления администратора."
+	if organizer {
+		buttons = append(buttons, adminMenuOrganizerButton)
+	}
+	if switchable {
+		msg = fmt.Sprintf("Панель управления точкой %q.", act.Name)
 	}
 
 	return c.Send(
-		"Панель управления администратора.",
+		msg,
 		createMarkupWithButtonsFromStrings(buttons, 2),
 	)
 }
 
-func extractGroupName(ctx context.Context, s fsm.Context) (string, error) {
-	var groupName string
-	if err := s.Data(ctx, groupNameKey, &groupName); err != nil {
+// extractEventID возвращает идентификатор события, выбранного при /start. Если
+// событие ещё не выбрано, берётся текущее.
+func (p *Port) extractEventID(ctx context.Context, s fsm.Context) (string, error) {
+	var eventID string
+	if err := s.Data(ctx, eventIDKey, &eventID); err == nil && eventID != "" {
+		return eventID, nil
+	}
+
+	event, err := p.app.Queries.CurrentEvent.Handle(ctx, query.CurrentEvent{})
+	if err != nil {
+		return "", fmt.Errorf("failed extract event id: %w", err)
+	}
+
+	if err = s.Update(ctx, eventIDKey, event.ID); err != nil {
+		return "", err
+	}
+
+	return event.ID, nil
+}
+
+// extractGroupName возвращает текущее название группы участника. В сессии
+// хранится идентификатор группы, поэтому переименование её не ломает.
+func (p *Port) extractGroupName(ctx context.Context, s fsm.Context) (string, error) {
+	var characterID string
+	if err := s.Data(ctx, characterIDKey, &characterID); err != nil {
+		return "", fmt.Errorf("failed extract character id: %w", err)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return "", err
+	}
+
+	groupName, err := p.app.Queries.GroupName.Handle(ctx, query.GroupName{
+		EventID:     eventID,
+		CharacterID: characterID,
+	})
+	if err != nil {
 		return "", fmt.Errorf("failed extract group name: %w", err)
 	}
 	return groupName, nil
@@ -8,24 +8,73 @@ import (
 	"gopkg.in/telebot.v3"
 )
 
-const groupNameKey = "groupName"
+const eventIDKey = "eventID"
+const characterIDKey = "characterID"
 const activityNameKey = "groupActivityName"
+const teamRoleKey = "teamRole"
+const userRoleKey = "userRole"
 
 const (
 	participantMenuHandle = fsm.State("participantMenuHandle")
 	adminMenuHandle       = fsm.State("adminMenuHandle")
+	organizerMenuHandle   = fsm.State("organizerMenuHandle")
+
+	adminHandleActivityState = fsm.State("adminHandleActivityState")
+
+	organizerHandleActivityState     = fsm.State("organizerHandleActivityState")
+	organizerBookHandleGroupState    = fsm.State("organizerBookHandleGroupState")
+	organizerBookHandleActivityState = fsm.State("organizerBookHandleActivityState")
+	organizerBookHandleSlotState     = fsm.State("organizerBookHandleSlotState")
+	organizerUnbookHandleGroupState  = fsm.State("organizerUnbookHandleGroupState")
+	organizerUnbookHandleSlotState   = fsm.State("organizerUnbookHandleSlotState")
+	organizerRenameHandleGroupState  = fsm.State("organizerRenameHandleGroupState")
+	organizerRenameHandleNameState   = fsm.State("organizerRenameHandleNameState")
+	organizerWaveHandleGroupsState   = fsm.State("organizerWaveHandleGroupsState")
+	organizerWaveHandleTimeState     = fsm.State("organizerWaveHandleTimeState")
+	organizerWindowHandleGroupState  = fsm.State("organizerWindowHandleGroupState")
+	organizerWindowHandleActionState = fsm.State("organizerWindowHandleActionState")
 
 	awardHandleGroupNameState = fsm.State("awardHandleGroupNameState")
 	awardHandleSkillState     = fsm.State("awardHandleSkillState")
 	awardHandlePointsState    = fsm.State("awardHandlePointsState")
 
+	revokeGradeHandleReasonState = fsm.State("revokeGradeHandleReasonState")
+
+	penaltyHandleGroupNameState = fsm.State("penaltyHandleGroupNameState")
+	penaltyHandlePointsState    = fsm.State("penaltyHandlePointsState")
+	penaltyHandleReasonState    = fsm.State("penaltyHandleReasonState")
+
+	checkInHandleSlotState   = fsm.State("checkInHandleSlotState")
+	checkInHandleStatusState = fsm.State("checkInHandleStatusState")
+
 	takeSlotHandleActivityNameState = fsm.State("takeSlotHandleActivityNameState")
 	takeSlotHandleStartTimeState    = fsm.State("takeSlotHandleStartTimeState")
 	takeSlotHandleApproveState      = fsm.State("takeSlotHandleApproveState")
 
+	cancelSlotHandleSlotState    = fsm.State("cancelSlotHandleSlotState")
+	cancelSlotHandleApproveState = fsm.State("cancelSlotHandleApproveState")
+
 	additionalHandleActivityNameState = fsm.State("additionalHandleActivityNameState")
+	additionalHandleActionState       = fsm.State("additionalHandleActionState")
+	additionalHandleCodeState         = fsm.State("additionalHandleCodeState")
+	additionalHandleProofState        = fsm.State("additionalHandleProofState")
+
+	secretCodesHandleActionState = fsm.State("secretCodesHandleActionState")
+	secretCodesHandleSkillState  = fsm.State("secretCodesHandleSkillState")
+	secretCodesHandlePointsState = fsm.State("secretCodesHandlePointsState")
+
+	submissionsHandleActionState = fsm.State("submissionsHandleActionState")
+	submissionsHandleSkillState  = fsm.State("submissionsHandleSkillState")
+	submissionsHandlePointsState = fsm.State("submissionsHandlePointsState")
+	submissionsHandleReasonState = fsm.State("submissionsHandleReasonState")
+
+	quizHandleActivityState = fsm.State("quizHandleActivityState")
+	quizHandleAnswerState   = fsm.State("quizHandleAnswerState")
 
 	learnMoreHandleActivityNameState = fsm.State("learnMoreHandleActivityNameState")
+
+	waitlistHandleActionState = fsm.State("waitlistHandleActionState")
+	waitlistHandleRangeState  = fsm.State("waitlistHandleRangeState")
 )
 
 func (p *Port) RegisterFSMManager(m *fsm.Manager, dp fsm.Dispatcher) {
@@ -67,6 +116,12 @@ func (p *Port) RegisterFSMManager(m *fsm.Manager, dp fsm.Dispatcher) {
 		fsmopt.Do(p.takeSlotSendChooseActivity),
 	))
 
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(participantMenuHandle),
+		fsmopt.On(participantMenuCancelSlotButton),
+		fsmopt.Do(p.cancelSlotSendBookedSlots),
+	))
+
 	dp.Dispatch(m.New(
 		fsmopt.OnStates(participantMenuHandle),
 		fsmopt.On(participantMenuGradesButton),
@@ -85,12 +140,156 @@ func (p *Port) RegisterFSMManager(m *fsm.Manager, dp fsm.Dispatcher) {
 		fsmopt.Do(p.sendParticipantAdditionalActivities),
 	))
 
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(participantMenuHandle),
+		fsmopt.On(participantMenuQuizButton),
+		fsmopt.Do(p.quizSendActivities),
+	))
+
 	dp.Dispatch(m.New(
 		fsmopt.OnStates(participantMenuHandle),
 		fsmopt.On(participantMenuLearnMore),
 		fsmopt.Do(p.learnMoreSendActivities),
 	))
 
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(participantMenuHandle),
+		fsmopt.On(participantMenuWaitlistButton),
+		fsmopt.Do(p.waitlistSendMenu),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(adminMenuHandle),
+		fsmopt.On(adminMenuOrganizerButton),
+		fsmopt.Do(p.sendOrganizerMenu),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(adminMenuHandle),
+		fsmopt.On(adminMenuSwitchActivityButton),
+		fsmopt.Do(p.adminSwitchActivity),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(adminHandleActivityState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.adminHandleActivity),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(organizerMenuHandle),
+		fsmopt.On(organizerMenuActivitiesButton),
+		fsmopt.Do(p.organizerSendActivities),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(organizerMenuHandle),
+		fsmopt.On(organizerMenuGroupsButton),
+		fsmopt.Do(p.organizerSendGroups),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(organizerMenuHandle),
+		fsmopt.On(organizerMenuBookButton),
+		fsmopt.Do(p.organizerBookSendEnterGroup),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(organizerMenuHandle),
+		fsmopt.On(organizerMenuUnbookButton),
+		fsmopt.Do(p.organizerUnbookSendEnterGroup),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(organizerMenuHandle),
+		fsmopt.On(organizerMenuRenameButton),
+		fsmopt.Do(p.organizerRenameSendEnterGroup),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(organizerMenuHandle),
+		fsmopt.On(organizerMenuWaveButton),
+		fsmopt.Do(p.organizerWaveSendEnterGroups),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(organizerMenuHandle),
+		fsmopt.On(organizerMenuWindowButton),
+		fsmopt.Do(p.organizerWindowSendEnterGroup),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(organizerHandleActivityState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.organizerHandleActivity),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(organizerBookHandleGroupState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.organizerBookHandleGroup),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(organizerBookHandleActivityState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.organizerBookHandleActivity),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(organizerBookHandleSlotState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.organizerBookHandleSlot),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(organizerUnbookHandleGroupState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.organizerUnbookHandleGroup),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(organizerUnbookHandleSlotState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.organizerUnbookHandleSlot),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(organizerRenameHandleGroupState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.organizerRenameHandleGroup),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(organizerRenameHandleNameState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.organizerRenameHandleName),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(organizerWaveHandleGroupsState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.organizerWaveHandleGroups),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(organizerWaveHandleTimeState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.organizerWaveHandleTime),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(organizerWindowHandleGroupState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.organizerWindowHandleGroup),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(organizerWindowHandleActionState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.organizerWindowHandleAction),
+	))
+
 	dp.Dispatch(m.New(
 		fsmopt.OnStates(adminMenuHandle),
 		fsmopt.On(adminMenuTimetableButton),
@@ -103,6 +302,132 @@ func (p *Port) RegisterFSMManager(m *fsm.Manager, dp fsm.Dispatcher) {
 		fsmopt.Do(p.awardSendEnterGroup),
 	))
 
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(adminMenuHandle),
+		fsmopt.On(adminMenuRevokeGradeButton),
+		fsmopt.Do(p.revokeGradeSendLast),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(revokeGradeHandleReasonState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.revokeGradeHandleReason),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(adminMenuHandle),
+		fsmopt.On(adminMenuPenaltyButton),
+		fsmopt.Do(p.penaltySendEnterGroup),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(penaltyHandleGroupNameState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.penaltyHandleGroupName),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(penaltyHandlePointsState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.penaltyHandlePoints),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(penaltyHandleReasonState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.penaltyHandleReason),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(adminMenuHandle),
+		fsmopt.On(adminMenuCheckInButton),
+		fsmopt.Do(p.checkInSendSlots),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(checkInHandleSlotState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.checkInHandleSlot),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(checkInHandleStatusState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.checkInHandleStatus),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(adminMenuHandle),
+		fsmopt.On(adminMenuSecretCodesButton),
+		fsmopt.Do(p.secretCodesSendMenu),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(secretCodesHandleActionState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.secretCodesHandleAction),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(secretCodesHandleSkillState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.secretCodesHandleSkill),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(secretCodesHandlePointsState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.secretCodesHandlePoints),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(adminMenuHandle),
+		fsmopt.On(adminMenuSubmissionsButton),
+		fsmopt.Do(p.submissionsSendNext),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(submissionsHandleActionState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.submissionsHandleAction),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(submissionsHandleSkillState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.submissionsHandleSkill),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(submissionsHandlePointsState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.submissionsHandlePoints),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(submissionsHandleReasonState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.submissionsHandleReason),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(adminMenuHandle),
+		fsmopt.On(adminMenuQuizResultsButton),
+		fsmopt.Do(p.quizSendResults),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(quizHandleActivityState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.quizHandleActivity),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(quizHandleAnswerState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.quizHandleAnswer),
+	))
+
 	dp.Dispatch(m.New(
 		fsmopt.OnStates(awardHandleGroupNameState),
 		fsmopt.On(telebot.OnText),
@@ -139,15 +464,69 @@ func (p *Port) RegisterFSMManager(m *fsm.Manager, dp fsm.Dispatcher) {
 		fsmopt.Do(p.takeSlotHandleApprove),
 	))
 
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(cancelSlotHandleSlotState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.cancelSlotHandleSlot),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(cancelSlotHandleApproveState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.cancelSlotHandleApprove),
+	))
+
 	dp.Dispatch(m.New(
 		fsmopt.OnStates(additionalHandleActivityNameState),
 		fsmopt.On(telebot.OnText),
 		fsmopt.Do(p.additionalHandleActivityName),
 	))
 
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(additionalHandleActionState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.additionalHandleAction),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(additionalHandleCodeState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.additionalHandleCode),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(additionalHandleProofState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.additionalHandleProof),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(additionalHandleProofState),
+		fsmopt.On(telebot.OnPhoto),
+		fsmopt.Do(p.additionalHandleProof),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(additionalHandleProofState),
+		fsmopt.On(telebot.OnVideo),
+		fsmopt.Do(p.additionalHandleProof),
+	))
+
 	dp.Dispatch(m.New(
 		fsmopt.OnStates(learnMoreHandleActivityNameState),
 		fsmopt.On(telebot.OnText),
 		fsmopt.Do(p.learnMoreHandleActivityName),
 	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(waitlistHandleActionState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.waitlistHandleAction),
+	))
+
+	dp.Dispatch(m.New(
+		fsmopt.OnStates(waitlistHandleRangeState),
+		fsmopt.On(telebot.OnText),
+		fsmopt.Do(p.waitlistHandleRange),
+	))
 }
@@ -3,6 +3,7 @@ package telegram
 import (
 	"context"
 	"fmt"
+	"html"
 	"strconv"
 
 	"github.com/vitaliy-ukiru/fsm-telebot/v2"
@@ -15,32 +16,62 @@ import (
 func (p *Port) sendParticipantsGrades(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
-	groupName, err := extractGroupName(ctx, s)
+	eventID, err := p.extractEventID(ctx, s)
 	if err != nil {
 		return err
 	}
 
-	char, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{GroupName: groupName})
+	groupName, err := p.extractGroupName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	char, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{EventID: eventID, GroupName: groupName})
 	if err != nil {
 		return err
 	}
 
 	msg := "<b>УСПЕВАЕМОСТЬ</b>\n\n"
-	if len(char.Grades) == 0 {
+	if len(char.Grades) == 0 && len(char.Penalties) == 0 {
 		msg += "Здесь пока пусто. Проходи точки, получай оценки и поднимай свой рейтинг в Сессии!"
 	}
 
+	emojis := skillEmojis(char.RatingBreakdown)
 	for _, grade := range char.Grades {
-		msg += buildMessage(" ",
+		line := buildMessage(" ",
 			grade.Time.Format(sm.TimeFormat),
 			"|",
 			fmt.Sprintf("%q", grade.ActivityName),
 			"-",
-			grade.SkillType,
+			skillLabel(emojis[grade.SkillType], grade.SkillType),
 			"-",
 			"<i>"+strconv.Itoa(grade.Points),
-			"б.</i>\n",
+			"б.</i>",
 		)
+		if grade.Revoked {
+			line = fmt.Sprintf("<s>%s</s> (отменено: %s)", line, html.EscapeString(*grade.RevokeReason))
+		}
+		msg += line + "\n"
+	}
+
+	if len(char.Penalties) > 0 {
+		msg += "\n<b>Штрафы</b>\n"
+	}
+	for _, penalty := range char.Penalties {
+		where := "организатор"
+		if penalty.ActivityName != nil {
+			where = fmt.Sprintf("%q", *penalty.ActivityName)
+		}
+		msg += buildMessage(" ",
+			penalty.Time.Format(sm.TimeFormat),
+			"|",
+			where,
+			"-",
+			"<i>−"+strconv.Itoa(penalty.Points),
+			"б.</i>",
+			"-",
+			html.EscapeString(penalty.Reason),
+		) + "\n"
 	}
 
 	if _, err = gradesSticker.Send(c.Bot(), c.Recipient(), nil); err != nil {
@@ -0,0 +1,351 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vitaliy-ukiru/fsm-telebot/v2"
+	"gopkg.in/telebot.v3"
+
+	"github.com/zhikh23/sm-instruction/internal/app/command"
+	"github.com/zhikh23/sm-instruction/internal/app/query"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+const organizerWaveGroupsKey = "organizerWaveGroups"
+
+const (
+	organizerMenuWaveButton   = "Волна старта"
+	organizerMenuWindowButton = "Окно группы"
+
+	organizerWindowRestartButton = "Перезапустить"
+	organizerWindowPauseButton   = "Пауза"
+	organizerWindowResumeButton  = "Продолжить"
+)
+
+// organizerWindowExtensions — варианты продления окна Инструкции.
+var organizerWindowExtensions = map[string]time.Duration{
+	"+15 мин": 15 * time.Minute,
+	"+30 мин": 30 * time.Minute,
+	"+60 мин": time.Hour,
+}
+
+func (p *Port) organizerWaveSendEnterGroups(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	if err := s.SetState(ctx, organizerWaveHandleGroupsState); err != nil {
+		return err
+	}
+
+	return c.Send(buildMessage("\n",
+		"<b>ВОЛНА СТАРТА</b>",
+		"",
+		"Перечисли группы волны через запятую или каждую с новой строки.",
+		"Группы волны начнут Инструкцию не раньше назначенного времени.",
+	),
+		telebot.ModeHTML,
+		createMarkupWithButtonsFromStrings([]string{organizerBackButton}, 1),
+	)
+}
+
+func (p *Port) organizerWaveHandleGroups(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	if c.Message().Text == organizerBackButton {
+		return p.sendOrganizerMenu(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	groupNames := sm.SplitGroupNames(c.Message().Text)
+	if len(groupNames) == 0 {
+		return c.Send("🚫 Перечисли хотя бы одну группу.")
+	}
+
+	started := make([]string, 0)
+	for _, groupName := range groupNames {
+		char, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{EventID: eventID, GroupName: groupName})
+		if errors.Is(err, sm.ErrCharacterNotFound) {
+			return c.Send(fmt.Sprintf("🚫 Группа %s не найдена. Попробуй ещё раз.", groupName))
+		} else if err != nil {
+			return err
+		}
+		if char.Start != nil {
+			started = append(started, groupName)
+		}
+	}
+	if len(started) > 0 {
+		return c.Send(fmt.Sprintf(
+			"🚫 Уже начали Инструкцию: %s. Их окно меняется в разделе «%s».",
+			strings.Join(started, ", "), organizerMenuWindowButton,
+		))
+	}
+
+	if err = s.Update(ctx, organizerWaveGroupsKey, groupNames); err != nil {
+		return err
+	}
+
+	if err = s.SetState(ctx, organizerWaveHandleTimeState); err != nil {
+		return err
+	}
+
+	return c.Send(buildMessage("\n",
+		"Введи время старта волны в формате:",
+		"<code>13:00</code>",
+	),
+		telebot.ModeHTML,
+		createMarkupWithButtonsFromStrings([]string{organizerBackButton}, 1),
+	)
+}
+
+func (p *Port) organizerWaveHandleTime(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	if c.Message().Text == organizerBackButton {
+		return p.sendOrganizerMenu(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	event, err := p.app.Queries.GetEvent.Handle(ctx, query.GetEvent{EventID: eventID})
+	if err != nil {
+		return err
+	}
+
+	start, err := sm.ParseTimeAt(event.Date, c.Message().Text)
+	if err != nil {
+		return c.Send("🚫 Введи время в формате 13:00.")
+	}
+
+	var groupNames []string
+	if err = s.Data(ctx, organizerWaveGroupsKey, &groupNames); err != nil {
+		return fmt.Errorf("failed extract wave groups: %w", err)
+	}
+
+	err = p.app.Commands.ScheduleInstructionStart.Handle(ctx, command.ScheduleInstructionStart{
+		EventID:    eventID,
+		GroupNames: groupNames,
+		Start:      start,
+		Username:   c.Chat().Username,
+	})
+	if errors.Is(err, sm.ErrInstructionAlreadyStarted) {
+		started, err := p.startedGroups(ctx, eventID, groupNames)
+		if err != nil {
+			return err
+		}
+		if err = c.Send(fmt.Sprintf(
+			"🚫 Уже начали Инструкцию: %s. Волна не назначена ни одной группе.", strings.Join(started, ", "),
+		)); err != nil {
+			return err
+		}
+		return p.sendOrganizerMenu(c, s)
+	} else if errors.Is(err, sm.ErrPermissionDenied) {
+		return c.Send(permissionDeniedText)
+	} else if err != nil {
+		return err
+	}
+
+	for _, groupName := range groupNames {
+		p.notifyGroup(ctx, c, eventID, groupName, fmt.Sprintf(
+			"❕ Организатор назначил старт Инструкции твоей группы на %s.", start.Format(sm.TimeFormat),
+		))
+	}
+
+	if err = c.Send(fmt.Sprintf(
+		"✅ Старт в %s назначен группам: %s", start.Format(sm.TimeFormat), strings.Join(groupNames, ", "),
+	)); err != nil {
+		return err
+	}
+
+	return p.sendOrganizerMenu(c, s)
+}
+
+// startedGroups возвращает группы из groupNames, которые уже начали Инструкцию.
+func (p *Port) startedGroups(ctx context.Context, eventID string, groupNames []string) ([]string, error) {
+	res := make([]string, 0)
+	for _, groupName := range groupNames {
+		char, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{EventID: eventID, GroupName: groupName})
+		if err != nil {
+			return nil, err
+		}
+		if char.Start != nil {
+			res = append(res, groupName)
+		}
+	}
+	return res, nil
+}
+
+func (p *Port) organizerWindowSendEnterGroup(c telebot.Context, s fsm.Context) error {
+	return p.organizerSendEnterGroup(c, s, organizerWindowHandleGroupState)
+}
+
+func (p *Port) organizerWindowHandleGroup(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	if c.Message().Text == organizerBackButton {
+		return p.sendOrganizerMenu(c, s)
+	}
+
+	char, ok, err := p.organizerHandleGroup(ctx, c, s)
+	if !ok {
+		return err
+	}
+
+	if char.Start == nil {
+		if err = c.Send(fmt.Sprintf(
+			"Группа %s ещё не начала Инструкцию. Время старта назначается в разделе «%s».",
+			char.GroupName, organizerMenuWaveButton,
+		)); err != nil {
+			return err
+		}
+		return p.sendOrganizerMenu(c, s)
+	}
+
+	pauseButton := organizerWindowPauseButton
+	if char.PausedAt != nil {
+		pauseButton = organizerWindowResumeButton
+	}
+	buttons := []string{organizerWindowRestartButton, pauseButton}
+	for label := range organizerWindowExtensions {
+		buttons = append(buttons, label)
+	}
+	slices.Sort(buttons[2:])
+	buttons = append(buttons, organizerBackButton)
+
+	if err = s.SetState(ctx, organizerWindowHandleActionState); err != nil {
+		return err
+	}
+
+	return c.Send(
+		buildMessage("\n",
+			formatWindow(char),
+			"",
+			"<b>Журнал изменений окна:</b>",
+			formatWindowAdjustments(char.WindowAdjustments),
+		),
+		telebot.ModeHTML,
+		createMarkupWithButtonsFromStrings(buttons, 2),
+	)
+}
+
+func (p *Port) organizerWindowHandleAction(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	answer := c.Message().Text
+	if answer == organizerBackButton {
+		return p.sendOrganizerMenu(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	var groupName string
+	if err = s.Data(ctx, organizerGroupNameKey, &groupName); err != nil {
+		return err
+	}
+
+	if answer == organizerWindowRestartButton {
+		err = p.app.Commands.RestartInstruction.Handle(ctx, command.RestartInstruction{
+			EventID:   eventID,
+			GroupName: groupName,
+			Username:  c.Chat().Username,
+		})
+	} else if answer == organizerWindowPauseButton {
+		err = p.app.Commands.PauseInstruction.Handle(ctx, command.PauseInstruction{
+			EventID:   eventID,
+			GroupName: groupName,
+			Username:  c.Chat().Username,
+		})
+	} else if answer == organizerWindowResumeButton {
+		err = p.app.Commands.ResumeInstruction.Handle(ctx, command.ResumeInstruction{
+			EventID:   eventID,
+			GroupName: groupName,
+			Username:  c.Chat().Username,
+		})
+	} else if d, ok := organizerWindowExtensions[answer]; ok {
+		err = p.app.Commands.ExtendInstruction.Handle(ctx, command.ExtendInstruction{
+			EventID:   eventID,
+			GroupName: groupName,
+			Duration:  d,
+			Username:  c.Chat().Username,
+		})
+	} else {
+		return c.Send("🚫 Выбери действие из списка.")
+	}
+	if errors.Is(err, sm.ErrInstructionNotStarted) {
+		if err = c.Send("🚫 Группа ещё не начала Инструкцию."); err != nil {
+			return err
+		}
+		return p.sendOrganizerMenu(c, s)
+	} else if errors.Is(err, sm.ErrInstructionAlreadyPaused) {
+		return c.Send("🚫 Инструкция группы уже на паузе.")
+	} else if errors.Is(err, sm.ErrInstructionNotPaused) {
+		return c.Send("🚫 Инструкция группы не на паузе.")
+	} else if errors.Is(err, sm.ErrPermissionDenied) {
+		return c.Send(permissionDeniedText)
+	} else if err != nil {
+		return err
+	}
+
+	char, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{EventID: eventID, GroupName: groupName})
+	if err != nil {
+		return err
+	}
+
+	p.sendToCharacter(ctx, c.Bot(), eventID, char, "❕ Организатор изменил окно Инструкции твоей группы. "+formatWindow(char))
+
+	if err = c.Send("✅ "+formatWindow(char), telebot.ModeHTML); err != nil {
+		return err
+	}
+
+	return p.sendOrganizerMenu(c, s)
+}
+
+// formatWindow описывает окно Инструкции начавшей её группы.
+func formatWindow(char query.Character) string {
+	msg := fmt.Sprintf(
+		"Окно Инструкции группы %s: с %s до %s.",
+		char.GroupName, char.Start.Format(sm.TimeFormat), char.End.Format(sm.TimeFormat),
+	)
+	if char.PausedAt != nil {
+		msg += fmt.Sprintf(" На паузе с %s.", char.PausedAt.Format(sm.TimeFormat))
+	}
+	return msg
+}
+
+func formatWindowAdjustments(as []query.WindowAdjustment) string {
+	if len(as) == 0 {
+		return "<i>Окно не менялось.</i>"
+	}
+
+	lines := make([]string, len(as))
+	for i, a := range as {
+		var action string
+		switch a.Kind {
+		case sm.WindowPaused.String():
+			action = "пауза"
+		case sm.WindowResumed.String():
+			action = fmt.Sprintf("продолжение после паузы %d мин", int(a.Duration.Minutes()))
+		case sm.WindowExtended.String():
+			action = fmt.Sprintf("продление на %d мин", int(a.Duration.Minutes()))
+		case sm.WindowRestarted.String():
+			action = "перезапуск"
+		default:
+			action = a.Kind
+		}
+		lines[i] = fmt.Sprintf("%s — %s, @%s", a.Time.Format(sm.TimeFormat), action, a.Username)
+	}
+	return strings.Join(lines, "\n")
+}
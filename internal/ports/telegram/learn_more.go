@@ -17,7 +17,12 @@ const learnMoreActivityNameKey = "learnMoreActivityName"
 func (p *Port) learnMoreSendActivities(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
-	activities, err := p.app.Queries.Activities.Handle(ctx, query.Activities{})
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	activities, err := p.app.Queries.Activities.Handle(ctx, query.Activities{EventID: eventID})
 	if err != nil {
 		return err
 	}
@@ -59,12 +64,18 @@ func (p *Port) learnMoreHandleActivityName(c telebot.Context, s fsm.Context) err
 func (p *Port) learnMoreSendActivity(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
 	activityName, err := learnMoreExtractActivityName(ctx, s)
 	if err != nil {
 		return err
 	}
 
 	activity, err := p.app.Queries.GetActivity.Handle(ctx, query.GetActivity{
+		EventID:      eventID,
 		ActivityName: activityName,
 	})
 	if errors.Is(err, sm.ErrActivityNotFound) {
@@ -0,0 +1,106 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gopkg.in/telebot.v3"
+
+	"github.com/zhikh23/sm-instruction/internal/app/command"
+	"github.com/zhikh23/sm-instruction/internal/app/query"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// RunNoShowReleaser периодически освобождает слоты текущего мероприятия, на
+// которые группы не пришли, пока не будет отменён ctx. Каждый проход
+// рассматривает только брони, просроченные с предыдущего прохода.
+func (p *Port) RunNoShowReleaser(ctx context.Context, bot *telebot.Bot, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			window := now.Sub(last)
+			last = now
+
+			p.releaseNoShows(ctx, bot, window)
+		}
+	}
+}
+
+// releaseNoShows освобождает просроченные брони, уведомляет группы и
+// администраторов и предлагает освободившиеся слоты листам ожидания.
+func (p *Port) releaseNoShows(ctx context.Context, bot *telebot.Bot, window time.Duration) {
+	event, err := p.app.Queries.CurrentEvent.Handle(ctx, query.CurrentEvent{})
+	if errors.Is(err, sm.ErrEventNotFound) {
+		return
+	} else if err != nil {
+		p.log.Error("failed to get current event", "error", err)
+		return
+	}
+
+	err = p.app.Commands.ReleaseNoShows.Handle(ctx, command.ReleaseNoShows{
+		EventID: event.ID,
+		Window:  window,
+		OnReleased: func(ctx context.Context, slots []command.ReleasedSlot) {
+			for _, slot := range slots {
+				p.log.Info("released no-show slot", "group", slot.GroupName, "activity", slot.ActivityName, "start", slot.Start)
+				p.notifyNoShowReleased(ctx, bot, event.ID, slot)
+			}
+
+			// Освободившиеся слоты сначала получают группы из листов ожидания.
+			p.ProcessWaitlists(ctx, bot)
+		},
+	})
+	if err != nil {
+		p.log.Error("failed to release no-show slots", "error", err)
+	}
+}
+
+func (p *Port) notifyNoShowReleased(ctx context.Context, bot *telebot.Bot, eventID string, slot command.ReleasedSlot) {
+	char, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{EventID: eventID, GroupName: slot.GroupName})
+	if err != nil {
+		p.log.Error("failed to get character", "group", slot.GroupName, "error", err)
+	} else {
+		p.sendToCharacter(ctx, bot, eventID, char, fmt.Sprintf(
+			"❕ Вы не пришли на точку %q к %s, поэтому бронь снята.",
+			slot.ActivityName, slot.Start.Format(sm.TimeFormat),
+		))
+	}
+
+	act, err := p.app.Queries.GetActivity.Handle(ctx, query.GetActivity{EventID: eventID, ActivityName: slot.ActivityName})
+	if err != nil {
+		p.log.Error("failed to get activity", "activity", slot.ActivityName, "error", err)
+		return
+	}
+
+	for _, admin := range act.Admins {
+		p.sendToUser(ctx, bot, eventID, admin.Username, fmt.Sprintf(
+			"❕ Группа %s не пришла к %s, слот освобождён.",
+			slot.GroupName, slot.Start.Format(sm.TimeFormat),
+		))
+	}
+}
+
+// sendToUser отправляет сообщение пользователю, если он уже писал боту.
+func (p *Port) sendToUser(ctx context.Context, bot *telebot.Bot, eventID string, username string, msg string) {
+	user, err := p.app.Queries.GetUser.Handle(ctx, query.GetUser{EventID: eventID, Username: username})
+	if err != nil {
+		p.log.Error("failed to get user", "username", username, "error", err)
+		return
+	}
+
+	if user.ChatID == 0 {
+		return
+	}
+
+	if _, err = bot.Send(&telebot.User{ID: user.ChatID}, msg); err != nil {
+		p.log.Error("failed to send message", "username", username, "error", err)
+	}
+}
@@ -0,0 +1,542 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/vitaliy-ukiru/fsm-telebot/v2"
+	"gopkg.in/telebot.v3"
+
+	"github.com/zhikh23/sm-instruction/internal/app/command"
+	"github.com/zhikh23/sm-instruction/internal/app/query"
+	"github.com/zhikh23/sm-instruction/internal/common/commonerrs"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+const organizerGroupNameKey = "organizerGroupName"
+const organizerActivityNameKey = "organizerActivityName"
+
+const (
+	organizerMenuActivitiesButton = "Точки"
+	organizerMenuGroupsButton     = "Группы"
+	organizerMenuBookButton       = "Записать группу"
+	organizerMenuUnbookButton     = "Снять бронь"
+	organizerMenuRenameButton     = "Переименовать группу"
+	organizerBackButton           = "Назад"
+)
+
+func isOrganizer(ctx context.Context, s fsm.Context) bool {
+	var role string
+	if err := s.Data(ctx, userRoleKey, &role); err != nil {
+		return false
+	}
+	return role == sm.Organizer.String()
+}
+
+func (p *Port) sendOrganizerMenu(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	if !isOrganizer(ctx, s) {
+		return c.Send(permissionDeniedText)
+	}
+
+	if err := s.SetState(ctx, organizerMenuHandle); err != nil {
+		return err
+	}
+
+	if err := s.Update(ctx, activityNameKey, ""); err != nil {
+		return err
+	}
+
+	return c.Send(
+		"Панель организатора.",
+		createMarkupWithButtonsFromStrings([]string{
+			organizerMenuActivitiesButton,
+			organizerMenuGroupsButton,
+			organizerMenuBookButton,
+			organizerMenuUnbookButton,
+			organizerMenuRenameButton,
+			organizerMenuWaveButton,
+			organizerMenuWindowButton,
+		}, 2),
+	)
+}
+
+func (p *Port) organizerOverview(ctx context.Context, c telebot.Context, s fsm.Context) (query.Overview, bool, error) {
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return query.Overview{}, false, err
+	}
+
+	overview, err := p.app.Queries.EventOverview.Handle(ctx, query.EventOverview{
+		EventID:  eventID,
+		Username: c.Chat().Username,
+	})
+	if errors.Is(err, sm.ErrPermissionDenied) {
+		return query.Overview{}, false, c.Send(permissionDeniedText)
+	} else if err != nil {
+		return query.Overview{}, false, err
+	}
+
+	return overview, true, nil
+}
+
+func (p *Port) organizerSendActivities(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	overview, ok, err := p.organizerOverview(ctx, c, s)
+	if !ok {
+		return err
+	}
+
+	buttons := make([]string, 0, len(overview.Activities)+1)
+	for _, act := range overview.Activities {
+		buttons = append(buttons, act.Name)
+	}
+	buttons = append(buttons, organizerBackButton)
+
+	if err = s.SetState(ctx, organizerHandleActivityState); err != nil {
+		return err
+	}
+
+	return c.Send(
+		"Выбери точку, чтобы начислять на ней баллы и отмечать группы.",
+		createMarkupWithButtonsFromStrings(buttons, 2),
+	)
+}
+
+func (p *Port) organizerHandleActivity(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	activityName := c.Message().Text
+	if activityName == organizerBackButton {
+		return p.sendOrganizerMenu(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.app.Queries.GetActivity.Handle(ctx, query.GetActivity{EventID: eventID, ActivityName: activityName})
+	if errors.Is(err, sm.ErrActivityNotFound) {
+		return c.Send("🚫 Такой точки нет. Выбери точку из списка.")
+	} else if err != nil {
+		return err
+	}
+
+	if err = s.Update(ctx, activityNameKey, activityName); err != nil {
+		return err
+	}
+
+	return p.sendAdminMenu(c, s)
+}
+
+func (p *Port) organizerSendGroups(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	overview, ok, err := p.organizerOverview(ctx, c, s)
+	if !ok {
+		return err
+	}
+
+	lines := []string{"<b>ГРУППЫ</b>\n"}
+	for i, char := range overview.Characters {
+		taken := 0
+		for _, slot := range char.Slots {
+			if len(slot.Bookings) > 0 {
+				taken++
+			}
+		}
+		lines = append(lines, fmt.Sprintf(
+			"%d. %s — %0.1f, броней: %d, участников: %d",
+			i+1, char.GroupName, char.Rating, taken, len(char.Members),
+		))
+	}
+
+	if err = c.Send(buildMessage("\n", lines...), telebot.ModeHTML); err != nil {
+		return err
+	}
+
+	return p.sendOrganizerMenu(c, s)
+}
+
+func (p *Port) organizerBookSendEnterGroup(c telebot.Context, s fsm.Context) error {
+	return p.organizerSendEnterGroup(c, s, organizerBookHandleGroupState)
+}
+
+func (p *Port) organizerUnbookSendEnterGroup(c telebot.Context, s fsm.Context) error {
+	return p.organizerSendEnterGroup(c, s, organizerUnbookHandleGroupState)
+}
+
+func (p *Port) organizerRenameSendEnterGroup(c telebot.Context, s fsm.Context) error {
+	return p.organizerSendEnterGroup(c, s, organizerRenameHandleGroupState)
+}
+
+func (p *Port) organizerSendEnterGroup(c telebot.Context, s fsm.Context, state fsm.State) error {
+	ctx := context.Background()
+
+	if err := s.SetState(ctx, state); err != nil {
+		return err
+	}
+
+	return c.Send(buildMessage("\n",
+		"Введи название учебной группы в формате:",
+		"<code>СМ1-11Б</code>",
+	),
+		telebot.ModeHTML,
+		createMarkupWithButtonsFromStrings([]string{organizerBackButton}, 1),
+	)
+}
+
+// organizerHandleGroup запоминает введённую группу. Если группы нет, об этом
+// сообщается и ok равно false.
+func (p *Port) organizerHandleGroup(ctx context.Context, c telebot.Context, s fsm.Context) (query.Character, bool, error) {
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return query.Character{}, false, err
+	}
+
+	groupName := c.Message().Text
+	char, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{EventID: eventID, GroupName: groupName})
+	if errors.Is(err, sm.ErrCharacterNotFound) {
+		return query.Character{}, false, c.Send("🚫 Группа не найдена. Попробуй ещё раз.")
+	} else if err != nil {
+		return query.Character{}, false, err
+	}
+
+	if err = s.Update(ctx, organizerGroupNameKey, groupName); err != nil {
+		return query.Character{}, false, err
+	}
+
+	return char, true, nil
+}
+
+func (p *Port) organizerBookHandleGroup(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	if c.Message().Text == organizerBackButton {
+		return p.sendOrganizerMenu(c, s)
+	}
+
+	_, ok, err := p.organizerHandleGroup(ctx, c, s)
+	if !ok {
+		return err
+	}
+
+	overview, ok, err := p.organizerOverview(ctx, c, s)
+	if !ok {
+		return err
+	}
+
+	buttons := make([]string, 0, len(overview.Activities)+1)
+	for _, act := range overview.Activities {
+		if len(act.Slots) > 0 {
+			buttons = append(buttons, act.Name)
+		}
+	}
+	buttons = append(buttons, organizerBackButton)
+
+	if err = s.SetState(ctx, organizerBookHandleActivityState); err != nil {
+		return err
+	}
+
+	return c.Send("Выбери точку.", createMarkupWithButtonsFromStrings(buttons, 2))
+}
+
+func (p *Port) organizerBookHandleActivity(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	activityName := c.Message().Text
+	if activityName == organizerBackButton {
+		return p.sendOrganizerMenu(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	act, err := p.app.Queries.GetActivity.Handle(ctx, query.GetActivity{EventID: eventID, ActivityName: activityName})
+	if errors.Is(err, sm.ErrActivityNotFound) {
+		return c.Send("🚫 Такой точки нет. Выбери точку из списка.")
+	} else if err != nil {
+		return err
+	}
+
+	buttons := make([]string, 0, len(act.Slots)+1)
+	for _, slot := range act.Slots {
+		if len(slot.Bookings) < slot.Capacity {
+			buttons = append(buttons, slot.Start.Format(sm.TimeFormat))
+		}
+	}
+	if len(buttons) == 0 {
+		if err = c.Send("🚫 На этой точке не осталось свободных слотов."); err != nil {
+			return err
+		}
+		return p.sendOrganizerMenu(c, s)
+	}
+	buttons = append(buttons, organizerBackButton)
+
+	if err = s.Update(ctx, organizerActivityNameKey, activityName); err != nil {
+		return err
+	}
+
+	if err = s.SetState(ctx, organizerBookHandleSlotState); err != nil {
+		return err
+	}
+
+	return c.Send("Выбери время. Правила записи при этом не проверяются.", createMarkupWithButtonsFromStrings(buttons, 3))
+}
+
+func (p *Port) organizerBookHandleSlot(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	answer := c.Message().Text
+	if answer == organizerBackButton {
+		return p.sendOrganizerMenu(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	var groupName, activityName string
+	if err = s.Data(ctx, organizerGroupNameKey, &groupName); err != nil {
+		return err
+	}
+	if err = s.Data(ctx, organizerActivityNameKey, &activityName); err != nil {
+		return err
+	}
+
+	act, err := p.app.Queries.GetActivity.Handle(ctx, query.GetActivity{EventID: eventID, ActivityName: activityName})
+	if err != nil {
+		return err
+	}
+
+	slot, ok := findSlotByTime(act.Slots, answer)
+	if !ok {
+		return c.Send("🚫 Выбери время из списка.")
+	}
+
+	err = p.app.Commands.ForceTakeSlot.Handle(ctx, command.ForceTakeSlot{
+		EventID:      eventID,
+		GroupName:    groupName,
+		ActivityName: activityName,
+		Start:        slot.Start,
+		Username:     c.Chat().Username,
+	})
+	if errors.Is(err, sm.ErrSlotHasAlreadyTaken) || errors.Is(err, sm.ErrSlotNotFound) {
+		if err = c.Send("🚫 У группы уже есть бронь на это время или слот заполнен."); err != nil {
+			return err
+		}
+		return p.sendOrganizerMenu(c, s)
+	} else if errors.Is(err, sm.ErrPermissionDenied) {
+		return c.Send(permissionDeniedText)
+	} else if err != nil {
+		return err
+	}
+
+	p.notifyGroup(ctx, c, eventID, groupName, fmt.Sprintf(
+		"❕ Организатор записал твою группу на точку %q на время %s.", activityName, answer,
+	))
+
+	if err = c.Send(fmt.Sprintf("✅ Группа %s записана на точку %q на время %s", groupName, activityName, answer)); err != nil {
+		return err
+	}
+
+	return p.sendOrganizerMenu(c, s)
+}
+
+func (p *Port) organizerUnbookHandleGroup(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	if c.Message().Text == organizerBackButton {
+		return p.sendOrganizerMenu(c, s)
+	}
+
+	char, ok, err := p.organizerHandleGroup(ctx, c, s)
+	if !ok {
+		return err
+	}
+
+	buttons := make([]string, 0, len(char.Slots)+1)
+	for _, slot := range char.Slots {
+		if len(slot.Bookings) > 0 {
+			buttons = append(buttons, organizerBookedSlotText(slot))
+		}
+	}
+	if len(buttons) == 0 {
+		if err = c.Send("У группы нет броней."); err != nil {
+			return err
+		}
+		return p.sendOrganizerMenu(c, s)
+	}
+	buttons = append(buttons, organizerBackButton)
+
+	if err = s.SetState(ctx, organizerUnbookHandleSlotState); err != nil {
+		return err
+	}
+
+	return c.Send("Выбери бронь, которую нужно снять.", createMarkupWithButtonsFromStrings(buttons, 1))
+}
+
+func (p *Port) organizerUnbookHandleSlot(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	answer := c.Message().Text
+	if answer == organizerBackButton {
+		return p.sendOrganizerMenu(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	var groupName string
+	if err = s.Data(ctx, organizerGroupNameKey, &groupName); err != nil {
+		return err
+	}
+
+	char, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{EventID: eventID, GroupName: groupName})
+	if err != nil {
+		return err
+	}
+
+	var slot query.Slot
+	found := false
+	for _, sl := range char.Slots {
+		if len(sl.Bookings) > 0 && organizerBookedSlotText(sl) == answer {
+			slot, found = sl, true
+			break
+		}
+	}
+	if !found {
+		return c.Send("🚫 Выбери бронь из списка.")
+	}
+	activityName := slot.Bookings[0].Whom
+
+	err = p.app.Commands.ForceCancelSlot.Handle(ctx, command.ForceCancelSlot{
+		EventID:      eventID,
+		GroupName:    groupName,
+		ActivityName: activityName,
+		Start:        slot.Start,
+		Username:     c.Chat().Username,
+	})
+	if errors.Is(err, sm.ErrSlotAlreadyVisited) {
+		if err = c.Send("🚫 Группа уже побывала на этой точке, бронь снять нельзя."); err != nil {
+			return err
+		}
+		return p.sendOrganizerMenu(c, s)
+	} else if errors.Is(err, sm.ErrPermissionDenied) {
+		return c.Send(permissionDeniedText)
+	} else if err != nil {
+		return err
+	}
+
+	p.notifyGroup(ctx, c, eventID, groupName, fmt.Sprintf(
+		"❕ Организатор снял бронь твоей группы на точку %q на время %s.",
+		activityName, slot.Start.Format(sm.TimeFormat),
+	))
+
+	if err = c.Send(fmt.Sprintf("✅ Бронь группы %s снята", groupName)); err != nil {
+		return err
+	}
+
+	return p.sendOrganizerMenu(c, s)
+}
+
+func (p *Port) organizerRenameHandleGroup(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	if c.Message().Text == organizerBackButton {
+		return p.sendOrganizerMenu(c, s)
+	}
+
+	_, ok, err := p.organizerHandleGroup(ctx, c, s)
+	if !ok {
+		return err
+	}
+
+	if err = s.SetState(ctx, organizerRenameHandleNameState); err != nil {
+		return err
+	}
+
+	return c.Send(
+		"Введи новое название группы. Брони, оценки и участники группы сохранятся.",
+		createMarkupWithButtonsFromStrings([]string{organizerBackButton}, 1),
+	)
+}
+
+func (p *Port) organizerRenameHandleName(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	newGroupName := strings.TrimSpace(c.Message().Text)
+	if newGroupName == organizerBackButton {
+		return p.sendOrganizerMenu(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	var groupName string
+	if err = s.Data(ctx, organizerGroupNameKey, &groupName); err != nil {
+		return err
+	}
+
+	err = p.app.Commands.RenameCharacter.Handle(ctx, command.RenameCharacter{
+		EventID:      eventID,
+		GroupName:    groupName,
+		NewGroupName: newGroupName,
+		Username:     c.Chat().Username,
+	})
+	var invalidInputErr commonerrs.InvalidInputError
+	if errors.As(err, &invalidInputErr) || errors.Is(err, sm.ErrGroupNameTooLong) {
+		return c.Send("🚫 Название не подходит под формат групп мероприятия. Попробуй ещё раз.")
+	} else if errors.Is(err, sm.ErrSameGroupName) || errors.Is(err, sm.ErrCharacterAlreadyExists) {
+		return c.Send("🚫 Группа с таким названием уже есть. Попробуй ещё раз.")
+	} else if errors.Is(err, sm.ErrPermissionDenied) {
+		return c.Send(permissionDeniedText)
+	} else if err != nil {
+		return err
+	}
+
+	p.notifyGroup(ctx, c, eventID, newGroupName, fmt.Sprintf(
+		"❕ Организатор переименовал твою группу: теперь она называется %s.", newGroupName,
+	))
+
+	if err = c.Send(fmt.Sprintf("✅ Группа %s переименована в %s", groupName, newGroupName)); err != nil {
+		return err
+	}
+
+	return p.sendOrganizerMenu(c, s)
+}
+
+func (p *Port) notifyGroup(ctx context.Context, c telebot.Context, eventID string, groupName string, msg string) {
+	char, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{EventID: eventID, GroupName: groupName})
+	if err != nil {
+		p.log.Error("failed to get character", "group", groupName, "error", err)
+		return
+	}
+	p.sendToCharacter(ctx, c.Bot(), eventID, char, msg)
+}
+
+func organizerBookedSlotText(slot query.Slot) string {
+	return fmt.Sprintf("%s | %s", slot.Start.Format(sm.TimeFormat), slot.Bookings[0].Whom)
+}
+
+func findSlotByTime(slots []query.Slot, text string) (query.Slot, bool) {
+	text = strings.TrimSpace(text)
+	for _, slot := range slots {
+		if slot.Start.Format(sm.TimeFormat) == text {
+			return slot, true
+		}
+	}
+	return query.Slot{}, false
+}
@@ -0,0 +1,173 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/vitaliy-ukiru/fsm-telebot/v2"
+	"gopkg.in/telebot.v3"
+
+	"github.com/zhikh23/sm-instruction/internal/app/command"
+	"github.com/zhikh23/sm-instruction/internal/app/query"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+const penaltyGroupNameKey = "penaltyGroupName"
+const penaltyPointsKey = "penaltyPoints"
+const penaltyBackButton = "Назад"
+
+func (p *Port) penaltySendEnterGroup(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	if err := s.SetState(ctx, penaltyHandleGroupNameState); err != nil {
+		return err
+	}
+
+	return c.Send(buildMessage("\n",
+		"<b>ШТРАФ</b>",
+		"",
+		"Введи название учебной группы в формате:",
+		"<code>СМ1-11Б</code>",
+	),
+		createMarkupWithButtonsFromStrings([]string{penaltyBackButton}, 1), telebot.ModeHTML,
+	)
+}
+
+func (p *Port) penaltyHandleGroupName(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	groupName := c.Message().Text
+	if groupName == penaltyBackButton {
+		return p.sendAdminMenu(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	_, err = p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{EventID: eventID, GroupName: groupName})
+	if errors.Is(err, sm.ErrCharacterNotFound) {
+		return p.awardSendCharacterNotFound(c, s)
+	} else if err != nil {
+		return err
+	}
+
+	if err = s.Update(ctx, penaltyGroupNameKey, groupName); err != nil {
+		return err
+	}
+
+	event, err := p.app.Queries.GetEvent.Handle(ctx, query.GetEvent{EventID: eventID})
+	if err != nil {
+		return err
+	}
+
+	if err = s.SetState(ctx, penaltyHandlePointsState); err != nil {
+		return err
+	}
+
+	return c.Send(
+		fmt.Sprintf("Сколько баллов снять? Не больше %d за одно нарушение.", event.Rules.MaxPenaltyPoints),
+		createMarkupWithButtonsFromStrings([]string{penaltyBackButton}, 1),
+	)
+}
+
+func (p *Port) penaltyHandlePoints(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	text := c.Message().Text
+	if text == penaltyBackButton {
+		return p.sendAdminMenu(c, s)
+	}
+
+	points, err := strconv.Atoi(text)
+	if err != nil || points <= 0 {
+		return c.Send("🚫 Введи целое положительное число баллов.")
+	}
+
+	if err = s.Update(ctx, penaltyPointsKey, points); err != nil {
+		return err
+	}
+
+	if err = s.SetState(ctx, penaltyHandleReasonState); err != nil {
+		return err
+	}
+
+	return c.Send(
+		"Напиши причину штрафа. Её увидит группа.",
+		createMarkupWithButtonsFromStrings([]string{penaltyBackButton}, 1),
+	)
+}
+
+func (p *Port) penaltyHandleReason(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	reason := c.Message().Text
+	if reason == penaltyBackButton {
+		return p.sendAdminMenu(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	activityName, err := extractActivityName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	var groupName string
+	if err = s.Data(ctx, penaltyGroupNameKey, &groupName); err != nil {
+		return fmt.Errorf("failed extract group name: %w", err)
+	}
+
+	var points int
+	if err = s.Data(ctx, penaltyPointsKey, &points); err != nil {
+		return fmt.Errorf("failed extract penalty points: %w", err)
+	}
+
+	err = p.app.Commands.PenalizeCharacter.Handle(ctx, command.PenalizeCharacter{
+		EventID:      eventID,
+		GroupName:    groupName,
+		ActivityName: activityName,
+		Points:       points,
+		Reason:       reason,
+		Username:     c.Chat().Username,
+	})
+	if errors.Is(err, sm.ErrPenaltyTooLarge) {
+		return p.penaltySendRejected(c, s, "🚫 Слишком большой штраф за одно нарушение.")
+	} else if errors.Is(err, sm.ErrPenaltyLimitExceeded) {
+		return p.penaltySendRejected(c, s, fmt.Sprintf(
+			"🚫 Группа %s уже получила наибольшую допустимую сумму штрафов.", groupName,
+		))
+	} else if errors.Is(err, sm.ErrPermissionDenied) {
+		return p.penaltySendRejected(c, s, permissionDeniedText)
+	} else if err != nil {
+		return err
+	}
+
+	if char, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{
+		EventID:   eventID,
+		GroupName: groupName,
+	}); err == nil {
+		p.sendToCharacter(ctx, c.Bot(), eventID, char, fmt.Sprintf(
+			"⚠️ Группе выписан штраф %d б. Причина: %s", points, reason,
+		))
+	}
+
+	if err = c.Send(fmt.Sprintf("✅ Группе %s выписан штраф %d б.", groupName, points)); err != nil {
+		return err
+	}
+
+	return p.sendAdminMenu(c, s)
+}
+
+func (p *Port) penaltySendRejected(c telebot.Context, s fsm.Context, msg string) error {
+	if err := c.Send(msg); err != nil {
+		return err
+	}
+	return p.sendAdminMenu(c, s)
+}
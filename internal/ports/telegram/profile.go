@@ -16,12 +16,17 @@ import (
 func (p *Port) sendProfile(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
-	groupName, err := extractGroupName(ctx, s)
+	eventID, err := p.extractEventID(ctx, s)
 	if err != nil {
 		return err
 	}
 
-	char, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{GroupName: groupName})
+	groupName, err := p.extractGroupName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	char, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{EventID: eventID, GroupName: groupName})
 	if err != nil {
 		return err
 	}
@@ -31,8 +36,15 @@ func (p *Port) sendProfile(c telebot.Context, s fsm.Context) error {
 		"",
 		fmt.Sprintf("Учебная группа: <code>%s</code>", char.GroupName),
 	)
-	if time.Now().Before(*char.End) {
-		remains := char.End.Sub(*char.Start)
+	switch {
+	case char.Start == nil:
+		msg = buildMessage("\n", msg, "", "Инструкция ещё не началась.")
+		if char.ScheduledStart != nil {
+			msg = buildMessage("\n", msg, fmt.Sprintf("Старт твоей волны: <b>%s</b>.", char.ScheduledStart.Format(sm.TimeFormat)))
+		}
+	case time.Now().Before(*char.End):
+		// До старта волны окно Инструкции ещё не тратится.
+		remains := char.End.Sub(maxTime(time.Now(), *char.Start))
 		msg = buildMessage("\n",
 			msg,
 			"",
@@ -43,23 +55,37 @@ func (p *Port) sendProfile(c telebot.Context, s fsm.Context) error {
 				int(remains.Hours()), int(remains.Minutes())%60,
 			),
 		)
-	} else {
+		if char.PausedAt != nil {
+			msg = buildMessage("\n", msg, fmt.Sprintf(
+				"⏸ Инструкция на паузе с %s, время не тратится.", char.PausedAt.Format(sm.TimeFormat),
+			))
+		}
+	default:
 		msg = buildMessage("\n",
 			msg,
-			fmt.Sprintf("Инструкция окончена в <b>%s</b>.", char.Start.Format(sm.TimeFormat)),
+			fmt.Sprintf("Инструкция окончена в <b>%s</b>.", char.End.Format(sm.TimeFormat)),
 		)
 	}
 
+	skills := make([]string, 0, len(char.RatingBreakdown.Skills))
+	for _, skill := range char.RatingBreakdown.Skills {
+		skills = append(skills, fmt.Sprintf("%s <i>%s - %d</i>", skill.Emoji, skill.Skill, skill.Points))
+	}
+
 	msg = buildMessage("\n",
 		msg,
 		"<b>Навыки:</b>",
-		fmt.Sprintf("🛠 <i>Инженерные - %d</i>", char.Skills[sm.Engineering.String()]),
-		fmt.Sprintf("🔭 <i>Исследовательские - %d</i>", char.Skills[sm.Researching.String()]),
-		fmt.Sprintf("🤝 <i>Социальные - %d</i>", char.Skills[sm.Social.String()]),
-		fmt.Sprintf("⚽️ <i>Спортивные - %d</i>", char.Skills[sm.Sportive.String()]),
-		fmt.Sprintf("🔮 <i>Творческие - %d</i>", char.Skills[sm.Creative.String()]),
+		buildMessage("\n", skills...),
 		"",
 		fmt.Sprintf("🏅 Рейтинг: <b>%0.1f</b>", char.Rating),
+		"",
+		formatRatingBreakdown(char.RatingBreakdown),
+		"",
+		"<b>Достижения:</b>",
+		formatAchievements(char.Achievements),
+		"",
+		"<b>Команда:</b>",
+		formatTeam(c.Bot(), char, isCaptain(ctx, s)),
 	)
 
 	if err = c.Send(msg, telebot.ModeHTML); err != nil {
@@ -69,6 +95,51 @@ func (p *Port) sendProfile(c telebot.Context, s fsm.Context) error {
 	return p.sendParticipantMenu(c, s)
 }
 
+// formatRatingBreakdown объясняет участникам, как получился их рейтинг.
+func formatRatingBreakdown(b query.RatingBreakdown) string {
+	general := make([]string, 0)
+	additional := make([]string, 0)
+	for _, s := range b.Skills {
+		term := fmt.Sprintf("%d", s.Counted)
+		if s.Weight != 1 {
+			term = fmt.Sprintf("%d×%g", s.Counted, s.Weight)
+		}
+		if s.Counted < s.Points {
+			term += fmt.Sprintf(" (учтено %d из %d)", s.Counted, s.Points)
+		}
+
+		if s.General {
+			general = append(general, term)
+		} else {
+			additional = append(additional, term)
+		}
+	}
+
+	lines := []string{
+		"<i>Как считается рейтинг:</i>",
+		fmt.Sprintf("Основные навыки: %s = %0.2f", strings.Join(general, " + "), b.Base),
+		fmt.Sprintf("Множитель за дополнительные навыки (%s): ×%0.3f", strings.Join(additional, " + "), b.Factor),
+	}
+	if b.BalanceBonus > 0 {
+		lines = append(lines, fmt.Sprintf("Надбавка за баланс навыков: +%0.2f", b.BalanceBonus))
+	}
+	if b.Penalty > 0 {
+		lines = append(lines, fmt.Sprintf("Штрафы: −%0.2f", b.Penalty))
+	}
+	lines = append(lines, fmt.Sprintf(
+		"Итого: %0.2f × %0.3f + %0.2f − %0.2f = <b>%0.2f</b>", b.Base, b.Factor, b.BalanceBonus, b.Penalty, b.Total,
+	))
+
+	return buildMessage("\n", lines...)
+}
+
 func buildMessage(sep string, lines ...string) string {
 	return strings.Join(lines, sep)
 }
+
+func maxTime(a time.Time, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
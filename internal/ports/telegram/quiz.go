@@ -0,0 +1,366 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/vitaliy-ukiru/fsm-telebot/v2"
+	"gopkg.in/telebot.v3"
+
+	"github.com/zhikh23/sm-instruction/internal/app/command"
+	"github.com/zhikh23/sm-instruction/internal/app/query"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+const quizActivityNameKey = "quizActivityName"
+
+const (
+	quizBackButton  = "Назад"
+	quizLeaveButton = "Выйти из викторины"
+)
+
+func (p *Port) quizSendActivities(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	groupName, err := p.extractGroupName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	quizzes, err := p.app.Queries.AvailableQuizzes.Handle(ctx, query.AvailableQuizzes{
+		EventID:   eventID,
+		GroupName: groupName,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(quizzes) == 0 {
+		return p.additionalSendRejected(c, s, "Сейчас нет викторин, которые твоя группа ещё не прошла.")
+	}
+
+	if err = s.SetState(ctx, quizHandleActivityState); err != nil {
+		return err
+	}
+
+	lines := make([]string, 0, len(quizzes)+2)
+	buttons := make([]string, 0, len(quizzes)+1)
+	lines = append(lines, "<b>ВИКТОРИНЫ</b>", "")
+	for _, quiz := range quizzes {
+		lines = append(lines, fmt.Sprintf("🔹 %s — вопросов: %d", quiz.ActivityName, quiz.Questions))
+		buttons = append(buttons, quiz.ActivityName)
+	}
+	buttons = append(buttons, quizBackButton)
+
+	return c.Send(
+		buildMessage("\n", lines...),
+		createMarkupWithButtonsFromStrings(buttons, 2),
+		telebot.ModeHTML,
+	)
+}
+
+func (p *Port) quizHandleActivity(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	activityName := c.Message().Text
+	if activityName == quizBackButton {
+		return p.sendParticipantMenu(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	groupName, err := p.extractGroupName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	// Если группа уже начала викторину, продолжаем с текущего вопроса.
+	err = p.app.Commands.StartQuiz.Handle(ctx, command.StartQuiz{
+		EventID:      eventID,
+		GroupName:    groupName,
+		ActivityName: activityName,
+		Username:     c.Chat().Username,
+	})
+	if errors.Is(err, sm.ErrQuizNotFound) || errors.Is(err, sm.ErrActivityNotFound) {
+		return c.Send("🚫 Выбери одну из предложенных викторин.")
+	} else if errors.Is(err, sm.ErrGroupHasNotVisited) {
+		return p.additionalSendRejected(c, s, "🚫 Викторину можно пройти только во время забронированного слота на точке.")
+	} else if errors.Is(err, sm.ErrAwardOutsideSlotTime) {
+		return p.additionalSendRejected(c, s, "🚫 Слот твоей группы на этой точке уже закончился.")
+	} else if err != nil && !errors.Is(err, sm.ErrQuizAlreadyStarted) {
+		return err
+	}
+
+	if err = s.Update(ctx, quizActivityNameKey, activityName); err != nil {
+		return err
+	}
+
+	at, err := p.groupQuizAttempt(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	if at.Finished {
+		return p.quizSendResult(c, s, at)
+	}
+
+	return p.quizSendQuestion(c, s, at)
+}
+
+func (p *Port) quizSendQuestion(c telebot.Context, s fsm.Context, at query.QuizAttempt) error {
+	if err := s.SetState(context.Background(), quizHandleAnswerState); err != nil {
+		return err
+	}
+
+	q := at.Question
+	lines := []string{
+		fmt.Sprintf("<b>Вопрос %d/%d</b>", q.Number, at.Total),
+		"",
+		q.Text,
+		"",
+	}
+	if left := time.Until(q.Deadline); left > 0 {
+		lines = append(lines, fmt.Sprintf("⏱ На ответ: %d сек.", int(left.Round(time.Second).Seconds())))
+	} else {
+		lines = append(lines, "⏰ Время на ответ уже вышло.")
+	}
+	if len(q.Options) == 0 {
+		lines = append(lines, "Напиши ответ сообщением.")
+	}
+
+	return c.Send(
+		buildMessage("\n", lines...),
+		createMarkupWithButtonsFromStrings(append(slices.Clone(q.Options), quizLeaveButton), 2),
+		telebot.ModeHTML,
+	)
+}
+
+func (p *Port) quizHandleAnswer(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	answer := c.Message().Text
+	if answer == quizLeaveButton {
+		if err := c.Send("Время на вопросы идёт, даже если выйти из викторины. Вернуться к ней можно из меню."); err != nil {
+			return err
+		}
+		return p.sendParticipantMenu(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	groupName, err := p.extractGroupName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	var activityName string
+	if err = s.Data(ctx, quizActivityNameKey, &activityName); err != nil {
+		return fmt.Errorf("failed extract activity name: %w", err)
+	}
+
+	// Ответы сохраняются и при ошибке начисления, поэтому результаты
+	// показываем в любом случае.
+	answerErr := p.app.Commands.AnswerQuiz.Handle(ctx, command.AnswerQuiz{
+		EventID:      eventID,
+		GroupName:    groupName,
+		ActivityName: activityName,
+		Answer:       answer,
+		Username:     c.Chat().Username,
+	})
+	if errors.Is(answerErr, sm.ErrQuizAlreadyFinished) {
+		if err = c.Send("Викторина уже пройдена: на последний вопрос ответил другой участник группы."); err != nil {
+			return err
+		}
+	}
+
+	at, err := p.groupQuizAttempt(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	if answerErr == nil && at.LastAnswer != nil {
+		if err = c.Send(quizAnswerFeedback(*at.LastAnswer)); err != nil {
+			return err
+		}
+	}
+
+	if !at.Finished {
+		if answerErr != nil {
+			return answerErr
+		}
+		return p.quizSendQuestion(c, s, at)
+	}
+
+	if at.Awarded {
+		p.unlockAchievements(ctx, c.Bot(), eventID, groupName)
+	} else if answerErr != nil && !errors.Is(answerErr, sm.ErrQuizAlreadyFinished) {
+		p.log.Error("failed to award quiz", "group", groupName, "activity", activityName, "error", answerErr)
+	}
+
+	return p.quizSendResult(c, s, at)
+}
+
+func quizAnswerFeedback(a query.QuizAnswer) string {
+	if a.TimedOut {
+		return "⏰ Время на ответ вышло, ответ не засчитан."
+	} else if a.Correct {
+		return "✅ Верно!"
+	}
+	return "❌ Неверно."
+}
+
+func (p *Port) quizSendResult(c telebot.Context, s fsm.Context, at query.QuizAttempt) error {
+	ctx := context.Background()
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	skills, err := p.eventSkills(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	lines := []string{
+		fmt.Sprintf("<b>ВИКТОРИНА %s ПРОЙДЕНА</b>", at.ActivityName),
+		fmt.Sprintf("Верных ответов: %d из %d.", at.Correct, at.Total),
+		"",
+	}
+	lines = append(lines, quizScoreLines(skills, at.Scores)...)
+	if !at.Awarded {
+		lines = append(lines, "", "⚠️ Баллы не начислены автоматически. Обратись к администратору точки.")
+	}
+
+	if err = c.Send(buildMessage("\n", lines...), telebot.ModeHTML); err != nil {
+		return err
+	}
+
+	return p.sendParticipantMenu(c, s)
+}
+
+func quizScoreLines(skills []query.Skill, scores []query.QuizScore) []string {
+	res := make([]string, len(scores))
+	for i, score := range scores {
+		res[i] = fmt.Sprintf(
+			"%s: %d/%d — +%d б.",
+			skillLabels(skills, []string{score.Skill})[0], score.Correct, score.Total, score.Points,
+		)
+	}
+	return res
+}
+
+func (p *Port) groupQuizAttempt(ctx context.Context, s fsm.Context) (query.QuizAttempt, error) {
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return query.QuizAttempt{}, err
+	}
+
+	groupName, err := p.extractGroupName(ctx, s)
+	if err != nil {
+		return query.QuizAttempt{}, err
+	}
+
+	var activityName string
+	if err = s.Data(ctx, quizActivityNameKey, &activityName); err != nil {
+		return query.QuizAttempt{}, fmt.Errorf("failed extract activity name: %w", err)
+	}
+
+	return p.app.Queries.GroupQuizAttempt.Handle(ctx, query.GroupQuizAttempt{
+		EventID:      eventID,
+		GroupName:    groupName,
+		ActivityName: activityName,
+	})
+}
+
+// quizSendResults показывает администратору результаты викторины его точки
+// по всем группам, в том числе ещё не закончившим.
+func (p *Port) quizSendResults(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	activityName, err := extractActivityName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	attempts, err := p.app.Queries.QuizResults.Handle(ctx, query.QuizResults{
+		EventID:      eventID,
+		Username:     c.Chat().Username,
+		ActivityName: activityName,
+	})
+	if errors.Is(err, sm.ErrPermissionDenied) {
+		return c.Send(permissionDeniedText)
+	} else if errors.Is(err, sm.ErrQuizNotFound) {
+		return c.Send("🚫 У этой точки нет викторины.")
+	} else if err != nil {
+		return err
+	}
+
+	skills, err := p.eventSkills(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	lines := []string{fmt.Sprintf("<b>РЕЗУЛЬТАТЫ ВИКТОРИНЫ %s</b>", activityName)}
+	if len(attempts) == 0 {
+		lines = append(lines, "", "Ни одна группа ещё не начинала викторину.")
+	}
+	for _, at := range attempts {
+		lines = append(lines, "")
+		switch {
+		case !at.Finished:
+			lines = append(lines, fmt.Sprintf(
+				"⏳ %s — в процессе, отвечено %d из %d (начало в %s)",
+				at.GroupName, at.Answered, at.Total, at.StartedAt.Format(sm.TimeFormat),
+			))
+		case at.Awarded:
+			lines = append(lines, fmt.Sprintf("✅ %s — %d/%d верных, баллы начислены", at.GroupName, at.Correct, at.Total))
+		default:
+			lines = append(lines, fmt.Sprintf("⚠️ %s — %d/%d верных, баллы не начислены", at.GroupName, at.Correct, at.Total))
+		}
+		lines = append(lines, quizScoreLines(skills, at.Scores)...)
+	}
+
+	if err = c.Send(buildMessage("\n", lines...), telebot.ModeHTML); err != nil {
+		return err
+	}
+
+	return p.sendAdminMenu(c, s)
+}
+
+// hasQuiz проверяет, есть ли у точки викторина, чтобы показать администратору
+// кнопку с результатами.
+func (p *Port) hasQuiz(c telebot.Context, s fsm.Context, act query.Activity) bool {
+	ctx := context.Background()
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return false
+	}
+
+	_, err = p.app.Queries.QuizResults.Handle(ctx, query.QuizResults{
+		EventID:      eventID,
+		Username:     c.Chat().Username,
+		ActivityName: act.Name,
+	})
+	return err == nil
+}
@@ -13,12 +13,17 @@ import (
 func (p *Port) sendParticipantRating(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
-	groupName, err := extractGroupName(ctx, s)
+	eventID, err := p.extractEventID(ctx, s)
 	if err != nil {
 		return err
 	}
 
-	chars, err := p.app.Queries.Rating.Handle(ctx, query.Rating{})
+	groupName, err := p.extractGroupName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	chars, err := p.app.Queries.Rating.Handle(ctx, query.Rating{EventID: eventID})
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,146 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/vitaliy-ukiru/fsm-telebot/v2"
+	"gopkg.in/telebot.v3"
+
+	"github.com/zhikh23/sm-instruction/internal/app/command"
+	"github.com/zhikh23/sm-instruction/internal/app/query"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+const revokeGradeGroupNameKey = "revokeGradeGroupName"
+const revokeGradeIDKey = "revokeGradeID"
+const revokeGradeBackButton = "Назад"
+
+func (p *Port) revokeGradeSendLast(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	activityName, err := extractActivityName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	last, err := p.app.Queries.LastActivityGrade.Handle(ctx, query.LastActivityGrade{
+		EventID:      eventID,
+		ActivityName: activityName,
+	})
+	if errors.Is(err, sm.ErrGradeNotFound) {
+		if err = c.Send("Пока нечего отменять: ты ещё не начислял баллов."); err != nil {
+			return err
+		}
+		return p.sendAdminMenu(c, s)
+	} else if err != nil {
+		return err
+	}
+
+	if err = s.Update(ctx, revokeGradeGroupNameKey, last.GroupName); err != nil {
+		return err
+	}
+
+	if err = s.Update(ctx, revokeGradeIDKey, last.Grade.ID); err != nil {
+		return err
+	}
+
+	if err = s.SetState(ctx, revokeGradeHandleReasonState); err != nil {
+		return err
+	}
+
+	msg := buildMessage("\n",
+		"<b>ОТМЕНА НАЧИСЛЕНИЯ</b>",
+		"",
+		fmt.Sprintf(
+			"Последнее начисление: %s | %s - %s - <i>%d б.</i>",
+			last.Grade.Time.Format(sm.TimeFormat), last.GroupName, last.Grade.SkillType, last.Grade.Points,
+		),
+		"",
+		"Напиши причину отмены.",
+	)
+
+	return c.Send(
+		msg, telebot.ModeHTML,
+		createMarkupWithButtonsFromStrings([]string{revokeGradeBackButton}, 1),
+	)
+}
+
+func (p *Port) revokeGradeHandleReason(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	reason := c.Message().Text
+	if reason == revokeGradeBackButton {
+		return p.sendAdminMenu(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	activityName, err := extractActivityName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	groupName, err := revokeGradeExtractGroupName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	gradeID, err := revokeGradeExtractGradeID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	err = p.app.Commands.RevokeGrade.Handle(ctx, command.RevokeGrade{
+		EventID:      eventID,
+		GroupName:    groupName,
+		ActivityName: activityName,
+		GradeID:      gradeID,
+		Reason:       reason,
+		Username:     c.Chat().Username,
+	})
+	if errors.Is(err, sm.ErrGradeAlreadyRevoked) || errors.Is(err, sm.ErrGradeNotFound) {
+		if err = c.Send("🚫 Это начисление уже отменено."); err != nil {
+			return err
+		}
+		return p.sendAdminMenu(c, s)
+	} else if errors.Is(err, sm.ErrPermissionDenied) {
+		if err = c.Send(permissionDeniedText); err != nil {
+			return err
+		}
+		return p.sendAdminMenu(c, s)
+	} else if err != nil {
+		return err
+	}
+
+	if err = c.Send(fmt.Sprintf("✅ Начисление группе %s отменено", groupName)); err != nil {
+		return err
+	}
+
+	return p.sendAdminMenu(c, s)
+}
+
+func revokeGradeExtractGroupName(ctx context.Context, s fsm.Context) (string, error) {
+	var groupName string
+	if err := s.Data(ctx, revokeGradeGroupNameKey, &groupName); err != nil {
+		return "", fmt.Errorf("failed extract group name: %w", err)
+	}
+	return groupName, nil
+}
+
+func revokeGradeExtractGradeID(ctx context.Context, s fsm.Context) (string, error) {
+	var gradeID string
+	if err := s.Data(ctx, revokeGradeIDKey, &gradeID); err != nil {
+		return "", fmt.Errorf("failed extract grade id: %w", err)
+	}
+	return gradeID, nil
+}
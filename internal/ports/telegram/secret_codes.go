@@ -0,0 +1,330 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/vitaliy-ukiru/fsm-telebot/v2"
+	"gopkg.in/telebot.v3"
+
+	"github.com/zhikh23/sm-instruction/internal/app/command"
+	"github.com/zhikh23/sm-instruction/internal/app/query"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+const secretCodeMaxRedemptionsKey = "secretCodeMaxRedemptions"
+const secretCodeSkillKey = "secretCodeSkill"
+
+const (
+	secretCodesOneTimeButton  = "Одноразовый код"
+	secretCodesPerGroupButton = "Код для каждой группы"
+	secretCodesBackButton     = "Назад"
+)
+
+func (p *Port) secretCodesSendMenu(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	activityName, err := extractActivityName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	codes, err := p.app.Queries.SecretCodes.Handle(ctx, query.SecretCodes{
+		EventID:      eventID,
+		Username:     c.Chat().Username,
+		ActivityName: activityName,
+	})
+	if errors.Is(err, sm.ErrPermissionDenied) {
+		return c.Send(permissionDeniedText)
+	} else if err != nil {
+		return err
+	}
+
+	lines := []string{"<b>СЕКРЕТНЫЕ КОДЫ</b>", ""}
+	if len(codes) == 0 {
+		lines = append(lines, "Кодов пока нет.")
+	}
+	for _, code := range codes {
+		limit := "∞"
+		if code.MaxRedemptions > 0 {
+			limit = strconv.Itoa(code.MaxRedemptions)
+		}
+		lines = append(lines, fmt.Sprintf(
+			"🔹 <code>%s</code> — %d б. в навык %q, введён %d/%s",
+			code.Code, code.Points, code.Skill, code.Redemptions, limit,
+		))
+	}
+
+	if err = s.SetState(ctx, secretCodesHandleActionState); err != nil {
+		return err
+	}
+
+	return c.Send(
+		buildMessage("\n", lines...),
+		createMarkupWithButtonsFromStrings([]string{
+			secretCodesOneTimeButton,
+			secretCodesPerGroupButton,
+			secretCodesBackButton,
+		}, 2),
+		telebot.ModeHTML,
+	)
+}
+
+func (p *Port) secretCodesHandleAction(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	// Одноразовый код вводит только одна группа, код для каждой группы — каждая
+	// группа по одному разу.
+	var maxRedemptions int
+	switch c.Message().Text {
+	case secretCodesOneTimeButton:
+		maxRedemptions = 1
+	case secretCodesPerGroupButton:
+		maxRedemptions = 0
+	case secretCodesBackButton:
+		return p.sendAdminMenu(c, s)
+	default:
+		return c.Send("🚫 Выбери одно из предложенных действий.")
+	}
+
+	if err := s.Update(ctx, secretCodeMaxRedemptionsKey, maxRedemptions); err != nil {
+		return err
+	}
+
+	return p.secretCodesSendEnterSkill(c, s)
+}
+
+func (p *Port) secretCodesSendEnterSkill(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	activityName, err := extractActivityName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	act, err := p.app.Queries.AdminActivity.Handle(ctx, query.AdminActivity{
+		EventID:      eventID,
+		Username:     c.Chat().Username,
+		ActivityName: activityName,
+	})
+	if err != nil {
+		return err
+	}
+
+	skills, err := p.eventSkills(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.SetState(ctx, secretCodesHandleSkillState); err != nil {
+		return err
+	}
+
+	return c.Send(
+		"Выбери навык, в который код начислит баллы.",
+		createMarkupWithButtonsFromStrings(append(skillLabels(skills, act.Skills), secretCodesBackButton), 2),
+	)
+}
+
+func (p *Port) secretCodesHandleSkill(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	if c.Message().Text == secretCodesBackButton {
+		return p.secretCodesSendMenu(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	skills, err := p.eventSkills(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	activityName, err := extractActivityName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	act, err := p.app.Queries.AdminActivity.Handle(ctx, query.AdminActivity{
+		EventID:      eventID,
+		Username:     c.Chat().Username,
+		ActivityName: activityName,
+	})
+	if err != nil {
+		return err
+	}
+
+	skill, ok := skillByLabel(skills, c.Message().Text)
+	if !ok || !slices.Contains(act.Skills, skill) {
+		return c.Send("🚫 Выбери один из предложенных навыков.")
+	}
+
+	if err = s.Update(ctx, secretCodeSkillKey, skill); err != nil {
+		return err
+	}
+
+	options := make([]string, 0, act.MaxPoints+1)
+	for i := 1; i <= act.MaxPoints; i++ {
+		options = append(options, strconv.Itoa(i))
+	}
+	options = append(options, secretCodesBackButton)
+
+	if err = s.SetState(ctx, secretCodesHandlePointsState); err != nil {
+		return err
+	}
+
+	return c.Send(
+		"Сколько баллов получит группа за ввод кода?",
+		createMarkupWithButtonsFromStrings(options, 4),
+	)
+}
+
+func (p *Port) secretCodesHandlePoints(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	text := c.Message().Text
+	if text == secretCodesBackButton {
+		return p.secretCodesSendMenu(c, s)
+	}
+
+	points, err := strconv.Atoi(text)
+	if err != nil || points <= 0 {
+		return c.Send("🚫 Введи целое положительное число баллов.")
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	activityName, err := extractActivityName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	var maxRedemptions int
+	if err = s.Data(ctx, secretCodeMaxRedemptionsKey, &maxRedemptions); err != nil {
+		return fmt.Errorf("failed extract max redemptions: %w", err)
+	}
+
+	var skill string
+	if err = s.Data(ctx, secretCodeSkillKey, &skill); err != nil {
+		return fmt.Errorf("failed extract skill: %w", err)
+	}
+
+	code := sm.GenerateSecretCode()
+	err = p.app.Commands.IssueSecretCode.Handle(ctx, command.IssueSecretCode{
+		EventID:             eventID,
+		ActivityName:        activityName,
+		Code:                code,
+		SkillType:           skill,
+		Points:              points,
+		MaxRedemptions:      maxRedemptions,
+		MaxGroupRedemptions: 1,
+		Username:            c.Chat().Username,
+	})
+	if errors.Is(err, sm.ErrMaxPointsExceeded) {
+		return c.Send("🚫 Кажется это некорректное количество баллов.")
+	} else if errors.Is(err, sm.ErrSecretCodeAlreadyExists) {
+		return c.Send("🚫 Не удалось выпустить код, попробуй ещё раз.")
+	} else if errors.Is(err, sm.ErrPermissionDenied) {
+		if err = c.Send(permissionDeniedText); err != nil {
+			return err
+		}
+		return p.sendAdminMenu(c, s)
+	} else if err != nil {
+		return err
+	}
+
+	if err = c.Send(buildMessage("\n",
+		"✅ Код выпущен:",
+		fmt.Sprintf("<code>%s</code>", code),
+		"",
+		fmt.Sprintf("За его ввод группа получит %d б. в навык %q.", points, skill),
+	), telebot.ModeHTML); err != nil {
+		return err
+	}
+
+	return p.secretCodesSendMenu(c, s)
+}
+
+func (p *Port) additionalHandleCode(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	code := c.Message().Text
+	if code == additionalBackButton {
+		return p.sendParticipantMenu(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	groupName, err := p.extractGroupName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	var activityName string
+	if err = s.Data(ctx, additionalActivityNameKey, &activityName); err != nil {
+		return fmt.Errorf("failed extract activity name: %w", err)
+	}
+
+	err = p.app.Commands.RedeemSecretCode.Handle(ctx, command.RedeemSecretCode{
+		EventID:      eventID,
+		GroupName:    groupName,
+		ActivityName: activityName,
+		Code:         code,
+		Username:     c.Chat().Username,
+	})
+	if errors.Is(err, sm.ErrSecretCodeNotFound) {
+		return c.Send("🚫 Неверный код. Попробуй ещё раз.")
+	} else if errors.Is(err, sm.ErrTooManyCodeAttempts) {
+		event, err := p.app.Queries.GetEvent.Handle(ctx, query.GetEvent{EventID: eventID})
+		if err != nil {
+			return err
+		}
+		return p.additionalSendRejected(c, s, fmt.Sprintf(
+			"🚫 Слишком много неверных кодов. Ввод заблокирован на %d минут.",
+			int(event.Rules.CodeLockout.Minutes()),
+		))
+	} else if errors.Is(err, sm.ErrSecretCodeExhausted) {
+		return p.additionalSendRejected(c, s, "🚫 Этот код уже использован.")
+	} else if errors.Is(err, sm.ErrSecretCodeAlreadyRedeemed) {
+		return p.additionalSendRejected(c, s, "🚫 Твоя группа уже вводила этот код.")
+	} else if err != nil {
+		return err
+	}
+
+	p.unlockAchievements(ctx, c.Bot(), eventID, groupName)
+
+	if err = c.Send("✅ Код принят, баллы начислены!"); err != nil {
+		return err
+	}
+
+	return p.sendParticipantMenu(c, s)
+}
+
+func (p *Port) additionalSendRejected(c telebot.Context, s fsm.Context, msg string) error {
+	if err := c.Send(msg); err != nil {
+		return err
+	}
+	return p.sendParticipantMenu(c, s)
+}
@@ -0,0 +1,55 @@
+package telegram
+
+import (
+	"context"
+
+	"github.com/zhikh23/sm-instruction/internal/app/query"
+)
+
+func (p *Port) eventSkills(ctx context.Context, eventID string) ([]query.Skill, error) {
+	event, err := p.app.Queries.GetEvent.Handle(ctx, query.GetEvent{EventID: eventID})
+	if err != nil {
+		return nil, err
+	}
+	return event.Skills, nil
+}
+
+// skillLabel возвращает название навыка вместе с эмодзи из каталога мероприятия.
+func skillLabel(emoji string, name string) string {
+	if emoji == "" {
+		return name
+	}
+	return emoji + " " + name
+}
+
+func skillLabels(skills []query.Skill, names []string) []string {
+	res := make([]string, len(names))
+	for i, name := range names {
+		res[i] = name
+		for _, s := range skills {
+			if s.Name == name {
+				res[i] = skillLabel(s.Emoji, s.Name)
+				break
+			}
+		}
+	}
+	return res
+}
+
+// skillByLabel находит навык по подписи кнопки или по названию.
+func skillByLabel(skills []query.Skill, label string) (string, bool) {
+	for _, s := range skills {
+		if label == s.Name || label == skillLabel(s.Emoji, s.Name) {
+			return s.Name, true
+		}
+	}
+	return "", false
+}
+
+func skillEmojis(b query.RatingBreakdown) map[string]string {
+	res := make(map[string]string, len(b.Skills))
+	for _, s := range b.Skills {
+		res[s.Skill] = s.Emoji
+	}
+	return res
+}
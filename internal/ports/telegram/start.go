@@ -15,41 +15,76 @@ import (
 func (p *Port) StartHandleCommand(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
-	user, err := p.app.Queries.GetUser.Handle(ctx, query.GetUser{Username: c.Chat().Username})
-	if errors.Is(err, sm.ErrUserNotFound) {
+	event, err := p.app.Queries.CurrentEvent.Handle(ctx, query.CurrentEvent{})
+	if errors.Is(err, sm.ErrEventNotFound) {
 		return p.sendUserNotFound(c, s)
 	} else if err != nil {
 		return err
 	}
+	eventID := event.ID
 
-	if user.Role == "administrator" {
-		act, err := p.app.Queries.AdminActivity.Handle(ctx, query.AdminActivity{Username: c.Chat().Username})
-		if err != nil {
-			return err
-		}
+	if err = s.Update(ctx, eventIDKey, eventID); err != nil {
+		return err
+	}
 
-		if err = s.Update(ctx, activityNameKey, act.Name); err != nil {
+	if inviteCode := c.Message().Payload; inviteCode != "" {
+		if err = p.joinTeam(ctx, c, eventID, inviteCode); err != nil {
 			return err
 		}
+	}
+
+	user, err := p.app.Queries.GetUser.Handle(ctx, query.GetUser{EventID: eventID, Username: c.Chat().Username})
+	if errors.Is(err, sm.ErrUserNotFound) {
+		return p.sendUserNotFound(c, s)
+	} else if err != nil {
+		return err
+	}
+
+	err = p.app.Commands.RegisterChat.Handle(ctx, command.RegisterChat{
+		EventID:  eventID,
+		Username: user.Username,
+		ChatID:   c.Chat().ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = s.Update(ctx, userRoleKey, user.Role); err != nil {
+		return err
+	}
 
+	if user.Role == sm.Organizer.String() {
+		return p.sendOrganizerMenu(c, s)
+	}
+
+	if user.Role == "administrator" {
 		return p.sendAdminMenu(c, s)
 	}
 
 	char, err := p.app.Queries.CharacterByUsername.Handle(ctx, query.CharacterByUsername{
+		EventID:  eventID,
 		Username: c.Chat().Username,
 	})
 	if err != nil {
 		return err
 	}
 
-	if err = s.Update(ctx, groupNameKey, char.GroupName); err != nil {
+	if err = s.Update(ctx, characterIDKey, char.ID); err != nil {
 		return err
 	}
 
+	role := teamRoleOf(char, c.Chat().Username)
+	if err = s.Update(ctx, teamRoleKey, role); err != nil {
+		return err
+	}
+
+	// Инструкцию начинает капитан, остальные участники только наблюдают.
 	err = p.app.Commands.StartInstruction.Handle(ctx, command.StartInstruction{
+		EventID:   eventID,
 		GroupName: char.GroupName,
+		Username:  c.Chat().Username,
 	})
-	if err != nil {
+	if err != nil && !errors.Is(err, sm.ErrNotCaptain) {
 		return err
 	}
 
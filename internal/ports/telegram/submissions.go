@@ -0,0 +1,418 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/vitaliy-ukiru/fsm-telebot/v2"
+	"gopkg.in/telebot.v3"
+
+	"github.com/zhikh23/sm-instruction/internal/app/command"
+	"github.com/zhikh23/sm-instruction/internal/app/query"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+const submissionIDKey = "submissionID"
+const submissionGroupNameKey = "submissionGroupName"
+const submissionSkillKey = "submissionSkill"
+
+const (
+	submissionsApproveButton = "Принять"
+	submissionsRejectButton  = "Отклонить"
+	submissionsBackButton    = "Назад"
+)
+
+func (p *Port) additionalSendEnterProof(c telebot.Context, s fsm.Context) error {
+	if err := s.SetState(context.Background(), additionalHandleProofState); err != nil {
+		return err
+	}
+
+	return c.Send(
+		"Отправь решение задания: фото, видео или текст. Его проверит администратор.",
+		createMarkupWithButtonsFromStrings([]string{additionalBackButton}, 1),
+	)
+}
+
+func (p *Port) additionalHandleProof(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	m := c.Message()
+	if m.Text == additionalBackButton {
+		return p.sendParticipantMenu(c, s)
+	}
+
+	kind, text, fileID := sm.ProofText.String(), m.Text, ""
+	if m.Photo != nil {
+		kind, text, fileID = sm.ProofPhoto.String(), m.Caption, m.Photo.FileID
+	} else if m.Video != nil {
+		kind, text, fileID = sm.ProofVideo.String(), m.Caption, m.Video.FileID
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	groupName, err := p.extractGroupName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	var activityName string
+	if err = s.Data(ctx, additionalActivityNameKey, &activityName); err != nil {
+		return fmt.Errorf("failed extract activity name: %w", err)
+	}
+
+	err = p.app.Commands.SubmitProof.Handle(ctx, command.SubmitProof{
+		EventID:      eventID,
+		GroupName:    groupName,
+		ActivityName: activityName,
+		Kind:         kind,
+		Text:         text,
+		FileID:       fileID,
+		Username:     c.Chat().Username,
+	})
+	if errors.Is(err, sm.ErrSubmissionAlreadyPending) {
+		return p.additionalSendRejected(c, s, "🚫 Предыдущее решение группы ещё не проверено. Дождись результата.")
+	} else if errors.Is(err, sm.ErrSubmissionsNotAccepted) {
+		return p.additionalSendRejected(c, s, "🚫 Решения этого задания не принимаются в боте.")
+	} else if err != nil {
+		return err
+	}
+
+	if act, err := p.app.Queries.GetActivity.Handle(ctx, query.GetActivity{
+		EventID:      eventID,
+		ActivityName: activityName,
+	}); err == nil {
+		for _, admin := range act.Admins {
+			p.sendToUser(ctx, c.Bot(), eventID, admin.Username, fmt.Sprintf(
+				"📥 Группа %s отправила решение задания %q. Проверь его в панели администратора.",
+				groupName, act.FullName,
+			))
+		}
+	}
+
+	if err = c.Send("✅ Решение отправлено на проверку. Мы сообщим, когда его проверят."); err != nil {
+		return err
+	}
+
+	return p.sendParticipantMenu(c, s)
+}
+
+// submissionsSendNext показывает администратору самое старое непроверенное
+// решение по его активности.
+func (p *Port) submissionsSendNext(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	activityName, err := extractActivityName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	submissions, err := p.app.Queries.PendingSubmissions.Handle(ctx, query.PendingSubmissions{
+		EventID:      eventID,
+		Username:     c.Chat().Username,
+		ActivityName: activityName,
+	})
+	if errors.Is(err, sm.ErrPermissionDenied) {
+		return c.Send(permissionDeniedText)
+	} else if err != nil {
+		return err
+	}
+
+	if len(submissions) == 0 {
+		if err = c.Send("✅ Все решения проверены."); err != nil {
+			return err
+		}
+		return p.sendAdminMenu(c, s)
+	}
+
+	sub := submissions[0]
+	if err = s.Update(ctx, submissionIDKey, sub.ID); err != nil {
+		return err
+	}
+	if err = s.Update(ctx, submissionGroupNameKey, sub.GroupName); err != nil {
+		return err
+	}
+
+	if err = s.SetState(ctx, submissionsHandleActionState); err != nil {
+		return err
+	}
+
+	caption := buildMessage("\n",
+		fmt.Sprintf("<b>РЕШЕНИЕ ГРУППЫ %s</b>", sub.GroupName),
+		fmt.Sprintf("Отправил @%s в %s. В очереди: %d.", sub.Username, sub.SubmittedAt.Format(sm.TimeFormat), len(submissions)),
+		"",
+		sub.Text,
+	)
+	markup := createMarkupWithButtonsFromStrings([]string{
+		submissionsApproveButton,
+		submissionsRejectButton,
+		submissionsBackButton,
+	}, 2)
+
+	switch sub.Kind {
+	case sm.ProofPhoto.String():
+		return c.Send(&telebot.Photo{File: telebot.File{FileID: sub.FileID}, Caption: caption}, markup, telebot.ModeHTML)
+	case sm.ProofVideo.String():
+		return c.Send(&telebot.Video{File: telebot.File{FileID: sub.FileID}, Caption: caption}, markup, telebot.ModeHTML)
+	}
+	return c.Send(caption, markup, telebot.ModeHTML)
+}
+
+func (p *Port) submissionsHandleAction(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	switch c.Message().Text {
+	case submissionsApproveButton:
+		return p.submissionsSendEnterSkill(c, s)
+	case submissionsRejectButton:
+		if err := s.SetState(ctx, submissionsHandleReasonState); err != nil {
+			return err
+		}
+		return c.Send(
+			"Напиши причину отказа. Её увидит группа.",
+			createMarkupWithButtonsFromStrings([]string{submissionsBackButton}, 1),
+		)
+	case submissionsBackButton:
+		return p.sendAdminMenu(c, s)
+	}
+	return c.Send("🚫 Выбери одно из предложенных действий.")
+}
+
+func (p *Port) submissionsSendEnterSkill(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	activityName, err := extractActivityName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	act, err := p.app.Queries.AdminActivity.Handle(ctx, query.AdminActivity{
+		EventID:      eventID,
+		Username:     c.Chat().Username,
+		ActivityName: activityName,
+	})
+	if err != nil {
+		return err
+	}
+
+	skills, err := p.eventSkills(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.SetState(ctx, submissionsHandleSkillState); err != nil {
+		return err
+	}
+
+	return c.Send(
+		"Выбери навык, в который начислить баллы за решение.",
+		createMarkupWithButtonsFromStrings(append(skillLabels(skills, act.Skills), submissionsBackButton), 2),
+	)
+}
+
+func (p *Port) submissionsHandleSkill(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	if c.Message().Text == submissionsBackButton {
+		return p.submissionsSendNext(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	skills, err := p.eventSkills(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	activityName, err := extractActivityName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	act, err := p.app.Queries.AdminActivity.Handle(ctx, query.AdminActivity{
+		EventID:      eventID,
+		Username:     c.Chat().Username,
+		ActivityName: activityName,
+	})
+	if err != nil {
+		return err
+	}
+
+	skill, ok := skillByLabel(skills, c.Message().Text)
+	if !ok || !slices.Contains(act.Skills, skill) {
+		return c.Send("🚫 Выбери один из предложенных навыков.")
+	}
+
+	if err = s.Update(ctx, submissionSkillKey, skill); err != nil {
+		return err
+	}
+
+	options := make([]string, 0, act.MaxPoints+1)
+	for i := 1; i <= act.MaxPoints; i++ {
+		options = append(options, strconv.Itoa(i))
+	}
+	options = append(options, submissionsBackButton)
+
+	if err = s.SetState(ctx, submissionsHandlePointsState); err != nil {
+		return err
+	}
+
+	return c.Send(
+		"Сколько баллов начислить за решение?",
+		createMarkupWithButtonsFromStrings(options, 4),
+	)
+}
+
+func (p *Port) submissionsHandlePoints(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	text := c.Message().Text
+	if text == submissionsBackButton {
+		return p.submissionsSendNext(c, s)
+	}
+
+	points, err := strconv.Atoi(text)
+	if err != nil || points <= 0 {
+		return c.Send("🚫 Введи целое положительное число баллов.")
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	activityName, err := extractActivityName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	var submissionID, groupName, skill string
+	if err = s.Data(ctx, submissionIDKey, &submissionID); err != nil {
+		return fmt.Errorf("failed extract submission id: %w", err)
+	}
+	if err = s.Data(ctx, submissionGroupNameKey, &groupName); err != nil {
+		return fmt.Errorf("failed extract group name: %w", err)
+	}
+	if err = s.Data(ctx, submissionSkillKey, &skill); err != nil {
+		return fmt.Errorf("failed extract skill: %w", err)
+	}
+
+	err = p.app.Commands.ApproveSubmission.Handle(ctx, command.ApproveSubmission{
+		EventID:      eventID,
+		SubmissionID: submissionID,
+		SkillType:    skill,
+		Points:       points,
+		Username:     c.Chat().Username,
+	})
+	if errors.Is(err, sm.ErrMaxPointsExceeded) {
+		return c.Send("🚫 Кажется это некорректное количество баллов.")
+	} else if errors.Is(err, sm.ErrSubmissionAlreadyReviewed) || errors.Is(err, sm.ErrSubmissionNotFound) {
+		return p.submissionsSendAlreadyReviewed(c, s)
+	} else if errors.Is(err, sm.ErrPermissionDenied) {
+		if err = c.Send(permissionDeniedText); err != nil {
+			return err
+		}
+		return p.sendAdminMenu(c, s)
+	} else if err != nil {
+		return err
+	}
+
+	p.submissionsNotifyGroup(ctx, c.Bot(), eventID, groupName, fmt.Sprintf(
+		"✅ Решение задания %q принято: +%d б. в навык %q.", activityName, points, skill,
+	))
+	p.unlockAchievements(ctx, c.Bot(), eventID, groupName)
+
+	if err = c.Send(fmt.Sprintf("✅ Решение группы %s принято, начислено %d б.", groupName, points)); err != nil {
+		return err
+	}
+
+	return p.submissionsSendNext(c, s)
+}
+
+func (p *Port) submissionsHandleReason(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	reason := c.Message().Text
+	if reason == submissionsBackButton {
+		return p.submissionsSendNext(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	activityName, err := extractActivityName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	var submissionID, groupName string
+	if err = s.Data(ctx, submissionIDKey, &submissionID); err != nil {
+		return fmt.Errorf("failed extract submission id: %w", err)
+	}
+	if err = s.Data(ctx, submissionGroupNameKey, &groupName); err != nil {
+		return fmt.Errorf("failed extract group name: %w", err)
+	}
+
+	err = p.app.Commands.RejectSubmission.Handle(ctx, command.RejectSubmission{
+		EventID:      eventID,
+		SubmissionID: submissionID,
+		Reason:       reason,
+		Username:     c.Chat().Username,
+	})
+	if errors.Is(err, sm.ErrSubmissionAlreadyReviewed) || errors.Is(err, sm.ErrSubmissionNotFound) {
+		return p.submissionsSendAlreadyReviewed(c, s)
+	} else if errors.Is(err, sm.ErrPermissionDenied) {
+		if err = c.Send(permissionDeniedText); err != nil {
+			return err
+		}
+		return p.sendAdminMenu(c, s)
+	} else if err != nil {
+		return err
+	}
+
+	p.submissionsNotifyGroup(ctx, c.Bot(), eventID, groupName, fmt.Sprintf(
+		"❌ Решение задания %q отклонено. Причина: %s", activityName, reason,
+	))
+
+	if err = c.Send(fmt.Sprintf("Решение группы %s отклонено.", groupName)); err != nil {
+		return err
+	}
+
+	return p.submissionsSendNext(c, s)
+}
+
+func (p *Port) submissionsSendAlreadyReviewed(c telebot.Context, s fsm.Context) error {
+	if err := c.Send("🚫 Это решение уже проверил другой администратор."); err != nil {
+		return err
+	}
+	return p.submissionsSendNext(c, s)
+}
+
+func (p *Port) submissionsNotifyGroup(ctx context.Context, bot *telebot.Bot, eventID string, groupName string, msg string) {
+	char, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{EventID: eventID, GroupName: groupName})
+	if err != nil {
+		p.log.Error("failed to get character", "group", groupName, "error", err)
+		return
+	}
+	p.sendToCharacter(ctx, bot, eventID, char, msg)
+}
@@ -21,17 +21,31 @@ const takeSlotApproveButton = "Да!"
 func (p *Port) takeSlotSendChooseActivity(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
-	groupName, err := extractGroupName(ctx, s)
+	if ok, err := p.requireCaptain(c, s); !ok {
+		return err
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	groupName, err := p.extractGroupName(ctx, s)
 	if err != nil {
 		return err
 	}
 
 	activities, err := p.app.Queries.AvailableActivities.Handle(ctx, query.AvailableActivities{
+		EventID:   eventID,
 		GroupName: groupName,
 	})
 	if errors.Is(err, sm.ErrSlotsMaxNumberExceeded) {
+		event, err := p.app.Queries.GetEvent.Handle(ctx, query.GetEvent{EventID: eventID})
+		if err != nil {
+			return err
+		}
 		if err = c.Send(
-			fmt.Sprintf("🚫 К сожалению, ты уже записался на максимальное количество точек (%d).", sm.MaxTakenSlots),
+			fmt.Sprintf("🚫 К сожалению, ты уже записался на максимальное количество точек (%d).", event.Rules.MaxTakenSlots),
 		); err != nil {
 			return err
 		}
@@ -41,7 +55,10 @@ func (p *Port) takeSlotSendChooseActivity(c telebot.Context, s fsm.Context) erro
 	}
 
 	if len(activities) == 0 {
-		return c.Send("🚫 Больше не осталось слотов для записи :(")
+		return c.Send(fmt.Sprintf(
+			"🚫 Больше не осталось слотов для записи :(\nВстань в очередь в разделе «%s», и мы предложим освободившееся время.",
+			participantMenuWaitlistButton,
+		))
 	}
 
 	buttons := make([]string, len(activities))
@@ -67,9 +84,14 @@ func (p *Port) takeSlotSendChooseActivity(c telebot.Context, s fsm.Context) erro
 func (p *Port) takeSlotHandleActivityName(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
 	activityName := c.Message().Text
 
-	_, err := p.app.Queries.GetActivity.Handle(ctx, query.GetActivity{ActivityName: activityName})
+	_, err = p.app.Queries.GetActivity.Handle(ctx, query.GetActivity{EventID: eventID, ActivityName: activityName})
 	if errors.Is(err, sm.ErrActivityNotFound) {
 		err = c.Send("🚫 Выбери одну из предложенных точек.")
 		if err != nil {
@@ -90,7 +112,12 @@ func (p *Port) takeSlotHandleActivityName(c telebot.Context, s fsm.Context) erro
 func (p *Port) takeSlotSendSlots(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
-	groupName, err := extractGroupName(ctx, s)
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	groupName, err := p.extractGroupName(ctx, s)
 	if err != nil {
 		return err
 	}
@@ -101,6 +128,7 @@ func (p *Port) takeSlotSendSlots(c telebot.Context, s fsm.Context) error {
 	}
 
 	slots, err := p.app.Queries.AvailableSlots.Handle(ctx, query.AvailableSlots{
+		EventID:      eventID,
 		GroupName:    groupName,
 		ActivityName: activityName,
 	})
@@ -133,12 +161,20 @@ func (p *Port) takeSlotSendSlots(c telebot.Context, s fsm.Context) error {
 func (p *Port) takeSlotHandleStartTime(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
-	startS := c.Message().Text
-	parsed, err := time.Parse(sm.TimeFormat, startS)
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	event, err := p.app.Queries.GetEvent.Handle(ctx, query.GetEvent{EventID: eventID})
+	if err != nil {
+		return err
+	}
+
+	start, err := sm.ParseTimeAt(event.Date, c.Message().Text)
 	if err != nil {
 		return c.Send("🚫 Выбери корректное время начала точки.")
 	}
-	start := time.Date(time.Now().Year(), time.Now().Month(), time.Now().Day(), parsed.Hour(), parsed.Minute(), 0, 0, time.Local)
 
 	if err = s.Update(ctx, takeSlotStartTime, start); err != nil {
 		return err
@@ -150,12 +186,18 @@ func (p *Port) takeSlotHandleStartTime(c telebot.Context, s fsm.Context) error {
 func (p *Port) takeSlotSendActivity(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
 	activityName, err := takeSkillExtractActivityName(ctx, s)
 	if err != nil {
 		return err
 	}
 
 	activity, err := p.app.Queries.GetActivity.Handle(ctx, query.GetActivity{
+		EventID:      eventID,
 		ActivityName: activityName,
 	})
 	if err != nil {
@@ -191,12 +233,17 @@ func (p *Port) takeSlotSendActivity(c telebot.Context, s fsm.Context) error {
 func (p *Port) takeSlotHandleApprove(c telebot.Context, s fsm.Context) error {
 	ctx := context.Background()
 
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
 	answer := c.Message().Text
 	if answer != takeSlotApproveButton {
 		return p.sendParticipantMenu(c, s)
 	}
 
-	groupName, err := extractGroupName(ctx, s)
+	groupName, err := p.extractGroupName(ctx, s)
 	if err != nil {
 		return err
 	}
@@ -212,15 +259,34 @@ func (p *Port) takeSlotHandleApprove(c telebot.Context, s fsm.Context) error {
 	}
 
 	err = p.app.Commands.TakeSlot.Handle(ctx, command.TakeSlot{
+		EventID:      eventID,
 		GroupName:    groupName,
 		ActivityName: activityName,
 		Start:        start,
+		Username:     c.Chat().Username,
 	})
-	if errors.Is(err, sm.ErrSlotIsTooLate) {
+	if errors.Is(err, sm.ErrNotCaptain) {
+		return p.sendNotCaptain(c, s)
+	} else if errors.Is(err, sm.ErrSlotIsTooLate) {
 		if err = c.Send("🚫 Ой, кажется ты пытаешься забронировать точку уже после окончания инструкции :("); err != nil {
 			return err
 		}
 		return p.sendParticipantMenu(c, s)
+	} else if errors.Is(err, sm.ErrSlotIsTooEarly) {
+		if err = c.Send("🚫 Это время раньше старта Инструкции твоей группы. Выбери другое время."); err != nil {
+			return err
+		}
+		return p.sendParticipantMenu(c, s)
+	} else if errors.Is(err, sm.ErrSlotIsUnreachable) {
+		if err = c.Send("🚫 Ты не успеешь дойти до этой точки с соседних броней. Выбери другое время."); err != nil {
+			return err
+		}
+		return p.sendParticipantMenu(c, s)
+	} else if errors.Is(err, sm.ErrSlotIsOffered) {
+		if err = c.Send("🚫 Это время уже предложено группе из листа ожидания. Выбери другое время."); err != nil {
+			return err
+		}
+		return p.sendParticipantMenu(c, s)
 	} else if err != nil {
 		return err
 	}
@@ -230,6 +296,8 @@ func (p *Port) takeSlotHandleApprove(c telebot.Context, s fsm.Context) error {
 		return err
 	}
 
+	p.unlockAchievements(ctx, c.Bot(), eventID, groupName)
+
 	return p.sendParticipantMenu(c, s)
 }
 
@@ -0,0 +1,100 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/vitaliy-ukiru/fsm-telebot/v2"
+	"gopkg.in/telebot.v3"
+
+	"github.com/zhikh23/sm-instruction/internal/app/command"
+	"github.com/zhikh23/sm-instruction/internal/app/query"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+// joinTeam принимает приглашение из ссылки вида t.me/<бот>?start=<код>.
+// О неудаче сообщается пользователю, после чего /start продолжается как обычно.
+func (p *Port) joinTeam(ctx context.Context, c telebot.Context, eventID string, inviteCode string) error {
+	err := p.app.Commands.JoinTeam.Handle(ctx, command.JoinTeam{
+		EventID:    eventID,
+		InviteCode: inviteCode,
+		Username:   c.Chat().Username,
+	})
+	if errors.Is(err, sm.ErrInvalidInviteCode) {
+		return c.Send("🚫 Ссылка-приглашение недействительна. Попроси капитана группы прислать новую.")
+	} else if errors.Is(err, sm.ErrAlreadyInAnotherTeam) {
+		return c.Send("🚫 Ты уже состоишь в другой группе.")
+	} else if errors.Is(err, sm.ErrUserIsNotParticipant) {
+		return c.Send("🚫 Администраторы не могут вступать в группы.")
+	} else if err != nil {
+		return err
+	}
+
+	return c.Send("✅ Приглашение принято!")
+}
+
+func teamRoleOf(char query.Character, username string) string {
+	for _, m := range char.Members {
+		if m.Username == username {
+			return m.Role
+		}
+	}
+	return sm.TeamMember.String()
+}
+
+// isCaptain сообщает, что пользователь — капитан своей группы. Роль
+// запоминается при /start; сессии, начатые до появления ролей, были только
+// у капитанов.
+func isCaptain(ctx context.Context, s fsm.Context) bool {
+	var role string
+	if err := s.Data(ctx, teamRoleKey, &role); err != nil || role == "" {
+		return true
+	}
+	return role == sm.Captain.String()
+}
+
+// requireCaptain отказывает участникам, которые не являются капитанами.
+func (p *Port) requireCaptain(c telebot.Context, s fsm.Context) (bool, error) {
+	if isCaptain(context.Background(), s) {
+		return true, nil
+	}
+
+	return false, p.sendNotCaptain(c, s)
+}
+
+// sendNotCaptain сообщает об отказе, если роль в группе сменилась после /start.
+func (p *Port) sendNotCaptain(c telebot.Context, s fsm.Context) error {
+	if err := c.Send("🚫 Бронировать точки может только капитан группы."); err != nil {
+		return err
+	}
+
+	return p.sendParticipantMenu(c, s)
+}
+
+// sendToCharacter отправляет сообщение всем участникам группы.
+func (p *Port) sendToCharacter(ctx context.Context, bot *telebot.Bot, eventID string, char query.Character, msg string) {
+	for _, m := range char.Members {
+		p.sendToUser(ctx, bot, eventID, m.Username, msg)
+	}
+}
+
+func formatTeam(bot *telebot.Bot, char query.Character, captain bool) string {
+	lines := make([]string, 0, len(char.Members)+2)
+	for _, m := range char.Members {
+		if m.Role == sm.Captain.String() {
+			lines = append(lines, fmt.Sprintf("👑 @%s", m.Username))
+		} else {
+			lines = append(lines, fmt.Sprintf("👤 @%s", m.Username))
+		}
+	}
+
+	if captain {
+		lines = append(lines,
+			"",
+			fmt.Sprintf("Ссылка-приглашение: https://t.me/%s?start=%s", bot.Me.Username, char.InviteCode),
+		)
+	}
+
+	return buildMessage("\n", lines...)
+}
@@ -0,0 +1,397 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vitaliy-ukiru/fsm-telebot/v2"
+	"gopkg.in/telebot.v3"
+
+	"github.com/zhikh23/sm-instruction/internal/app/command"
+	"github.com/zhikh23/sm-instruction/internal/app/query"
+	"github.com/zhikh23/sm-instruction/internal/domain/sm"
+)
+
+const waitlistActivityNameKey = "waitlistActivityName"
+
+const (
+	waitlistJoinPrefix    = "Встать в очередь: "
+	waitlistLeavePrefix   = "Покинуть очередь: "
+	waitlistAcceptPrefix  = "Принять: "
+	waitlistDeclinePrefix = "Отказаться: "
+	waitlistAnyTimeButton = "Любое время"
+	waitlistBackButton    = "Назад"
+)
+
+func (p *Port) waitlistSendMenu(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	if ok, err := p.requireCaptain(c, s); !ok {
+		return err
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	groupName, err := p.extractGroupName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	entries, err := p.app.Queries.GroupWaitlists.Handle(ctx, query.GroupWaitlists{EventID: eventID, GroupName: groupName})
+	if err != nil {
+		return err
+	}
+
+	activities, err := p.app.Queries.WaitlistActivities.Handle(ctx, query.WaitlistActivities{
+		EventID:   eventID,
+		GroupName: groupName,
+	})
+	if err != nil {
+		return err
+	}
+
+	lines := []string{"<b>ЛИСТ ОЖИДАНИЯ</b>\n"}
+	buttons := make([]string, 0)
+	joined := make(map[string]bool)
+	now := time.Now()
+	for _, e := range entries {
+		joined[e.ActivityName] = true
+		lines = append(lines, fmt.Sprintf("🔹 %s — место в очереди: %d%s", e.ActivityName, e.Position, waitlistRangeText(e)))
+		if e.OfferStart != nil && now.Before(*e.OfferExpiresAt) {
+			lines = append(lines, fmt.Sprintf(
+				"❕ Предложено время %s, ответь до %s",
+				e.OfferStart.Format(sm.TimeFormat), e.OfferExpiresAt.Format(sm.TimeFormat),
+			))
+			buttons = append(buttons, waitlistAcceptPrefix+e.ActivityName, waitlistDeclinePrefix+e.ActivityName)
+		} else {
+			buttons = append(buttons, waitlistLeavePrefix+e.ActivityName)
+		}
+	}
+	if len(entries) == 0 {
+		lines = append(lines, "Ты пока не стоишь ни в одной очереди.")
+	}
+
+	for _, act := range activities {
+		if !joined[act.Name] {
+			buttons = append(buttons, waitlistJoinPrefix+act.Name)
+		}
+	}
+	if len(activities) > 0 {
+		lines = append(lines, "", "Если на точке не осталось подходящих слотов, встань в очередь — "+
+			"мы предложим освободившееся время.")
+	}
+	buttons = append(buttons, waitlistBackButton)
+
+	if err = s.SetState(ctx, waitlistHandleActionState); err != nil {
+		return err
+	}
+
+	return c.Send(
+		buildMessage("\n", lines...), telebot.ModeHTML,
+		createMarkupWithButtonsFromStrings(buttons, 1),
+	)
+}
+
+func (p *Port) waitlistHandleAction(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	answer := c.Message().Text
+	if answer == waitlistBackButton {
+		return p.sendParticipantMenu(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	groupName, err := p.extractGroupName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case strings.HasPrefix(answer, waitlistJoinPrefix):
+		activityName := strings.TrimPrefix(answer, waitlistJoinPrefix)
+		if err = s.Update(ctx, waitlistActivityNameKey, activityName); err != nil {
+			return err
+		}
+		if err = s.SetState(ctx, waitlistHandleRangeState); err != nil {
+			return err
+		}
+		return c.Send(
+			"В какое время тебе удобно прийти? Напиши промежуток в формате <code>12:00-14:00</code> "+
+				"или выбери любое время.",
+			telebot.ModeHTML,
+			createMarkupWithButtonsFromStrings([]string{waitlistAnyTimeButton, waitlistBackButton}, 2),
+		)
+
+	case strings.HasPrefix(answer, waitlistLeavePrefix):
+		err = p.app.Commands.LeaveWaitlist.Handle(ctx, command.LeaveWaitlist{
+			EventID:      eventID,
+			GroupName:    groupName,
+			ActivityName: strings.TrimPrefix(answer, waitlistLeavePrefix),
+			Username:     c.Chat().Username,
+		})
+		if errors.Is(err, sm.ErrNotCaptain) {
+			return p.sendNotCaptain(c, s)
+		} else if err != nil && !errors.Is(err, sm.ErrNotInWaitlist) {
+			return err
+		}
+		if err = c.Send("✅ Ты покинул очередь."); err != nil {
+			return err
+		}
+
+	case strings.HasPrefix(answer, waitlistAcceptPrefix):
+		activityName := strings.TrimPrefix(answer, waitlistAcceptPrefix)
+		entry, ok, err := p.waitlistGroupEntry(ctx, eventID, groupName, activityName)
+		if err != nil {
+			return err
+		}
+
+		err = p.app.Commands.AcceptWaitlistOffer.Handle(ctx, command.AcceptWaitlistOffer{
+			EventID:      eventID,
+			GroupName:    groupName,
+			ActivityName: activityName,
+			Username:     c.Chat().Username,
+		})
+		if errors.Is(err, sm.ErrNotCaptain) {
+			return p.sendNotCaptain(c, s)
+		} else if errors.Is(err, sm.ErrWaitlistOfferExpired) ||
+			errors.Is(err, sm.ErrWaitlistOfferNotFound) ||
+			errors.Is(err, sm.ErrNotInWaitlist) {
+			if err = c.Send("🚫 Время на ответ истекло :("); err != nil {
+				return err
+			}
+		} else if err != nil {
+			p.log.Info("failed to accept waitlist offer", "group", groupName, "activity", activityName, "error", err)
+			if err = c.Send("🚫 Не получилось занять этот слот. Ты остаёшься в очереди."); err != nil {
+				return err
+			}
+		} else {
+			msg := fmt.Sprintf("✅ Успешно забронирована точка %q", activityName)
+			if ok && entry.OfferStart != nil {
+				msg += " на время " + entry.OfferStart.Format(sm.TimeFormat)
+			}
+			if err = c.Send(msg); err != nil {
+				return err
+			}
+			p.unlockAchievements(ctx, c.Bot(), eventID, groupName)
+		}
+
+	case strings.HasPrefix(answer, waitlistDeclinePrefix):
+		err = p.app.Commands.DeclineWaitlistOffer.Handle(ctx, command.DeclineWaitlistOffer{
+			EventID:      eventID,
+			GroupName:    groupName,
+			ActivityName: strings.TrimPrefix(answer, waitlistDeclinePrefix),
+			Username:     c.Chat().Username,
+		})
+		if errors.Is(err, sm.ErrNotCaptain) {
+			return p.sendNotCaptain(c, s)
+		} else if err != nil && !errors.Is(err, sm.ErrNotInWaitlist) && !errors.Is(err, sm.ErrWaitlistOfferNotFound) {
+			return err
+		}
+		if err = c.Send("✅ Ты отказался от предложения и покинул очередь."); err != nil {
+			return err
+		}
+
+	default:
+		if err = c.Send("🚫 Выбери одно из предложенных действий."); err != nil {
+			return err
+		}
+	}
+
+	return p.waitlistSendMenu(c, s)
+}
+
+func (p *Port) waitlistHandleRange(c telebot.Context, s fsm.Context) error {
+	ctx := context.Background()
+
+	answer := c.Message().Text
+	if answer == waitlistBackButton {
+		return p.waitlistSendMenu(c, s)
+	}
+
+	eventID, err := p.extractEventID(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	groupName, err := p.extractGroupName(ctx, s)
+	if err != nil {
+		return err
+	}
+
+	var activityName string
+	if err = s.Data(ctx, waitlistActivityNameKey, &activityName); err != nil {
+		return fmt.Errorf("failed extract activity name: %w", err)
+	}
+
+	var from, to *time.Time
+	if answer != waitlistAnyTimeButton {
+		event, err := p.app.Queries.GetEvent.Handle(ctx, query.GetEvent{EventID: eventID})
+		if err != nil {
+			return err
+		}
+
+		from, to, err = parseWaitlistRange(answer, event)
+		if err != nil {
+			return c.Send("🚫 Напиши промежуток в формате <code>12:00-14:00</code>.", telebot.ModeHTML)
+		}
+	}
+
+	err = p.app.Commands.JoinWaitlist.Handle(ctx, command.JoinWaitlist{
+		EventID:      eventID,
+		GroupName:    groupName,
+		ActivityName: activityName,
+		From:         from,
+		To:           to,
+		Username:     c.Chat().Username,
+	})
+	if errors.Is(err, sm.ErrNotCaptain) {
+		return p.sendNotCaptain(c, s)
+	} else if errors.Is(err, sm.ErrAlreadyInWaitlist) {
+		if err = c.Send("❕ Ты уже стоишь в этой очереди."); err != nil {
+			return err
+		}
+	} else if errors.Is(err, sm.ErrActivityAlreadyTaken) {
+		if err = c.Send("❕ Ты уже записан на эту точку."); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else {
+		if err = c.Send(fmt.Sprintf("✅ Ты в очереди на точку %q. Мы напишем, как только освободится время.", activityName)); err != nil {
+			return err
+		}
+		p.ProcessWaitlists(ctx, c.Bot())
+	}
+
+	return p.waitlistSendMenu(c, s)
+}
+
+func (p *Port) waitlistGroupEntry(
+	ctx context.Context,
+	eventID string,
+	groupName string,
+	activityName string,
+) (query.WaitlistEntry, bool, error) {
+	entries, err := p.app.Queries.GroupWaitlists.Handle(ctx, query.GroupWaitlists{EventID: eventID, GroupName: groupName})
+	if err != nil {
+		return query.WaitlistEntry{}, false, err
+	}
+
+	for _, e := range entries {
+		if e.ActivityName == activityName {
+			return e, true, nil
+		}
+	}
+	return query.WaitlistEntry{}, false, nil
+}
+
+// RunWaitlistProcessor периодически предлагает свободные слоты группам из листов ожидания,
+// пока не будет отменён ctx.
+func (p *Port) RunWaitlistProcessor(ctx context.Context, bot *telebot.Bot, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.ProcessWaitlists(ctx, bot)
+		}
+	}
+}
+
+// ProcessWaitlists предлагает свободные слоты группам из очереди и уведомляет их.
+func (p *Port) ProcessWaitlists(ctx context.Context, bot *telebot.Bot) {
+	event, err := p.app.Queries.CurrentEvent.Handle(ctx, query.CurrentEvent{})
+	if errors.Is(err, sm.ErrEventNotFound) {
+		return
+	} else if err != nil {
+		p.log.Error("failed to get current event", "error", err)
+		return
+	}
+
+	// Время в БД хранится с точностью до микросекунд.
+	since := time.Now().Truncate(time.Microsecond)
+
+	if err = p.app.Commands.OfferWaitlistSlots.Handle(ctx, command.OfferWaitlistSlots{EventID: event.ID}); err != nil {
+		p.log.Error("failed to offer waitlist slots", "error", err)
+		return
+	}
+
+	offers, err := p.app.Queries.WaitlistOffers.Handle(ctx, query.WaitlistOffers{EventID: event.ID, OfferedSince: since})
+	if err != nil {
+		p.log.Error("failed to get waitlist offers", "error", err)
+		return
+	}
+
+	for _, offer := range offers {
+		char, err := p.app.Queries.GetCharacter.Handle(ctx, query.GetCharacter{EventID: event.ID, GroupName: offer.GroupName})
+		if err != nil {
+			p.log.Error("failed to get character", "group", offer.GroupName, "error", err)
+			continue
+		}
+
+		p.log.Info("offered waitlist slot", "group", offer.GroupName, "activity", offer.ActivityName, "start", *offer.OfferStart)
+		p.sendToCharacter(ctx, bot, event.ID, char, fmt.Sprintf(
+			"❕ Освободилось время %s на точке %q! Подтверди бронь в разделе «%s» до %s, иначе место уйдёт следующей группе.",
+			offer.OfferStart.Format(sm.TimeFormat), offer.ActivityName, participantMenuWaitlistButton,
+			offer.OfferExpiresAt.Format(sm.TimeFormat),
+		))
+	}
+}
+
+func waitlistRangeText(e query.WaitlistEntry) string {
+	switch {
+	case e.From != nil && e.To != nil:
+		return fmt.Sprintf(" (с %s до %s)", e.From.Format(sm.TimeFormat), e.To.Format(sm.TimeFormat))
+	case e.From != nil:
+		return fmt.Sprintf(" (с %s)", e.From.Format(sm.TimeFormat))
+	case e.To != nil:
+		return fmt.Sprintf(" (до %s)", e.To.Format(sm.TimeFormat))
+	default:
+		return ""
+	}
+}
+
+// parseWaitlistRange разбирает промежуток вида "12:00-14:00" в дне проведения события.
+func parseWaitlistRange(s string, event query.Event) (*time.Time, *time.Time, error) {
+	fromS, toS, ok := strings.Cut(s, "-")
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid range %q", s)
+	}
+
+	at := func(v string) (*time.Time, error) {
+		t, err := sm.ParseTimeAt(event.Date, v)
+		if err != nil {
+			return nil, err
+		}
+		return &t, nil
+	}
+
+	from, err := at(fromS)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	to, err := at(toS)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !from.Before(*to) {
+		return nil, nil, fmt.Errorf("invalid range %q", s)
+	}
+
+	return from, to, nil
+}
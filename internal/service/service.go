@@ -21,12 +21,26 @@ func NewApplication() (*app.Application, func() error) {
 	users, closeUsers := adapters.NewPGUsersRepository()
 	chars, closeChars := adapters.NewPGCharactersRepository()
 	activities, closeActivities := adapters.NewPGActivitiesRepository()
+	events, closeEvents := adapters.NewPGEventsRepository()
+	waitlists, closeWaitlists := adapters.NewPGWaitlistsRepository()
+	codes, closeCodes := adapters.NewPGSecretCodesRepository()
+	submissions, closeSubmissions := adapters.NewPGSubmissionsRepository()
+	quizzes, closeQuizzes := adapters.NewPGQuizzesRepository()
 
-	return newApplication(log, metricsClient, users, chars, activities), func() error {
+	application := newApplication(
+		log, metricsClient, users, chars, activities, events, waitlists, codes, submissions, quizzes,
+	)
+
+	return application, func() error {
 		var err error
 		err = errors.Join(err, closeUsers())
 		err = errors.Join(err, closeChars())
 		err = errors.Join(err, closeActivities())
+		err = errors.Join(err, closeEvents())
+		err = errors.Join(err, closeWaitlists())
+		err = errors.Join(err, closeCodes())
+		err = errors.Join(err, closeSubmissions())
+		err = errors.Join(err, closeQuizzes())
 		return err
 	}
 }
@@ -37,24 +51,98 @@ func newApplication(
 	users sm.UsersRepository,
 	chars sm.CharactersRepository,
 	activities sm.ActivitiesRepository,
+	events sm.EventsRepository,
+	waitlists sm.WaitlistsRepository,
+	codes sm.SecretCodesRepository,
+	submissions sm.SubmissionsRepository,
+	quizzes sm.QuizzesRepository,
 ) *app.Application {
+	// Решения участников оцениваются тем же начислением, что и посещения точек.
+	awardCharacter := command.NewAwardCharacterHandler(users, chars, activities, events, log, metricsClient)
+
 	return &app.Application{
 		Commands: app.Commands{
-			StartInstruction: command.NewStartInstructionHandler(users, chars, log, metricsClient),
-			AwardCharacter:   command.NewAwardCharacterHandler(chars, activities, log, metricsClient),
-			TakeSlot:         command.NewTakeSlotHandler(chars, activities, log, metricsClient),
+			StartInstruction: command.NewStartInstructionHandler(users, chars, events, log, metricsClient),
+			AwardCharacter:   awardCharacter,
+			TakeSlot:         command.NewTakeSlotHandler(chars, activities, waitlists, events, log, metricsClient),
+			CancelSlot:       command.NewCancelSlotHandler(chars, activities, events, log, metricsClient),
+			RevokeGrade:      command.NewRevokeGradeHandler(users, chars, activities, log, metricsClient),
+			CorrectGrade:     command.NewCorrectGradeHandler(users, chars, activities, log, metricsClient),
+			CheckInSlot:      command.NewCheckInSlotHandler(users, chars, activities, log, metricsClient),
+			MarkSlotNoShow:   command.NewMarkSlotNoShowHandler(users, chars, activities, log, metricsClient),
+			ReleaseSlot:      command.NewReleaseSlotHandler(chars, activities, events, log, metricsClient),
+			ReleaseNoShows:   command.NewReleaseNoShowsHandler(chars, activities, events, log, metricsClient),
+			RegisterChat:     command.NewRegisterChatHandler(users, log, metricsClient),
+			JoinWaitlist:     command.NewJoinWaitlistHandler(chars, activities, waitlists, log, metricsClient),
+			LeaveWaitlist:    command.NewLeaveWaitlistHandler(chars, waitlists, log, metricsClient),
+			OfferWaitlistSlots: command.NewOfferWaitlistSlotsHandler(
+				chars, activities, waitlists, events, log, metricsClient,
+			),
+			AcceptWaitlistOffer: command.NewAcceptWaitlistOfferHandler(
+				chars, activities, waitlists, events, log, metricsClient,
+			),
+			DeclineWaitlistOffer: command.NewDeclineWaitlistOfferHandler(chars, waitlists, log, metricsClient),
+			ApplyTimetable:       command.NewApplyTimetableHandler(chars, activities, events, log, metricsClient),
+			UnlockAchievements:   command.NewUnlockAchievementsHandler(chars, activities, events, log, metricsClient),
+			JoinTeam:             command.NewJoinTeamHandler(users, chars, log, metricsClient),
+			ForceTakeSlot:        command.NewForceTakeSlotHandler(users, chars, activities, log, metricsClient),
+			ForceCancelSlot:      command.NewForceCancelSlotHandler(users, chars, activities, log, metricsClient),
+			PenalizeCharacter: command.NewPenalizeCharacterHandler(
+				users, chars, activities, events, log, metricsClient,
+			),
+			RenameCharacter: command.NewRenameCharacterHandler(users, chars, events, log, metricsClient),
+			ScheduleInstructionStart: command.NewScheduleInstructionStartHandler(
+				users, chars, log, metricsClient,
+			),
+			RestartInstruction: command.NewRestartInstructionHandler(users, chars, events, log, metricsClient),
+			ExtendInstruction:  command.NewExtendInstructionHandler(users, chars, events, log, metricsClient),
+			PauseInstruction:   command.NewPauseInstructionHandler(users, chars, log, metricsClient),
+			ResumeInstruction:  command.NewResumeInstructionHandler(users, chars, log, metricsClient),
+			IssueSecretCode: command.NewIssueSecretCodeHandler(
+				users, activities, codes, events, log, metricsClient,
+			),
+			RedeemSecretCode: command.NewRedeemSecretCodeHandler(
+				chars, activities, codes, events, log, metricsClient,
+			),
+			SubmitProof: command.NewSubmitProofHandler(chars, activities, submissions, log, metricsClient),
+			ApproveSubmission: command.NewApproveSubmissionHandler(
+				submissions, events, awardCharacter, log, metricsClient,
+			),
+			RejectSubmission: command.NewRejectSubmissionHandler(
+				users, activities, submissions, log, metricsClient,
+			),
+			StartQuiz:  command.NewStartQuizHandler(chars, activities, quizzes, events, log, metricsClient),
+			AnswerQuiz: command.NewAnswerQuizHandler(chars, activities, quizzes, events, log, metricsClient),
 		},
 		Queries: app.Queries{
 			GetUser:              query.NewGetUserHandler(users, log, metricsClient),
-			CharacterByUsername:  query.NewCharacterByUsernameHandler(chars, log, metricsClient),
-			GetCharacter:         query.NewGetCharacterHandler(chars, log, metricsClient),
-			Rating:               query.NewRatingHandler(chars, log, metricsClient),
+			CharacterByUsername:  query.NewCharacterByUsernameHandler(chars, events, log, metricsClient),
+			GroupName:            query.NewGroupNameHandler(chars, log, metricsClient),
+			GetCharacter:         query.NewGetCharacterHandler(chars, events, log, metricsClient),
+			Rating:               query.NewRatingHandler(chars, events, log, metricsClient),
 			GetActivity:          query.NewGetActivityHandler(activities, log, metricsClient),
-			AdminActivity:        query.NewAdminActivtyHandler(activities, log, metricsClient),
+			AdminActivity:        query.NewAdminActivtyHandler(users, activities, log, metricsClient),
+			AdminActivities:      query.NewAdminActivitiesHandler(activities, log, metricsClient),
 			Activities:           query.NewActivitiesHandler(activities, log, metricsClient),
-			AvailableActivities:  query.NewAvailableActivitiesHandler(chars, activities, log, metricsClient),
+			AvailableActivities:  query.NewAvailableActivitiesHandler(chars, activities, events, log, metricsClient),
 			AdditionalActivities: query.NewAdditionalActivitiesHandler(activities, log, metricsClient),
-			AvailableSlots:       query.NewAvailableSlotsHandler(chars, activities, log, metricsClient),
+			AvailableSlots:       query.NewAvailableSlotsHandler(chars, activities, waitlists, events, log, metricsClient),
+			GetEvent:             query.NewGetEventHandler(events, log, metricsClient),
+			CurrentEvent:         query.NewCurrentEventHandler(events, log, metricsClient),
+			LastActivityGrade:    query.NewLastActivityGradeHandler(chars, log, metricsClient),
+			OverdueSlots:         query.NewOverdueSlotsHandler(activities, events, log, metricsClient),
+			GroupWaitlists:       query.NewGroupWaitlistsHandler(waitlists, log, metricsClient),
+			WaitlistOffers:       query.NewWaitlistOffersHandler(waitlists, log, metricsClient),
+			WaitlistActivities:   query.NewWaitlistActivitiesHandler(chars, activities, log, metricsClient),
+			PlanTimetable:        query.NewPlanTimetableHandler(chars, activities, events, log, metricsClient),
+			EventOverview:        query.NewEventOverviewHandler(users, chars, activities, events, log, metricsClient),
+			SecretCodes:          query.NewSecretCodesHandler(users, activities, codes, log, metricsClient),
+			PendingSubmissions: query.NewPendingSubmissionsHandler(
+				users, activities, submissions, log, metricsClient,
+			),
+			AvailableQuizzes: query.NewAvailableQuizzesHandler(quizzes, log, metricsClient),
+			GroupQuizAttempt: query.NewGroupQuizAttemptHandler(activities, quizzes, log, metricsClient),
+			QuizResults:      query.NewQuizResultsHandler(users, activities, quizzes, log, metricsClient),
 		},
 	}
 }
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS event_rules (
+    id                                 BOOLEAN          PRIMARY KEY DEFAULT TRUE,
+    instruction_duration_minutes       INTEGER          NOT NULL,
+    max_taken_slots                    INTEGER          NOT NULL,
+    min_duration_before_minutes        INTEGER          NOT NULL,
+    min_duration_before_cancel_minutes INTEGER          NOT NULL,
+    rating_lambda                      DOUBLE PRECISION NOT NULL,
+    slot_duration_minutes              INTEGER          NOT NULL,
+    first_slot_start_minutes           INTEGER          NOT NULL,
+    last_slot_start_minutes            INTEGER          NOT NULL,
+
+    CONSTRAINT single_row CHECK ( id )
+);
+
+INSERT INTO event_rules (
+    id,
+    instruction_duration_minutes,
+    max_taken_slots,
+    min_duration_before_minutes,
+    min_duration_before_cancel_minutes,
+    rating_lambda,
+    slot_duration_minutes,
+    first_slot_start_minutes,
+    last_slot_start_minutes
+) VALUES (TRUE, 240, 7, 5, 15, 1.0 / 72, 20, 680, 1040)
+ON CONFLICT DO NOTHING;
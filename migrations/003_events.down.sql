@@ -0,0 +1,104 @@
+-- Сохраняются только данные последнего события.
+DELETE FROM events WHERE id NOT IN (SELECT id FROM events ORDER BY date DESC LIMIT 1);
+
+ALTER TABLE characters      DROP CONSTRAINT IF EXISTS fk_username;
+ALTER TABLE admins          DROP CONSTRAINT IF EXISTS fk_activity_name;
+ALTER TABLE admins          DROP CONSTRAINT IF EXISTS fk_username;
+ALTER TABLE activity_slots  DROP CONSTRAINT IF EXISTS fk_activity_name;
+ALTER TABLE activity_slots  DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE grades          DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE grades          DROP CONSTRAINT IF EXISTS fk_activity_name;
+ALTER TABLE character_slots DROP CONSTRAINT IF EXISTS fk_activity_name;
+ALTER TABLE character_slots DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE users           DROP CONSTRAINT IF EXISTS fk_event_id;
+ALTER TABLE activities      DROP CONSTRAINT IF EXISTS fk_event_id;
+ALTER TABLE event_rules     DROP CONSTRAINT IF EXISTS fk_event_id;
+
+ALTER TABLE users           DROP CONSTRAINT IF EXISTS users_pkey;
+ALTER TABLE characters      DROP CONSTRAINT IF EXISTS characters_pkey;
+ALTER TABLE characters      DROP CONSTRAINT IF EXISTS characters_event_id_username_key;
+ALTER TABLE activities      DROP CONSTRAINT IF EXISTS activities_pkey;
+ALTER TABLE admins          DROP CONSTRAINT IF EXISTS admins_pkey;
+ALTER TABLE activity_slots  DROP CONSTRAINT IF EXISTS activity_slots_pkey;
+ALTER TABLE character_slots DROP CONSTRAINT IF EXISTS character_slots_pkey;
+ALTER TABLE event_rules     DROP CONSTRAINT IF EXISTS event_rules_pkey;
+
+ALTER TABLE users           DROP COLUMN event_id;
+ALTER TABLE characters      DROP COLUMN event_id;
+ALTER TABLE activities      DROP COLUMN event_id;
+ALTER TABLE admins          DROP COLUMN event_id;
+ALTER TABLE activity_slots  DROP COLUMN event_id;
+ALTER TABLE grades          DROP COLUMN event_id;
+ALTER TABLE character_slots DROP COLUMN event_id;
+ALTER TABLE event_rules     DROP COLUMN event_id;
+
+ALTER TABLE event_rules ADD COLUMN id BOOLEAN PRIMARY KEY DEFAULT TRUE;
+ALTER TABLE event_rules ADD CONSTRAINT single_row CHECK ( id );
+INSERT INTO event_rules (
+    id,
+    instruction_duration_minutes,
+    max_taken_slots,
+    min_duration_before_minutes,
+    min_duration_before_cancel_minutes,
+    rating_lambda,
+    slot_duration_minutes,
+    first_slot_start_minutes,
+    last_slot_start_minutes
+) VALUES (TRUE, 240, 7, 5, 15, 1.0 / 72, 20, 680, 1040)
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE users           ADD PRIMARY KEY ( username );
+ALTER TABLE characters      ADD PRIMARY KEY ( group_name );
+ALTER TABLE characters      ADD CONSTRAINT characters_username_key UNIQUE ( username );
+ALTER TABLE activities      ADD PRIMARY KEY ( name );
+ALTER TABLE admins          ADD PRIMARY KEY ( activity_name, username );
+ALTER TABLE activity_slots  ADD PRIMARY KEY ( activity_name, start );
+ALTER TABLE character_slots ADD PRIMARY KEY ( group_name, start );
+
+ALTER TABLE characters
+    ADD CONSTRAINT fk_username
+        FOREIGN KEY ( username )
+            REFERENCES users ( username )
+            ON DELETE CASCADE;
+
+ALTER TABLE admins
+    ADD CONSTRAINT fk_activity_name
+        FOREIGN KEY ( activity_name )
+            REFERENCES activities ( name )
+            ON DELETE CASCADE,
+    ADD CONSTRAINT fk_username
+        FOREIGN KEY ( username )
+            REFERENCES users ( username )
+            ON DELETE CASCADE;
+
+ALTER TABLE activity_slots
+    ADD CONSTRAINT fk_activity_name
+        FOREIGN KEY ( activity_name )
+            REFERENCES activities ( name )
+            ON DELETE CASCADE,
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( group_name )
+            REFERENCES characters ( group_name )
+            ON DELETE CASCADE;
+
+ALTER TABLE grades
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( group_name )
+            REFERENCES characters ( group_name )
+            ON DELETE CASCADE,
+    ADD CONSTRAINT fk_activity_name
+        FOREIGN KEY ( activity_name )
+            REFERENCES activities ( name )
+            ON DELETE CASCADE;
+
+ALTER TABLE character_slots
+    ADD CONSTRAINT fk_activity_name
+        FOREIGN KEY ( activity_name )
+            REFERENCES activities ( name )
+            ON DELETE CASCADE,
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( group_name )
+            REFERENCES characters ( group_name )
+            ON DELETE CASCADE;
+
+DROP TABLE IF EXISTS events;
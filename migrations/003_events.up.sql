@@ -0,0 +1,138 @@
+CREATE TABLE IF NOT EXISTS events (
+    id       VARCHAR (64)  PRIMARY KEY,
+    name     VARCHAR (256) NOT NULL,
+    date     DATE          NOT NULL,
+    timezone VARCHAR (64)  NOT NULL
+);
+
+-- Уже загруженные данные переносятся в отдельное событие.
+INSERT INTO events (id, name, date, timezone)
+SELECT 'legacy',
+       'Инструктаж',
+       COALESCE((SELECT MIN(start)::DATE FROM activity_slots), CURRENT_DATE),
+       'Europe/Moscow'
+WHERE EXISTS (SELECT 1 FROM users);
+
+ALTER TABLE characters      DROP CONSTRAINT IF EXISTS fk_username;
+ALTER TABLE admins          DROP CONSTRAINT IF EXISTS fk_activity_name;
+ALTER TABLE admins          DROP CONSTRAINT IF EXISTS fk_username;
+ALTER TABLE activity_slots  DROP CONSTRAINT IF EXISTS fk_activity_name;
+ALTER TABLE activity_slots  DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE grades          DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE grades          DROP CONSTRAINT IF EXISTS fk_activity_name;
+ALTER TABLE character_slots DROP CONSTRAINT IF EXISTS fk_activity_name;
+ALTER TABLE character_slots DROP CONSTRAINT IF EXISTS fk_group_name;
+
+ALTER TABLE users           DROP CONSTRAINT IF EXISTS users_pkey;
+ALTER TABLE characters      DROP CONSTRAINT IF EXISTS characters_pkey;
+ALTER TABLE characters      DROP CONSTRAINT IF EXISTS characters_username_key;
+ALTER TABLE activities      DROP CONSTRAINT IF EXISTS activities_pkey;
+ALTER TABLE admins          DROP CONSTRAINT IF EXISTS admins_pkey;
+ALTER TABLE activity_slots  DROP CONSTRAINT IF EXISTS activity_slots_pkey;
+ALTER TABLE character_slots DROP CONSTRAINT IF EXISTS character_slots_pkey;
+ALTER TABLE event_rules     DROP CONSTRAINT IF EXISTS event_rules_pkey;
+ALTER TABLE event_rules     DROP CONSTRAINT IF EXISTS single_row;
+
+ALTER TABLE users           ADD COLUMN event_id VARCHAR (64);
+ALTER TABLE characters      ADD COLUMN event_id VARCHAR (64);
+ALTER TABLE activities      ADD COLUMN event_id VARCHAR (64);
+ALTER TABLE admins          ADD COLUMN event_id VARCHAR (64);
+ALTER TABLE activity_slots  ADD COLUMN event_id VARCHAR (64);
+ALTER TABLE grades          ADD COLUMN event_id VARCHAR (64);
+ALTER TABLE character_slots ADD COLUMN event_id VARCHAR (64);
+ALTER TABLE event_rules     ADD COLUMN event_id VARCHAR (64);
+
+UPDATE users           SET event_id = 'legacy';
+UPDATE characters      SET event_id = 'legacy';
+UPDATE activities      SET event_id = 'legacy';
+UPDATE admins          SET event_id = 'legacy';
+UPDATE activity_slots  SET event_id = 'legacy';
+UPDATE grades          SET event_id = 'legacy';
+UPDATE character_slots SET event_id = 'legacy';
+UPDATE event_rules     SET event_id = 'legacy';
+
+-- Правила по умолчанию без загруженного события больше не нужны.
+DELETE FROM event_rules WHERE NOT EXISTS (SELECT 1 FROM events WHERE id = event_rules.event_id);
+ALTER TABLE event_rules DROP COLUMN id;
+
+ALTER TABLE users           ALTER COLUMN event_id SET NOT NULL;
+ALTER TABLE characters      ALTER COLUMN event_id SET NOT NULL;
+ALTER TABLE activities      ALTER COLUMN event_id SET NOT NULL;
+ALTER TABLE admins          ALTER COLUMN event_id SET NOT NULL;
+ALTER TABLE activity_slots  ALTER COLUMN event_id SET NOT NULL;
+ALTER TABLE grades          ALTER COLUMN event_id SET NOT NULL;
+ALTER TABLE character_slots ALTER COLUMN event_id SET NOT NULL;
+ALTER TABLE event_rules     ALTER COLUMN event_id SET NOT NULL;
+
+ALTER TABLE users           ADD PRIMARY KEY ( event_id, username );
+ALTER TABLE characters      ADD PRIMARY KEY ( event_id, group_name );
+ALTER TABLE characters      ADD UNIQUE ( event_id, username );
+ALTER TABLE activities      ADD PRIMARY KEY ( event_id, name );
+ALTER TABLE admins          ADD PRIMARY KEY ( event_id, activity_name, username );
+ALTER TABLE activity_slots  ADD PRIMARY KEY ( event_id, activity_name, start );
+ALTER TABLE character_slots ADD PRIMARY KEY ( event_id, group_name, start );
+ALTER TABLE event_rules     ADD PRIMARY KEY ( event_id );
+
+ALTER TABLE users
+    ADD CONSTRAINT fk_event_id
+        FOREIGN KEY ( event_id )
+            REFERENCES events ( id )
+            ON DELETE CASCADE;
+
+ALTER TABLE event_rules
+    ADD CONSTRAINT fk_event_id
+        FOREIGN KEY ( event_id )
+            REFERENCES events ( id )
+            ON DELETE CASCADE;
+
+ALTER TABLE activities
+    ADD CONSTRAINT fk_event_id
+        FOREIGN KEY ( event_id )
+            REFERENCES events ( id )
+            ON DELETE CASCADE;
+
+ALTER TABLE characters
+    ADD CONSTRAINT fk_username
+        FOREIGN KEY ( event_id, username )
+            REFERENCES users ( event_id, username )
+            ON DELETE CASCADE;
+
+ALTER TABLE admins
+    ADD CONSTRAINT fk_activity_name
+        FOREIGN KEY ( event_id, activity_name )
+            REFERENCES activities ( event_id, name )
+            ON DELETE CASCADE,
+    ADD CONSTRAINT fk_username
+        FOREIGN KEY ( event_id, username )
+            REFERENCES users ( event_id, username )
+            ON DELETE CASCADE;
+
+ALTER TABLE activity_slots
+    ADD CONSTRAINT fk_activity_name
+        FOREIGN KEY ( event_id, activity_name )
+            REFERENCES activities ( event_id, name )
+            ON DELETE CASCADE,
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE;
+
+ALTER TABLE grades
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE,
+    ADD CONSTRAINT fk_activity_name
+        FOREIGN KEY ( event_id, activity_name )
+            REFERENCES activities ( event_id, name )
+            ON DELETE CASCADE;
+
+ALTER TABLE character_slots
+    ADD CONSTRAINT fk_activity_name
+        FOREIGN KEY ( event_id, activity_name )
+            REFERENCES activities ( event_id, name )
+            ON DELETE CASCADE,
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE;
@@ -0,0 +1,9 @@
+DELETE FROM grades WHERE revoked_at IS NOT NULL;
+
+ALTER TABLE grades
+    DROP COLUMN IF EXISTS revoked_at,
+    DROP COLUMN IF EXISTS revoked_by,
+    DROP COLUMN IF EXISTS revoke_reason;
+
+ALTER TABLE grades DROP CONSTRAINT IF EXISTS grades_pkey;
+ALTER TABLE grades DROP COLUMN IF EXISTS id;
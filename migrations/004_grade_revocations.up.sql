@@ -0,0 +1,9 @@
+ALTER TABLE grades ADD COLUMN id VARCHAR (64) NULL;
+UPDATE grades SET id = gen_random_uuid()::TEXT;
+ALTER TABLE grades ALTER COLUMN id SET NOT NULL;
+ALTER TABLE grades ADD PRIMARY KEY ( event_id, id );
+
+ALTER TABLE grades
+    ADD COLUMN revoked_at    TIMESTAMP     NULL,
+    ADD COLUMN revoked_by    VARCHAR (256) NULL,
+    ADD COLUMN revoke_reason TEXT          NULL;
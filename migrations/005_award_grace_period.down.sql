@@ -0,0 +1 @@
+ALTER TABLE event_rules DROP COLUMN IF EXISTS award_grace_period_minutes;
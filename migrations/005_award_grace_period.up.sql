@@ -0,0 +1 @@
+ALTER TABLE event_rules ADD COLUMN award_grace_period_minutes INTEGER NOT NULL DEFAULT 10;
@@ -0,0 +1,3 @@
+ALTER TABLE activity_slots  DROP COLUMN IF EXISTS status;
+ALTER TABLE character_slots DROP COLUMN IF EXISTS status;
+DROP TYPE IF EXISTS SLOT_STATUS;
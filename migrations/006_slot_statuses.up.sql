@@ -0,0 +1,17 @@
+DO $$ BEGIN
+    CREATE TYPE SLOT_STATUS AS ENUM (
+        'free',
+        'booked',
+        'checked_in',
+        'no_show',
+        'completed'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+ALTER TABLE activity_slots  ADD COLUMN status SLOT_STATUS NOT NULL DEFAULT 'free';
+ALTER TABLE character_slots ADD COLUMN status SLOT_STATUS NOT NULL DEFAULT 'free';
+
+UPDATE activity_slots  SET status = 'booked' WHERE group_name IS NOT NULL;
+UPDATE character_slots SET status = 'booked' WHERE activity_name IS NOT NULL;
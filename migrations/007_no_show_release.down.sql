@@ -0,0 +1,2 @@
+ALTER TABLE event_rules DROP COLUMN IF EXISTS no_show_timeout_minutes;
+ALTER TABLE users       DROP COLUMN IF EXISTS chat_id;
@@ -0,0 +1,2 @@
+ALTER TABLE event_rules ADD COLUMN no_show_timeout_minutes INTEGER NOT NULL DEFAULT 10;
+ALTER TABLE users       ADD COLUMN chat_id BIGINT NULL;
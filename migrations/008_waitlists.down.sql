@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS waitlist_entries;
+
+ALTER TABLE event_rules DROP COLUMN IF EXISTS waitlist_offer_timeout_minutes;
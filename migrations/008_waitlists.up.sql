@@ -0,0 +1,25 @@
+ALTER TABLE event_rules ADD COLUMN waitlist_offer_timeout_minutes INTEGER NOT NULL DEFAULT 5;
+
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    event_id         VARCHAR (64)  NOT NULL,
+    activity_name    VARCHAR (256) NOT NULL,
+    group_name       VARCHAR (8)   NOT NULL,
+    from_            TIMESTAMP     NULL,
+    to_              TIMESTAMP     NULL,
+    joined_at        TIMESTAMP     NOT NULL,
+    offer_start      TIMESTAMP     NULL,
+    offered_at       TIMESTAMP     NULL,
+    offer_expires_at TIMESTAMP     NULL,
+
+    PRIMARY KEY ( event_id, activity_name, group_name ),
+
+    CONSTRAINT fk_activity_name
+        FOREIGN KEY ( event_id, activity_name )
+            REFERENCES activities ( event_id, name )
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE
+);
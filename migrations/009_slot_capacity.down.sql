@@ -0,0 +1,23 @@
+ALTER TABLE activity_slots ADD COLUMN group_name VARCHAR (256) NULL;
+ALTER TABLE activity_slots ADD COLUMN status SLOT_STATUS NOT NULL DEFAULT 'free';
+
+-- В слоте остаётся только одна группа: остальные брони теряются.
+UPDATE activity_slots s
+SET    group_name = b.group_name,
+       status     = b.status
+FROM   (
+    SELECT DISTINCT ON (event_id, activity_name, start) event_id, activity_name, start, group_name, status
+    FROM   activity_slot_bookings
+    ORDER BY event_id, activity_name, start, group_name
+) b
+WHERE  s.event_id = b.event_id AND s.activity_name = b.activity_name AND s.start = b.start;
+
+ALTER TABLE activity_slots
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE;
+
+DROP TABLE IF EXISTS activity_slot_bookings;
+
+ALTER TABLE activity_slots DROP COLUMN IF EXISTS capacity;
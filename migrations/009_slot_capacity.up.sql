@@ -0,0 +1,30 @@
+ALTER TABLE activity_slots ADD COLUMN capacity INTEGER NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS activity_slot_bookings (
+    event_id      VARCHAR (64)  NOT NULL,
+    activity_name VARCHAR (256) NOT NULL,
+    start         TIMESTAMP     NOT NULL,
+    group_name    VARCHAR (8)   NOT NULL,
+    status        SLOT_STATUS   NOT NULL DEFAULT 'booked',
+
+    PRIMARY KEY ( event_id, activity_name, start, group_name ),
+
+    CONSTRAINT fk_activity_slot
+        FOREIGN KEY ( event_id, activity_name, start )
+            REFERENCES activity_slots ( event_id, activity_name, start )
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE
+);
+
+INSERT INTO activity_slot_bookings (event_id, activity_name, start, group_name, status)
+SELECT event_id, activity_name, start, group_name, status
+FROM   activity_slots
+WHERE  group_name IS NOT NULL;
+
+ALTER TABLE activity_slots DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE activity_slots DROP COLUMN group_name;
+ALTER TABLE activity_slots DROP COLUMN status;
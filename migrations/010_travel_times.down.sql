@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS event_travel_times;
+
+ALTER TABLE activities DROP COLUMN IF EXISTS zone;
@@ -0,0 +1,15 @@
+ALTER TABLE activities ADD COLUMN zone VARCHAR (256) NULL;
+
+CREATE TABLE IF NOT EXISTS event_travel_times (
+    event_id         VARCHAR (64)  NOT NULL,
+    from_zone        VARCHAR (256) NOT NULL,
+    to_zone          VARCHAR (256) NOT NULL,
+    duration_minutes INTEGER       NOT NULL,
+
+    PRIMARY KEY ( event_id, from_zone, to_zone ),
+
+    CONSTRAINT fk_event_id
+        FOREIGN KEY ( event_id )
+            REFERENCES events ( id )
+            ON DELETE CASCADE
+);
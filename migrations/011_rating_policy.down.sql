@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS event_rating_skills;
+
+ALTER TABLE events DROP COLUMN IF EXISTS rating_balance_bonus;
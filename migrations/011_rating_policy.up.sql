@@ -0,0 +1,15 @@
+ALTER TABLE events ADD COLUMN rating_balance_bonus DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS event_rating_skills (
+    event_id VARCHAR (64)     NOT NULL,
+    skill    SKILL_TYPE       NOT NULL,
+    weight   DOUBLE PRECISION NOT NULL DEFAULT 1,
+    cap      INTEGER          NOT NULL DEFAULT 0,
+
+    PRIMARY KEY ( event_id, skill ),
+
+    CONSTRAINT fk_event_id
+        FOREIGN KEY ( event_id )
+            REFERENCES events ( id )
+            ON DELETE CASCADE
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS character_achievements (
+    event_id       VARCHAR (64) NOT NULL,
+    group_name     VARCHAR (8)  NOT NULL,
+    achievement_id VARCHAR (64) NOT NULL,
+    unlocked_at    TIMESTAMP    NOT NULL,
+
+    PRIMARY KEY ( event_id, group_name, achievement_id ),
+
+    CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE
+);
@@ -0,0 +1,3 @@
+ALTER TABLE characters DROP COLUMN IF EXISTS invite_code;
+
+DROP TABLE IF EXISTS character_members;
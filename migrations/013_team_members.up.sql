@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS character_members (
+    event_id   VARCHAR (64)  NOT NULL,
+    group_name VARCHAR (8)   NOT NULL,
+    username   VARCHAR (256) NOT NULL,
+    role       VARCHAR (16)  NOT NULL DEFAULT 'member',
+
+    -- Пользователь может состоять только в одной группе мероприятия.
+    PRIMARY KEY ( event_id, username ),
+
+    CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE
+);
+
+INSERT INTO character_members (event_id, group_name, username, role)
+SELECT event_id, group_name, username, 'captain'
+FROM   characters;
+
+ALTER TABLE characters ADD COLUMN invite_code VARCHAR (64);
+UPDATE characters SET invite_code = md5(random()::text || event_id || group_name);
+ALTER TABLE characters ALTER COLUMN invite_code SET NOT NULL;
+ALTER TABLE characters ADD UNIQUE ( event_id, invite_code );
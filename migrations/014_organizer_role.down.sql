@@ -0,0 +1,2 @@
+-- Значение перечисления удалить нельзя, поэтому организаторы просто удаляются.
+DELETE FROM users WHERE role = 'organizer';
@@ -0,0 +1 @@
+ALTER TYPE USER_ROLE ADD VALUE IF NOT EXISTS 'organizer';
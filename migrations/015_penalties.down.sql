@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS character_penalties;
+
+ALTER TABLE event_rules DROP COLUMN IF EXISTS max_total_penalty_points;
+ALTER TABLE event_rules DROP COLUMN IF EXISTS max_penalty_points;
@@ -0,0 +1,20 @@
+ALTER TABLE event_rules ADD COLUMN max_penalty_points       INTEGER NOT NULL DEFAULT 5;
+ALTER TABLE event_rules ADD COLUMN max_total_penalty_points INTEGER NOT NULL DEFAULT 15;
+
+CREATE TABLE IF NOT EXISTS character_penalties (
+    event_id      VARCHAR (64)  NOT NULL,
+    id            VARCHAR (64)  NOT NULL,
+    group_name    VARCHAR (8)   NOT NULL,
+    points        INTEGER       NOT NULL CHECK ( points > 0 ),
+    reason        TEXT          NOT NULL,
+    activity_name VARCHAR (256) NULL,
+    username      VARCHAR (256) NOT NULL,
+    time          TIMESTAMP     NOT NULL,
+
+    PRIMARY KEY ( event_id, id ),
+
+    CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE
+);
@@ -0,0 +1,17 @@
+DO $$ BEGIN
+    CREATE TYPE SKILL_TYPE AS ENUM (
+        'Инженерные',
+        'Исследовательские',
+        'Социальные',
+        'Творческие',
+        'Спортивные'
+    );
+EXCEPTION
+    WHEN duplicate_object THEN null;
+END $$;
+
+ALTER TABLE event_rating_skills ALTER COLUMN skill      TYPE SKILL_TYPE   USING skill::SKILL_TYPE;
+ALTER TABLE grades              ALTER COLUMN skill_type TYPE SKILL_TYPE   USING skill_type::SKILL_TYPE;
+ALTER TABLE activities          ALTER COLUMN skills     TYPE SKILL_TYPE[] USING skills::TEXT[]::SKILL_TYPE[];
+
+DROP TABLE IF EXISTS event_skills;
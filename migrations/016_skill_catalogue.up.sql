@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS event_skills (
+    event_id      VARCHAR (64)  NOT NULL,
+    name          VARCHAR (256) NOT NULL,
+    emoji         VARCHAR (16)  NOT NULL DEFAULT '',
+    category      VARCHAR (16)  NOT NULL CHECK ( category IN ('general', 'additional') ),
+    display_order INTEGER       NOT NULL DEFAULT 0,
+
+    PRIMARY KEY ( event_id, name ),
+
+    CONSTRAINT fk_event_id
+        FOREIGN KEY ( event_id )
+            REFERENCES events ( id )
+            ON DELETE CASCADE
+);
+
+INSERT INTO event_skills (event_id, name, emoji, category, display_order)
+SELECT e.id, s.name, s.emoji, s.category, s.display_order
+FROM   events e
+CROSS JOIN (VALUES
+    ('Инженерные',        '🛠', 'general',    1),
+    ('Исследовательские', '🔭', 'general',    2),
+    ('Социальные',        '🤝', 'general',    3),
+    ('Творческие',        '🔮', 'additional', 4),
+    ('Спортивные',        '⚽️', 'additional', 5)
+) AS s (name, emoji, category, display_order)
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE activities          ALTER COLUMN skills     TYPE VARCHAR (256)[] USING skills::TEXT[];
+ALTER TABLE grades              ALTER COLUMN skill_type TYPE VARCHAR (256)   USING skill_type::TEXT;
+ALTER TABLE event_rating_skills ALTER COLUMN skill      TYPE VARCHAR (256)   USING skill::TEXT;
+
+DROP TYPE IF EXISTS SKILL_TYPE;
@@ -0,0 +1,62 @@
+ALTER TABLE grades                 DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE character_slots        DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE waitlist_entries       DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE activity_slot_bookings DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE character_achievements DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE character_members      DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE character_penalties    DROP CONSTRAINT IF EXISTS fk_group_name;
+
+ALTER TABLE characters             ALTER COLUMN group_name TYPE VARCHAR (8);
+ALTER TABLE grades                 ALTER COLUMN group_name TYPE VARCHAR (8);
+ALTER TABLE character_slots        ALTER COLUMN group_name TYPE VARCHAR (8);
+ALTER TABLE waitlist_entries       ALTER COLUMN group_name TYPE VARCHAR (8);
+ALTER TABLE activity_slot_bookings ALTER COLUMN group_name TYPE VARCHAR (8);
+ALTER TABLE character_achievements ALTER COLUMN group_name TYPE VARCHAR (8);
+ALTER TABLE character_members      ALTER COLUMN group_name TYPE VARCHAR (8);
+ALTER TABLE character_penalties    ALTER COLUMN group_name TYPE VARCHAR (8);
+
+ALTER TABLE grades
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE;
+
+ALTER TABLE character_slots
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE;
+
+ALTER TABLE waitlist_entries
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE;
+
+ALTER TABLE activity_slot_bookings
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE;
+
+ALTER TABLE character_achievements
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE;
+
+ALTER TABLE character_members
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE;
+
+ALTER TABLE character_penalties
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE;
+
+ALTER TABLE characters DROP COLUMN IF EXISTS id;
+
+ALTER TABLE events DROP COLUMN IF EXISTS group_name_pattern;
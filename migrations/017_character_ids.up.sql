@@ -0,0 +1,67 @@
+ALTER TABLE events ADD COLUMN group_name_pattern TEXT NOT NULL DEFAULT '^СМ\d{1,2}\-\d{2,3}[Б]?$';
+
+ALTER TABLE characters ADD COLUMN id VARCHAR (64);
+UPDATE characters SET id = gen_random_uuid()::TEXT;
+ALTER TABLE characters ALTER COLUMN id SET NOT NULL;
+ALTER TABLE characters ADD UNIQUE ( event_id, id );
+
+-- Ключи пересоздаются с ON UPDATE CASCADE, чтобы переименование группы
+-- не ломало брони, оценки и остальные связанные записи.
+ALTER TABLE grades                 DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE character_slots        DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE waitlist_entries       DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE activity_slot_bookings DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE character_achievements DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE character_members      DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE character_penalties    DROP CONSTRAINT IF EXISTS fk_group_name;
+
+ALTER TABLE characters             ALTER COLUMN group_name TYPE VARCHAR (64);
+ALTER TABLE grades                 ALTER COLUMN group_name TYPE VARCHAR (64);
+ALTER TABLE character_slots        ALTER COLUMN group_name TYPE VARCHAR (64);
+ALTER TABLE waitlist_entries       ALTER COLUMN group_name TYPE VARCHAR (64);
+ALTER TABLE activity_slot_bookings ALTER COLUMN group_name TYPE VARCHAR (64);
+ALTER TABLE character_achievements ALTER COLUMN group_name TYPE VARCHAR (64);
+ALTER TABLE character_members      ALTER COLUMN group_name TYPE VARCHAR (64);
+ALTER TABLE character_penalties    ALTER COLUMN group_name TYPE VARCHAR (64);
+
+ALTER TABLE grades
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE character_slots
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE waitlist_entries
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE activity_slot_bookings
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE character_achievements
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE character_members
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE character_penalties
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
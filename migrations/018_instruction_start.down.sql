@@ -0,0 +1,2 @@
+ALTER TABLE characters DROP COLUMN IF EXISTS extension_minutes;
+ALTER TABLE characters DROP COLUMN IF EXISTS scheduled_start;
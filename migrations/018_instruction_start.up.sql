@@ -0,0 +1,2 @@
+ALTER TABLE characters ADD COLUMN scheduled_start   TIMESTAMP NULL;
+ALTER TABLE characters ADD COLUMN extension_minutes INTEGER   NOT NULL DEFAULT 0 CHECK ( extension_minutes >= 0 );
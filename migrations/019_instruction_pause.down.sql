@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS character_window_adjustments;
+
+ALTER TABLE characters DROP COLUMN IF EXISTS paused_seconds;
+ALTER TABLE characters DROP COLUMN IF EXISTS paused_at;
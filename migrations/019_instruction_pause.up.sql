@@ -0,0 +1,19 @@
+ALTER TABLE characters ADD COLUMN paused_at      TIMESTAMP NULL;
+ALTER TABLE characters ADD COLUMN paused_seconds INTEGER   NOT NULL DEFAULT 0 CHECK ( paused_seconds >= 0 );
+
+CREATE TABLE IF NOT EXISTS character_window_adjustments (
+    event_id         VARCHAR (64)  NOT NULL,
+    id               VARCHAR (64)  NOT NULL,
+    group_name       VARCHAR (64)  NOT NULL,
+    kind             VARCHAR (16)  NOT NULL,
+    duration_seconds INTEGER       NOT NULL CHECK ( duration_seconds >= 0 ),
+    username         VARCHAR (256) NOT NULL,
+    time             TIMESTAMP     NOT NULL,
+
+    PRIMARY KEY ( event_id, id ),
+
+    CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE
+);
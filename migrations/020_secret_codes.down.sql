@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS character_code_attempts;
+DROP TABLE IF EXISTS secret_code_redemptions;
+DROP TABLE IF EXISTS secret_codes;
+
+ALTER TABLE event_rules DROP COLUMN IF EXISTS code_lockout_minutes;
+ALTER TABLE event_rules DROP COLUMN IF EXISTS max_code_attempts;
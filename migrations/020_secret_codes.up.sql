@@ -0,0 +1,49 @@
+ALTER TABLE event_rules ADD COLUMN max_code_attempts    INTEGER NOT NULL DEFAULT 5;
+ALTER TABLE event_rules ADD COLUMN code_lockout_minutes INTEGER NOT NULL DEFAULT 15;
+
+CREATE TABLE IF NOT EXISTS secret_codes (
+    event_id              VARCHAR (64)  NOT NULL,
+    code                  VARCHAR (32)  NOT NULL,
+    activity_name         VARCHAR (256) NOT NULL,
+    skill_type            VARCHAR (256) NOT NULL,
+    points                INTEGER       NOT NULL CHECK ( points > 0 ),
+    max_redemptions       INTEGER       NOT NULL CHECK ( max_redemptions >= 0 ),
+    max_group_redemptions INTEGER       NOT NULL CHECK ( max_group_redemptions > 0 ),
+    created_by            VARCHAR (256) NOT NULL,
+
+    PRIMARY KEY ( event_id, code ),
+
+    CONSTRAINT fk_activity_name
+        FOREIGN KEY ( event_id, activity_name )
+            REFERENCES activities ( event_id, name )
+            ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS secret_code_redemptions (
+    event_id   VARCHAR (64)  NOT NULL,
+    code       VARCHAR (32)  NOT NULL,
+    group_name VARCHAR (64)  NOT NULL,
+    username   VARCHAR (256) NOT NULL,
+    time       TIMESTAMP     NOT NULL,
+
+    CONSTRAINT fk_code
+        FOREIGN KEY ( event_id, code )
+            REFERENCES secret_codes ( event_id, code )
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS character_code_attempts (
+    event_id   VARCHAR (64) NOT NULL,
+    group_name VARCHAR (64) NOT NULL,
+    time       TIMESTAMP    NOT NULL,
+
+    CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS submissions (
+    event_id      VARCHAR (64)  NOT NULL,
+    id            VARCHAR (64)  NOT NULL,
+    activity_name VARCHAR (256) NOT NULL,
+    group_name    VARCHAR (64)  NOT NULL,
+    username      VARCHAR (256) NOT NULL,
+    kind          VARCHAR (16)  NOT NULL,
+    text          TEXT          NOT NULL,
+    file_id       VARCHAR (256) NOT NULL,
+    status        VARCHAR (16)  NOT NULL,
+    submitted_at  TIMESTAMP     NOT NULL,
+    reviewed_by   VARCHAR (256) NULL,
+    skill_type    VARCHAR (256) NULL,
+    points        INTEGER       NULL CHECK ( points > 0 ),
+    reason        TEXT          NULL,
+    reviewed_at   TIMESTAMP     NULL,
+
+    PRIMARY KEY ( event_id, id ),
+
+    CONSTRAINT fk_activity_name
+        FOREIGN KEY ( event_id, activity_name )
+            REFERENCES activities ( event_id, name )
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+-- У группы может быть только одно непроверенное решение на точке.
+CREATE UNIQUE INDEX IF NOT EXISTS submissions_pending_idx
+    ON submissions ( event_id, activity_name, group_name )
+    WHERE status = 'pending';
@@ -0,0 +1,57 @@
+CREATE TABLE IF NOT EXISTS quiz_questions (
+    event_id      VARCHAR (64)  NOT NULL,
+    activity_name VARCHAR (256) NOT NULL,
+    number        INTEGER       NOT NULL CHECK ( number >= 0 ),
+    text          TEXT          NOT NULL,
+    options       TEXT[]        NOT NULL,
+    answers       TEXT[]        NOT NULL,
+    skill_type    VARCHAR (256) NOT NULL,
+    time_seconds  INTEGER       NOT NULL CHECK ( time_seconds > 0 ),
+
+    PRIMARY KEY ( event_id, activity_name, number ),
+
+    CONSTRAINT fk_activity_name
+        FOREIGN KEY ( event_id, activity_name )
+            REFERENCES activities ( event_id, name )
+            ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS quiz_attempts (
+    event_id      VARCHAR (64)  NOT NULL,
+    activity_name VARCHAR (256) NOT NULL,
+    group_name    VARCHAR (64)  NOT NULL,
+    username      VARCHAR (256) NOT NULL,
+    started_at    TIMESTAMP     NOT NULL,
+    awarded       BOOLEAN       NOT NULL DEFAULT FALSE,
+
+    PRIMARY KEY ( event_id, activity_name, group_name ),
+
+    CONSTRAINT fk_activity_name
+        FOREIGN KEY ( event_id, activity_name )
+            REFERENCES activities ( event_id, name )
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS quiz_answers (
+    event_id      VARCHAR (64)  NOT NULL,
+    activity_name VARCHAR (256) NOT NULL,
+    group_name    VARCHAR (64)  NOT NULL,
+    number        INTEGER       NOT NULL CHECK ( number >= 0 ),
+    text          TEXT          NOT NULL,
+    username      VARCHAR (256) NOT NULL,
+    correct       BOOLEAN       NOT NULL,
+    timed_out     BOOLEAN       NOT NULL,
+    time          TIMESTAMP     NOT NULL,
+
+    PRIMARY KEY ( event_id, activity_name, group_name, number ),
+
+    CONSTRAINT fk_attempt
+        FOREIGN KEY ( event_id, activity_name, group_name )
+            REFERENCES quiz_attempts ( event_id, activity_name, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,214 @@
+ALTER TABLE grades                       ADD COLUMN group_name VARCHAR (64);
+ALTER TABLE character_slots              ADD COLUMN group_name VARCHAR (64);
+ALTER TABLE waitlist_entries             ADD COLUMN group_name VARCHAR (64);
+ALTER TABLE activity_slot_bookings       ADD COLUMN group_name VARCHAR (64);
+ALTER TABLE character_achievements       ADD COLUMN group_name VARCHAR (64);
+ALTER TABLE character_members            ADD COLUMN group_name VARCHAR (64);
+ALTER TABLE character_penalties          ADD COLUMN group_name VARCHAR (64);
+ALTER TABLE character_window_adjustments ADD COLUMN group_name VARCHAR (64);
+ALTER TABLE secret_code_redemptions      ADD COLUMN group_name VARCHAR (64);
+ALTER TABLE character_code_attempts      ADD COLUMN group_name VARCHAR (64);
+ALTER TABLE submissions                  ADD COLUMN group_name VARCHAR (64);
+ALTER TABLE quiz_attempts                ADD COLUMN group_name VARCHAR (64);
+ALTER TABLE quiz_answers                 ADD COLUMN group_name VARCHAR (64);
+
+UPDATE grades t
+SET    group_name = c.group_name
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.id = t.character_id;
+
+UPDATE character_slots t
+SET    group_name = c.group_name
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.id = t.character_id;
+
+UPDATE waitlist_entries t
+SET    group_name = c.group_name
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.id = t.character_id;
+
+UPDATE activity_slot_bookings t
+SET    group_name = c.group_name
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.id = t.character_id;
+
+UPDATE character_achievements t
+SET    group_name = c.group_name
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.id = t.character_id;
+
+UPDATE character_members t
+SET    group_name = c.group_name
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.id = t.character_id;
+
+UPDATE character_penalties t
+SET    group_name = c.group_name
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.id = t.character_id;
+
+UPDATE character_window_adjustments t
+SET    group_name = c.group_name
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.id = t.character_id;
+
+UPDATE secret_code_redemptions t
+SET    group_name = c.group_name
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.id = t.character_id;
+
+UPDATE character_code_attempts t
+SET    group_name = c.group_name
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.id = t.character_id;
+
+UPDATE submissions t
+SET    group_name = c.group_name
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.id = t.character_id;
+
+UPDATE quiz_attempts t
+SET    group_name = c.group_name
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.id = t.character_id;
+
+UPDATE quiz_answers t
+SET    group_name = c.group_name
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.id = t.character_id;
+
+ALTER TABLE grades                       ALTER COLUMN group_name SET NOT NULL;
+ALTER TABLE character_slots              ALTER COLUMN group_name SET NOT NULL;
+ALTER TABLE waitlist_entries             ALTER COLUMN group_name SET NOT NULL;
+ALTER TABLE activity_slot_bookings       ALTER COLUMN group_name SET NOT NULL;
+ALTER TABLE character_achievements       ALTER COLUMN group_name SET NOT NULL;
+ALTER TABLE character_members            ALTER COLUMN group_name SET NOT NULL;
+ALTER TABLE character_penalties          ALTER COLUMN group_name SET NOT NULL;
+ALTER TABLE character_window_adjustments ALTER COLUMN group_name SET NOT NULL;
+ALTER TABLE secret_code_redemptions      ALTER COLUMN group_name SET NOT NULL;
+ALTER TABLE character_code_attempts      ALTER COLUMN group_name SET NOT NULL;
+ALTER TABLE submissions                  ALTER COLUMN group_name SET NOT NULL;
+ALTER TABLE quiz_attempts                ALTER COLUMN group_name SET NOT NULL;
+ALTER TABLE quiz_answers                 ALTER COLUMN group_name SET NOT NULL;
+
+ALTER TABLE quiz_answers                 DROP CONSTRAINT IF EXISTS fk_attempt;
+ALTER TABLE grades                       DROP CONSTRAINT IF EXISTS fk_character_id;
+ALTER TABLE character_slots              DROP CONSTRAINT IF EXISTS fk_character_id;
+ALTER TABLE waitlist_entries             DROP CONSTRAINT IF EXISTS fk_character_id;
+ALTER TABLE activity_slot_bookings       DROP CONSTRAINT IF EXISTS fk_character_id;
+ALTER TABLE character_achievements       DROP CONSTRAINT IF EXISTS fk_character_id;
+ALTER TABLE character_members            DROP CONSTRAINT IF EXISTS fk_character_id;
+ALTER TABLE character_penalties          DROP CONSTRAINT IF EXISTS fk_character_id;
+ALTER TABLE character_window_adjustments DROP CONSTRAINT IF EXISTS fk_character_id;
+ALTER TABLE secret_code_redemptions      DROP CONSTRAINT IF EXISTS fk_character_id;
+ALTER TABLE character_code_attempts      DROP CONSTRAINT IF EXISTS fk_character_id;
+ALTER TABLE submissions                  DROP CONSTRAINT IF EXISTS fk_character_id;
+ALTER TABLE quiz_attempts                DROP CONSTRAINT IF EXISTS fk_character_id;
+
+ALTER TABLE grades                       DROP COLUMN character_id;
+ALTER TABLE character_slots              DROP COLUMN character_id;
+ALTER TABLE waitlist_entries             DROP COLUMN character_id;
+ALTER TABLE activity_slot_bookings       DROP COLUMN character_id;
+ALTER TABLE character_achievements       DROP COLUMN character_id;
+ALTER TABLE character_members            DROP COLUMN character_id;
+ALTER TABLE character_penalties          DROP COLUMN character_id;
+ALTER TABLE character_window_adjustments DROP COLUMN character_id;
+ALTER TABLE secret_code_redemptions      DROP COLUMN character_id;
+ALTER TABLE character_code_attempts      DROP COLUMN character_id;
+ALTER TABLE submissions                  DROP COLUMN character_id;
+ALTER TABLE quiz_attempts                DROP COLUMN character_id;
+ALTER TABLE quiz_answers                 DROP COLUMN character_id;
+
+ALTER TABLE characters DROP CONSTRAINT IF EXISTS characters_pkey;
+ALTER TABLE characters DROP CONSTRAINT IF EXISTS characters_event_id_group_name_key;
+ALTER TABLE characters ADD PRIMARY KEY ( event_id, group_name );
+ALTER TABLE characters ADD UNIQUE ( event_id, id );
+
+ALTER TABLE character_slots              ADD PRIMARY KEY ( event_id, group_name, start );
+ALTER TABLE waitlist_entries             ADD PRIMARY KEY ( event_id, activity_name, group_name );
+ALTER TABLE activity_slot_bookings       ADD PRIMARY KEY ( event_id, activity_name, start, group_name );
+ALTER TABLE character_achievements       ADD PRIMARY KEY ( event_id, group_name, achievement_id );
+ALTER TABLE quiz_attempts                ADD PRIMARY KEY ( event_id, activity_name, group_name );
+ALTER TABLE quiz_answers                 ADD PRIMARY KEY ( event_id, activity_name, group_name, number );
+
+CREATE UNIQUE INDEX IF NOT EXISTS submissions_pending_idx
+    ON submissions ( event_id, activity_name, group_name )
+    WHERE status = 'pending';
+
+ALTER TABLE grades
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE character_slots
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE waitlist_entries
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE activity_slot_bookings
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE character_achievements
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE character_members
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE character_penalties
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE character_window_adjustments
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE secret_code_redemptions
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE character_code_attempts
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE submissions
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE quiz_attempts
+    ADD CONSTRAINT fk_group_name
+        FOREIGN KEY ( event_id, group_name )
+            REFERENCES characters ( event_id, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE quiz_answers
+    ADD CONSTRAINT fk_attempt
+        FOREIGN KEY ( event_id, activity_name, group_name )
+            REFERENCES quiz_attempts ( event_id, activity_name, group_name )
+            ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,217 @@
+-- Связанные записи ссылаются на группу по id. Название группы только
+-- отображается, поэтому его переименование ничего не каскадирует.
+ALTER TABLE grades                       ADD COLUMN character_id VARCHAR (64);
+ALTER TABLE character_slots              ADD COLUMN character_id VARCHAR (64);
+ALTER TABLE waitlist_entries             ADD COLUMN character_id VARCHAR (64);
+ALTER TABLE activity_slot_bookings       ADD COLUMN character_id VARCHAR (64);
+ALTER TABLE character_achievements       ADD COLUMN character_id VARCHAR (64);
+ALTER TABLE character_members            ADD COLUMN character_id VARCHAR (64);
+ALTER TABLE character_penalties          ADD COLUMN character_id VARCHAR (64);
+ALTER TABLE character_window_adjustments ADD COLUMN character_id VARCHAR (64);
+ALTER TABLE secret_code_redemptions      ADD COLUMN character_id VARCHAR (64);
+ALTER TABLE character_code_attempts      ADD COLUMN character_id VARCHAR (64);
+ALTER TABLE submissions                  ADD COLUMN character_id VARCHAR (64);
+ALTER TABLE quiz_attempts                ADD COLUMN character_id VARCHAR (64);
+ALTER TABLE quiz_answers                 ADD COLUMN character_id VARCHAR (64);
+
+UPDATE grades t
+SET    character_id = c.id
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.group_name = t.group_name;
+
+UPDATE character_slots t
+SET    character_id = c.id
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.group_name = t.group_name;
+
+UPDATE waitlist_entries t
+SET    character_id = c.id
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.group_name = t.group_name;
+
+UPDATE activity_slot_bookings t
+SET    character_id = c.id
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.group_name = t.group_name;
+
+UPDATE character_achievements t
+SET    character_id = c.id
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.group_name = t.group_name;
+
+UPDATE character_members t
+SET    character_id = c.id
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.group_name = t.group_name;
+
+UPDATE character_penalties t
+SET    character_id = c.id
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.group_name = t.group_name;
+
+UPDATE character_window_adjustments t
+SET    character_id = c.id
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.group_name = t.group_name;
+
+UPDATE secret_code_redemptions t
+SET    character_id = c.id
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.group_name = t.group_name;
+
+UPDATE character_code_attempts t
+SET    character_id = c.id
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.group_name = t.group_name;
+
+UPDATE submissions t
+SET    character_id = c.id
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.group_name = t.group_name;
+
+UPDATE quiz_attempts t
+SET    character_id = c.id
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.group_name = t.group_name;
+
+UPDATE quiz_answers t
+SET    character_id = c.id
+FROM   characters c
+WHERE  c.event_id = t.event_id AND c.group_name = t.group_name;
+
+ALTER TABLE grades                       ALTER COLUMN character_id SET NOT NULL;
+ALTER TABLE character_slots              ALTER COLUMN character_id SET NOT NULL;
+ALTER TABLE waitlist_entries             ALTER COLUMN character_id SET NOT NULL;
+ALTER TABLE activity_slot_bookings       ALTER COLUMN character_id SET NOT NULL;
+ALTER TABLE character_achievements       ALTER COLUMN character_id SET NOT NULL;
+ALTER TABLE character_members            ALTER COLUMN character_id SET NOT NULL;
+ALTER TABLE character_penalties          ALTER COLUMN character_id SET NOT NULL;
+ALTER TABLE character_window_adjustments ALTER COLUMN character_id SET NOT NULL;
+ALTER TABLE secret_code_redemptions      ALTER COLUMN character_id SET NOT NULL;
+ALTER TABLE character_code_attempts      ALTER COLUMN character_id SET NOT NULL;
+ALTER TABLE submissions                  ALTER COLUMN character_id SET NOT NULL;
+ALTER TABLE quiz_attempts                ALTER COLUMN character_id SET NOT NULL;
+ALTER TABLE quiz_answers                 ALTER COLUMN character_id SET NOT NULL;
+
+ALTER TABLE quiz_answers                 DROP CONSTRAINT IF EXISTS fk_attempt;
+ALTER TABLE grades                       DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE character_slots              DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE waitlist_entries             DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE activity_slot_bookings       DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE character_achievements       DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE character_members            DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE character_penalties          DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE character_window_adjustments DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE secret_code_redemptions      DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE character_code_attempts      DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE submissions                  DROP CONSTRAINT IF EXISTS fk_group_name;
+ALTER TABLE quiz_attempts                DROP CONSTRAINT IF EXISTS fk_group_name;
+
+-- Первичные ключи и индексы с group_name удаляются вместе со столбцом.
+ALTER TABLE grades                       DROP COLUMN group_name;
+ALTER TABLE character_slots              DROP COLUMN group_name;
+ALTER TABLE waitlist_entries             DROP COLUMN group_name;
+ALTER TABLE activity_slot_bookings       DROP COLUMN group_name;
+ALTER TABLE character_achievements       DROP COLUMN group_name;
+ALTER TABLE character_members            DROP COLUMN group_name;
+ALTER TABLE character_penalties          DROP COLUMN group_name;
+ALTER TABLE character_window_adjustments DROP COLUMN group_name;
+ALTER TABLE secret_code_redemptions      DROP COLUMN group_name;
+ALTER TABLE character_code_attempts      DROP COLUMN group_name;
+ALTER TABLE submissions                  DROP COLUMN group_name;
+ALTER TABLE quiz_attempts                DROP COLUMN group_name;
+ALTER TABLE quiz_answers                 DROP COLUMN group_name;
+
+ALTER TABLE characters DROP CONSTRAINT IF EXISTS characters_pkey;
+ALTER TABLE characters DROP CONSTRAINT IF EXISTS characters_event_id_id_key;
+ALTER TABLE characters ADD PRIMARY KEY ( event_id, id );
+ALTER TABLE characters ADD UNIQUE ( event_id, group_name );
+
+ALTER TABLE character_slots              ADD PRIMARY KEY ( event_id, character_id, start );
+ALTER TABLE waitlist_entries             ADD PRIMARY KEY ( event_id, activity_name, character_id );
+ALTER TABLE activity_slot_bookings       ADD PRIMARY KEY ( event_id, activity_name, start, character_id );
+ALTER TABLE character_achievements       ADD PRIMARY KEY ( event_id, character_id, achievement_id );
+ALTER TABLE quiz_attempts                ADD PRIMARY KEY ( event_id, activity_name, character_id );
+ALTER TABLE quiz_answers                 ADD PRIMARY KEY ( event_id, activity_name, character_id, number );
+
+CREATE UNIQUE INDEX IF NOT EXISTS submissions_pending_idx
+    ON submissions ( event_id, activity_name, character_id )
+    WHERE status = 'pending';
+
+ALTER TABLE grades
+    ADD CONSTRAINT fk_character_id
+        FOREIGN KEY ( event_id, character_id )
+            REFERENCES characters ( event_id, id )
+            ON DELETE CASCADE;
+
+ALTER TABLE character_slots
+    ADD CONSTRAINT fk_character_id
+        FOREIGN KEY ( event_id, character_id )
+            REFERENCES characters ( event_id, id )
+            ON DELETE CASCADE;
+
+ALTER TABLE waitlist_entries
+    ADD CONSTRAINT fk_character_id
+        FOREIGN KEY ( event_id, character_id )
+            REFERENCES characters ( event_id, id )
+            ON DELETE CASCADE;
+
+ALTER TABLE activity_slot_bookings
+    ADD CONSTRAINT fk_character_id
+        FOREIGN KEY ( event_id, character_id )
+            REFERENCES characters ( event_id, id )
+            ON DELETE CASCADE;
+
+ALTER TABLE character_achievements
+    ADD CONSTRAINT fk_character_id
+        FOREIGN KEY ( event_id, character_id )
+            REFERENCES characters ( event_id, id )
+            ON DELETE CASCADE;
+
+ALTER TABLE character_members
+    ADD CONSTRAINT fk_character_id
+        FOREIGN KEY ( event_id, character_id )
+            REFERENCES characters ( event_id, id )
+            ON DELETE CASCADE;
+
+ALTER TABLE character_penalties
+    ADD CONSTRAINT fk_character_id
+        FOREIGN KEY ( event_id, character_id )
+            REFERENCES characters ( event_id, id )
+            ON DELETE CASCADE;
+
+ALTER TABLE character_window_adjustments
+    ADD CONSTRAINT fk_character_id
+        FOREIGN KEY ( event_id, character_id )
+            REFERENCES characters ( event_id, id )
+            ON DELETE CASCADE;
+
+ALTER TABLE secret_code_redemptions
+    ADD CONSTRAINT fk_character_id
+        FOREIGN KEY ( event_id, character_id )
+            REFERENCES characters ( event_id, id )
+            ON DELETE CASCADE;
+
+ALTER TABLE character_code_attempts
+    ADD CONSTRAINT fk_character_id
+        FOREIGN KEY ( event_id, character_id )
+            REFERENCES characters ( event_id, id )
+            ON DELETE CASCADE;
+
+ALTER TABLE submissions
+    ADD CONSTRAINT fk_character_id
+        FOREIGN KEY ( event_id, character_id )
+            REFERENCES characters ( event_id, id )
+            ON DELETE CASCADE;
+
+ALTER TABLE quiz_attempts
+    ADD CONSTRAINT fk_character_id
+        FOREIGN KEY ( event_id, character_id )
+            REFERENCES characters ( event_id, id )
+            ON DELETE CASCADE;
+
+ALTER TABLE quiz_answers
+    ADD CONSTRAINT fk_attempt
+        FOREIGN KEY ( event_id, activity_name, character_id )
+            REFERENCES quiz_attempts ( event_id, activity_name, character_id )
+            ON DELETE CASCADE;